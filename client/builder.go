@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ClientBuilder configures a Client and connects it to the server in a
+// single step with Build.
+type ClientBuilder struct {
+	transport  transport.Interface
+	options    []ClientOption
+	clientInfo mcp.Implementation
+	retries    int
+	retryDelay time.Duration
+	timeout    time.Duration
+}
+
+// NewClientBuilder creates a ClientBuilder that connects over the given
+// transport.
+//
+//	c, err := client.NewClientBuilder(t).
+//		WithRetry(3, time.Second).
+//		WithTimeout(10 * time.Second).
+//		Build(ctx)
+func NewClientBuilder(transport transport.Interface) *ClientBuilder {
+	return &ClientBuilder{
+		transport: transport,
+		clientInfo: mcp.Implementation{
+			Name:    "mcp-go",
+			Version: "1.0.0",
+		},
+	}
+}
+
+// Option adds a ClientOption applied when the client is built.
+func (b *ClientBuilder) Option(opt ClientOption) *ClientBuilder {
+	b.options = append(b.options, opt)
+	return b
+}
+
+// ClientInfo sets the implementation details sent to the server during
+// initialization.
+func (b *ClientBuilder) ClientInfo(name, version string) *ClientBuilder {
+	b.clientInfo = mcp.Implementation{Name: name, Version: version}
+	return b
+}
+
+// WithRetry makes Build retry a failed connection up to n more times,
+// waiting delay between attempts.
+func (b *ClientBuilder) WithRetry(n int, delay time.Duration) *ClientBuilder {
+	b.retries = n
+	b.retryDelay = delay
+	return b
+}
+
+// WithTimeout limits how long Build may take to connect and complete the
+// initialize handshake, including retries.
+func (b *ClientBuilder) WithTimeout(d time.Duration) *ClientBuilder {
+	b.timeout = d
+	return b
+}
+
+// Build creates the client, starts the transport and completes the
+// initialize handshake. If every attempt fails, the error from the last
+// attempt is returned and the transport is closed.
+func (b *ClientBuilder) Build(ctx context.Context) (*Client, error) {
+	if b.transport == nil {
+		return nil, fmt.Errorf("transport is nil")
+	}
+
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	c := NewClient(b.transport, b.options...)
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = b.clientInfo
+
+	started := false
+	var err error
+	for attempt := 0; attempt <= b.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, b.buildError(c, started, err)
+			case <-time.After(b.retryDelay):
+			}
+		}
+
+		if !started {
+			if err = c.Start(ctx); err != nil {
+				continue
+			}
+			started = true
+		}
+
+		if _, err = c.Initialize(ctx, request); err == nil {
+			return c, nil
+		}
+	}
+
+	return nil, b.buildError(c, started, err)
+}
+
+// buildError closes a started transport and wraps the last connection error.
+func (b *ClientBuilder) buildError(c *Client, started bool, err error) error {
+	if started {
+		_ = c.Close()
+	}
+	return fmt.Errorf("failed to connect client: %w", err)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStartTransport is a transport whose Start fails a number of times
+type failingStartTransport struct {
+	*transport.InProcessTransport
+	failures atomic.Int32
+	attempts atomic.Int32
+	err      error
+}
+
+func (f *failingStartTransport) Start(ctx context.Context) error {
+	f.attempts.Add(1)
+	if f.failures.Add(-1) >= 0 {
+		return f.err
+	}
+	return f.InProcessTransport.Start(ctx)
+}
+
+func TestClientBuilder_Build(t *testing.T) {
+	mcpServer := server.NewMCPServer("test-server", "1.0.0")
+
+	capabilities := mcp.ClientCapabilities{
+		Experimental: map[string]any{"builder": map[string]any{}},
+	}
+	c, err := NewClientBuilder(transport.NewInProcessTransport(mcpServer)).
+		ClientInfo("builder-test", "0.1.0").
+		Option(WithClientCapabilities(capabilities)).
+		WithTimeout(time.Second).
+		Build(context.Background())
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.True(t, c.IsInitialized())
+	assert.Equal(t, capabilities, c.GetClientCapabilities())
+	require.NoError(t, c.Ping(context.Background()))
+}
+
+func TestClientBuilder_BuildRetries(t *testing.T) {
+	mcpServer := server.NewMCPServer("test-server", "1.0.0")
+	tr := &failingStartTransport{
+		InProcessTransport: transport.NewInProcessTransport(mcpServer),
+		err:                errors.New("connection refused"),
+	}
+	tr.failures.Store(2)
+
+	c, err := NewClientBuilder(tr).
+		WithRetry(2, 10*time.Millisecond).
+		Build(context.Background())
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Equal(t, int32(3), tr.attempts.Load())
+	assert.True(t, c.IsInitialized())
+}
+
+func TestClientBuilder_BuildTransportError(t *testing.T) {
+	dialErr := errors.New("connection refused")
+	tr := &failingStartTransport{
+		InProcessTransport: transport.NewInProcessTransport(server.NewMCPServer("test-server", "1.0.0")),
+		err:                dialErr,
+	}
+	tr.failures.Store(100)
+
+	c, err := NewClientBuilder(tr).
+		WithRetry(1, 10*time.Millisecond).
+		Build(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, c)
+	assert.ErrorIs(t, err, dialErr)
+	assert.Equal(t, int32(2), tr.attempts.Load())
+}
+
+func TestClientBuilder_BuildUnreachableServer(t *testing.T) {
+	// Nothing listens on this port, so the connection is refused
+	tr, err := transport.NewStreamableHTTP("http://127.0.0.1:1/mcp")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewClientBuilder(tr).
+			WithTimeout(5 * time.Second).
+			Build(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Build did not return for an unreachable server")
+	}
+}
@@ -23,10 +23,18 @@ type Client struct {
 	requestID          atomic.Int64
 	clientCapabilities mcp.ClientCapabilities
 	serverCapabilities mcp.ServerCapabilities
+	serverInfo         mcp.Implementation
 	protocolVersion    string
 	samplingHandler    SamplingHandler
 	rootsHandler       RootsHandler
 	elicitationHandler ElicitationHandler
+	resourceCache      *resourceCache
+	progressMu         sync.RWMutex
+	progressHandler    func(mcp.ProgressNotification)
+	progressHandlers   map[mcp.ProgressToken]func(mcp.ProgressNotification)
+	progressTokens     atomic.Int64
+	logMu              sync.RWMutex
+	logHandler         func(mcp.LoggingMessageNotification)
 }
 
 type ClientOption func(*Client)
@@ -104,6 +112,16 @@ func (c *Client) Start(ctx context.Context) error {
 	}
 
 	c.transport.SetNotificationHandler(func(notification mcp.JSONRPCNotification) {
+		if c.resourceCache != nil {
+			c.resourceCache.handleNotification(notification)
+		}
+		switch notification.Method {
+		case "notifications/progress":
+			c.handleProgress(notification)
+		case "notifications/message":
+			c.handleLogMessage(notification)
+		}
+
 		c.notifyMu.RLock()
 		defer c.notifyMu.RUnlock()
 		for _, handler := range c.notifications {
@@ -119,6 +137,20 @@ func (c *Client) Start(ctx context.Context) error {
 	return nil
 }
 
+// decodeNotification decodes the params of a generic notification into the
+// notification type T.
+func decodeNotification[T any](notification mcp.JSONRPCNotification) (T, bool) {
+	var decoded T
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return decoded, false
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return decoded, false
+	}
+	return decoded, true
+}
+
 // Close shuts down the client and closes the transport.
 func (c *Client) Close() error {
 	return c.transport.Close()
@@ -228,8 +260,9 @@ func (c *Client) Initialize(
 		return nil, mcp.UnsupportedProtocolVersionError{Version: result.ProtocolVersion}
 	}
 
-	// Store serverCapabilities and protocol version
+	// Store serverCapabilities, server info and protocol version
 	c.serverCapabilities = result.Capabilities
+	c.serverInfo = result.ServerInfo
 	c.protocolVersion = result.ProtocolVersion
 
 	// Set protocol version on HTTP transports
@@ -241,7 +274,7 @@ func (c *Client) Initialize(
 	notification := mcp.JSONRPCNotification{
 		JSONRPC: mcp.JSONRPC_VERSION,
 		Notification: mcp.Notification{
-			Method: "notifications/initialized",
+			Method: mcp.MethodNotificationInitialized,
 		},
 	}
 
@@ -339,12 +372,31 @@ func (c *Client) ReadResource(
 	ctx context.Context,
 	request mcp.ReadResourceRequest,
 ) (*mcp.ReadResourceResult, error) {
+	cache := c.resourceCache
+	if !cacheable(request.Params) {
+		cache = nil
+	}
+	var generation uint64
+	if cache != nil {
+		if result, ok := cache.get(request.Params.URI); ok {
+			return result, nil
+		}
+		generation = cache.currentGeneration()
+	}
+
 	response, err := c.sendRequest(ctx, "resources/read", request.Params, request.Header)
 	if err != nil {
 		return nil, err
 	}
 
-	return mcp.ParseReadResourceResult(response)
+	result, err := mcp.ParseReadResourceResult(response)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.put(request.Params.URI, result, generation)
+	}
+	return result, nil
 }
 
 func (c *Client) Subscribe(
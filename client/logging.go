@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithStructuredLogging sets a log handler writing the log messages of the
+// server to logger, as SetLogHandler does. String data is logged as the
+// message; other data is logged under the "data" attribute, and the name of
+// the logger of the server under the "logger" attribute.
+func WithStructuredLogging(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logHandler = func(notification mcp.LoggingMessageNotification) {
+			params := notification.Params
+			var attrs []slog.Attr
+			if params.Logger != "" {
+				attrs = append(attrs, slog.String("logger", params.Logger))
+			}
+			message, ok := params.Data.(string)
+			if !ok {
+				message = "server log message"
+				attrs = append(attrs, slog.Any("data", params.Data))
+			}
+			logger.LogAttrs(context.Background(), slogLevel(params.Level), message, attrs...)
+		}
+	}
+}
+
+// slogLevel maps an MCP logging level to a slog level. Levels without a slog
+// counterpart are placed between or above the slog levels.
+func slogLevel(level mcp.LoggingLevel) slog.Level {
+	switch level {
+	case mcp.LoggingLevelDebug:
+		return slog.LevelDebug
+	case mcp.LoggingLevelInfo:
+		return slog.LevelInfo
+	case mcp.LoggingLevelNotice:
+		return slog.LevelInfo + 2
+	case mcp.LoggingLevelWarning:
+		return slog.LevelWarn
+	case mcp.LoggingLevelError:
+		return slog.LevelError
+	case mcp.LoggingLevelCritical:
+		return slog.LevelError + 4
+	case mcp.LoggingLevelAlert:
+		return slog.LevelError + 8
+	case mcp.LoggingLevelEmergency:
+		return slog.LevelError + 12
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SetLogHandler sets the handler receiving the log messages of the server,
+// sent as notifications/message, for example to write them to a slog.Logger.
+// Without a handler, log messages are discarded. Setting a new handler
+// replaces the previous one.
+func (c *Client) SetLogHandler(handler func(mcp.LoggingMessageNotification)) {
+	c.logMu.Lock()
+	defer c.logMu.Unlock()
+	c.logHandler = handler
+}
+
+// handleLogMessage passes a log message notification to the log handler.
+func (c *Client) handleLogMessage(notification mcp.JSONRPCNotification) {
+	c.logMu.RLock()
+	handler := c.logHandler
+	c.logMu.RUnlock()
+	if handler == nil {
+		return
+	}
+
+	message, ok := decodeNotification[mcp.LoggingMessageNotification](notification)
+	if !ok {
+		return
+	}
+	handler(message)
+}
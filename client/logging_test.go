@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SetLogHandler(t *testing.T) {
+	mcpServer := server.NewMCPServer("test-server", "1.0.0", server.WithLogging())
+	mcpServer.AddTool(mcp.NewTool("work"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		err := server.ServerFromContext(ctx).SendLogMessageToClient(ctx,
+			mcp.NewLoggingMessageNotification(mcp.LoggingLevelWarning, "worker", "disk almost full"))
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+	testServer := server.NewTestServer(mcpServer)
+	defer testServer.Close()
+
+	client, err := NewSSEMCPClient(testServer.URL + "/sse")
+	require.NoError(t, err)
+	defer client.Close()
+
+	received := make(chan mcp.LoggingMessageNotification, 1)
+	client.SetLogHandler(func(notification mcp.LoggingMessageNotification) {
+		received <- notification
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, client.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	_, err = client.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+	levelRequest := mcp.SetLevelRequest{}
+	levelRequest.Params.Level = mcp.LoggingLevelInfo
+	require.NoError(t, client.SetLevel(ctx, levelRequest))
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "work"
+	_, err = client.CallTool(ctx, request)
+	require.NoError(t, err)
+
+	select {
+	case notification := <-received:
+		assert.Equal(t, mcp.LoggingLevelWarning, notification.Params.Level)
+		assert.Equal(t, "worker", notification.Params.Logger)
+		assert.Equal(t, "disk almost full", notification.Params.Data)
+	case <-ctx.Done():
+		t.Fatal("log message not received")
+	}
+}
+
+func TestWithStructuredLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewClient(&progressTransport{}, WithStructuredLogging(logger))
+	require.NoError(t, c.Start(context.Background()))
+
+	levels := map[mcp.LoggingLevel]string{
+		mcp.LoggingLevelDebug:     "DEBUG",
+		mcp.LoggingLevelInfo:      "INFO",
+		mcp.LoggingLevelNotice:    "INFO+2",
+		mcp.LoggingLevelWarning:   "WARN",
+		mcp.LoggingLevelError:     "ERROR",
+		mcp.LoggingLevelCritical:  "ERROR+4",
+		mcp.LoggingLevelAlert:     "ERROR+8",
+		mcp.LoggingLevelEmergency: "ERROR+12",
+	}
+	for level, expected := range levels {
+		buf.Reset()
+		c.handleLogMessage(mcp.JSONRPCNotification{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			Notification: mcp.Notification{
+				Method: "notifications/message",
+				Params: mcp.NotificationParams{AdditionalFields: map[string]any{
+					"level":  level,
+					"logger": "db",
+					"data":   "connection lost",
+				}},
+			},
+		})
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), buf.String())
+		assert.Equal(t, expected, entry["level"], level)
+		assert.Equal(t, "connection lost", entry["msg"])
+		assert.Equal(t, "db", entry["logger"])
+	}
+
+	// Data that is not a string is logged as an attribute
+	buf.Reset()
+	c.handleLogMessage(mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: "notifications/message",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{
+				"level": "info",
+				"data":  map[string]any{"rows": 3},
+			}},
+		},
+	})
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, map[string]any{"rows": float64(3)}, entry["data"])
+	assert.NotContains(t, entry, "logger")
+}
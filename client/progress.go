@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SetProgressHandler sets the handler receiving the progress notifications
+// not routed to the progress handler of a call, such as those of calls made
+// with CallTool. Setting a new handler replaces the previous one, and a nil
+// handler drops these notifications.
+func (c *Client) SetProgressHandler(handler func(mcp.ProgressNotification)) {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	c.progressHandler = handler
+}
+
+// CallToolWithProgress calls a tool as CallTool does, routing the progress
+// notifications of the call to onProgress. Unless the request already has a
+// progress token, one is generated.
+func (c *Client) CallToolWithProgress(
+	ctx context.Context,
+	request mcp.CallToolRequest,
+	onProgress func(mcp.ProgressNotification),
+) (*mcp.CallToolResult, error) {
+	meta := &mcp.Meta{}
+	if request.Params.Meta != nil {
+		meta.ProgressToken = request.Params.Meta.ProgressToken
+		for key, value := range request.Params.Meta.GetAdditionalFields() {
+			meta.SetAdditionalField(key, value)
+		}
+	}
+	if meta.ProgressToken.IsZero() {
+		meta.ProgressToken = mcp.NewStringProgressToken(fmt.Sprintf("progress-%d", c.progressTokens.Add(1)))
+	}
+	request.Params.Meta = meta
+
+	key := meta.ProgressToken
+	c.progressMu.Lock()
+	if c.progressHandlers == nil {
+		c.progressHandlers = make(map[mcp.ProgressToken]func(mcp.ProgressNotification))
+	}
+	c.progressHandlers[key] = onProgress
+	c.progressMu.Unlock()
+	defer func() {
+		c.progressMu.Lock()
+		delete(c.progressHandlers, key)
+		c.progressMu.Unlock()
+	}()
+
+	return c.CallTool(ctx, request)
+}
+
+// handleProgress routes a progress notification to the progress handler of
+// its call, or to the handler set with SetProgressHandler.
+func (c *Client) handleProgress(notification mcp.JSONRPCNotification) {
+	progress, ok := decodeNotification[mcp.ProgressNotification](notification)
+	if !ok {
+		return
+	}
+
+	c.progressMu.RLock()
+	handler, ok := c.progressHandlers[progress.Params.ProgressToken]
+	if !ok {
+		handler = c.progressHandler
+	}
+	c.progressMu.RUnlock()
+	if handler != nil {
+		handler(progress)
+	}
+}
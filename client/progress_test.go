@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressTransport answers tool calls after sending a progress notification
+// with the progress token of the call, or "server-token" if it has none.
+type progressTransport struct {
+	mockTransport
+	onNotification func(mcp.JSONRPCNotification)
+}
+
+func (p *progressTransport) SetNotificationHandler(handler func(notification mcp.JSONRPCNotification)) {
+	p.onNotification = handler
+}
+
+func (p *progressTransport) SendRequest(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+	data, err := json.Marshal(request.Params)
+	if err != nil {
+		return nil, err
+	}
+	var params struct {
+		Meta struct {
+			ProgressToken mcp.ProgressToken `json:"progressToken"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, err
+	}
+	token := params.Meta.ProgressToken
+	if token.IsZero() {
+		token = mcp.NewStringProgressToken("server-token")
+	}
+
+	p.onNotification(mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: "notifications/progress",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{
+				"progressToken": token,
+				"progress":      1,
+				"total":         2,
+				"message":       "halfway",
+			}},
+		},
+	})
+
+	result, err := json.Marshal(mcp.NewToolResultText("done"))
+	if err != nil {
+		return nil, err
+	}
+	return transport.NewJSONRPCResultResponse(request.ID, result), nil
+}
+
+func TestClient_SetProgressHandler(t *testing.T) {
+	c := NewClient(&progressTransport{}, WithSession())
+	require.NoError(t, c.Start(context.Background()))
+
+	var received []mcp.ProgressNotification
+	c.SetProgressHandler(func(notification mcp.ProgressNotification) {
+		received = append(received, notification)
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "work"
+	_, err := c.CallTool(context.Background(), request)
+	require.NoError(t, err)
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "notifications/progress", received[0].Method)
+	assert.Equal(t, mcp.NewStringProgressToken("server-token"), received[0].Params.ProgressToken)
+	assert.Equal(t, float64(1), received[0].Params.Progress)
+	assert.Equal(t, float64(2), received[0].Params.Total)
+	assert.Equal(t, "halfway", received[0].Params.Message)
+}
+
+func TestClient_CallToolWithProgress(t *testing.T) {
+	c := NewClient(&progressTransport{}, WithSession())
+	require.NoError(t, c.Start(context.Background()))
+
+	c.SetProgressHandler(func(notification mcp.ProgressNotification) {
+		t.Errorf("unexpected progress notification for the global handler: %+v", notification)
+	})
+
+	var received []mcp.ProgressNotification
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "work"
+	result, err := c.CallToolWithProgress(context.Background(), request, func(notification mcp.ProgressNotification) {
+		received = append(received, notification)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "done", result.Content[0].(mcp.TextContent).Text)
+	require.Len(t, received, 1)
+	assert.Equal(t, mcp.NewStringProgressToken("progress-1"), received[0].Params.ProgressToken)
+
+	// Numeric tokens set by the caller are routed too
+	received = nil
+	request.Params.Meta = &mcp.Meta{ProgressToken: mcp.NewIntProgressToken(7)}
+	_, err = c.CallToolWithProgress(context.Background(), request, func(notification mcp.ProgressNotification) {
+		received = append(received, notification)
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, mcp.NewIntProgressToken(7), request.Params.Meta.ProgressToken)
+}
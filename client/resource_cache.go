@@ -0,0 +1,152 @@
+package client
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// resourceCache holds the results of resources/read requests by URI.
+type resourceCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]resourceCacheEntry
+	// generation is incremented by every invalidation, so that reads started
+	// before it do not cache contents it made stale.
+	generation uint64
+	// nextSweep is when put next evicts the expired entries.
+	nextSweep time.Time
+}
+
+type resourceCacheEntry struct {
+	result  *mcp.ReadResourceResult
+	expires time.Time // zero if the entry never expires
+}
+
+// WithResourceCache enables caching of ReadResource results by resource URI.
+// Cached results expire after ttl, or never if ttl is zero or negative, and
+// are dropped when the server sends notifications/resources/updated for
+// their URI. Requests with arguments or an Accept preference are not cached,
+// as their results may differ from those of the plain URI.
+func WithResourceCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.resourceCache = &resourceCache{
+			ttl:     ttl,
+			entries: make(map[string]resourceCacheEntry),
+		}
+	}
+}
+
+// cacheable reports whether the result of a read with params can be cached,
+// that is whether it depends on the URI only.
+func cacheable(params mcp.ReadResourceParams) bool {
+	return len(params.Arguments) == 0 &&
+		(params.Accept == "" || params.Accept == mcp.ContentTypePreferenceAuto)
+}
+
+// get returns a copy of the cached result for uri, so that callers modifying
+// it do not affect each other. An expired entry is evicted.
+func (rc *resourceCache) get(uri string) (*mcp.ReadResourceResult, bool) {
+	rc.mu.RLock()
+	entry, ok := rc.entries[uri]
+	rc.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if entry.expired(time.Now()) {
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		// The entry may have been replaced meanwhile
+		if current, ok := rc.entries[uri]; ok && current.expired(time.Now()) {
+			delete(rc.entries, uri)
+		}
+		return nil, false
+	}
+	return copyReadResourceResult(entry.result), true
+}
+
+// currentGeneration returns the generation to pass to put for a read
+// starting now.
+func (rc *resourceCache) currentGeneration() uint64 {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.generation
+}
+
+// put caches a copy of result for uri, unless the cache was invalidated since
+// generation was obtained from currentGeneration, as result may then be stale.
+// At most once per TTL, it also evicts the expired entries, so that entries
+// that are never read again do not accumulate.
+func (rc *resourceCache) put(uri string, result *mcp.ReadResourceResult, generation uint64) {
+	now := time.Now()
+	entry := resourceCacheEntry{result: copyReadResourceResult(result)}
+	if rc.ttl > 0 {
+		entry.expires = now.Add(rc.ttl)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if generation != rc.generation {
+		return
+	}
+	rc.entries[uri] = entry
+	if rc.ttl > 0 && now.After(rc.nextSweep) {
+		for key, entry := range rc.entries {
+			if entry.expired(now) {
+				delete(rc.entries, key)
+			}
+		}
+		rc.nextSweep = now.Add(rc.ttl)
+	}
+}
+
+func (e resourceCacheEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+func copyReadResourceResult(result *mcp.ReadResourceResult) *mcp.ReadResourceResult {
+	clone := *result
+	clone.Contents = slices.Clone(result.Contents)
+	return &clone
+}
+
+// invalidate drops the cached result for uri. As reads in flight may have
+// fetched the contents before the change, the results of all the reads
+// started before the call are discarded rather than cached.
+func (rc *resourceCache) invalidate(uri string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.entries, uri)
+	rc.generation++
+}
+
+// handleNotification drops cached resources the server reports as updated.
+func (rc *resourceCache) handleNotification(notification mcp.JSONRPCNotification) {
+	if notification.Method != mcp.MethodNotificationResourceUpdated {
+		return
+	}
+	if uri, ok := notification.Params.AdditionalFields["uri"].(string); ok {
+		rc.invalidate(uri)
+	}
+}
+
+// ResourceIsCached reports whether a result for uri is cached, in which case
+// ReadResource returns it without contacting the server. It always returns
+// false if the client was not created with WithResourceCache.
+func (c *Client) ResourceIsCached(uri string) bool {
+	if c.resourceCache == nil {
+		return false
+	}
+	_, ok := c.resourceCache.get(uri)
+	return ok
+}
+
+// InvalidateResourceCache drops the cached result for uri, so that the next
+// ReadResource call fetches it from the server.
+func (c *Client) InvalidateResourceCache(uri string) {
+	if c.resourceCache != nil {
+		c.resourceCache.invalidate(uri)
+	}
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachingTestClient(t *testing.T, ttl time.Duration) (*Client, *server.MCPServer, *atomic.Int32) {
+	t.Helper()
+
+	var reads atomic.Int32
+	mcpServer := server.NewMCPServer("test-server", "1.0.0")
+	mcpServer.AddResource(
+		mcp.NewResource("test://resource", "resource"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			reads.Add(1)
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "contents"}}, nil
+		},
+	)
+
+	c := NewClient(transport.NewInProcessTransport(mcpServer), WithResourceCache(ttl))
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { c.Close() })
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	_, err := c.Initialize(context.Background(), initRequest)
+	require.NoError(t, err)
+
+	return c, mcpServer, &reads
+}
+
+func readTestResource(t *testing.T, c *Client) {
+	t.Helper()
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "test://resource"
+	result, err := c.ReadResource(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "contents", result.Contents[0].(mcp.TextResourceContents).Text)
+}
+
+func TestClient_ResourceCache(t *testing.T) {
+	c, _, reads := newCachingTestClient(t, 0)
+
+	assert.False(t, c.ResourceIsCached("test://resource"))
+	readTestResource(t, c)
+	assert.Equal(t, int32(1), reads.Load(), "first read should miss the cache")
+	assert.True(t, c.ResourceIsCached("test://resource"))
+
+	readTestResource(t, c)
+	assert.Equal(t, int32(1), reads.Load(), "second read should hit the cache")
+
+	c.InvalidateResourceCache("test://resource")
+	assert.False(t, c.ResourceIsCached("test://resource"))
+	readTestResource(t, c)
+	assert.Equal(t, int32(2), reads.Load(), "read after invalidation should miss the cache")
+}
+
+func TestClient_ResourceCacheReturnsCopies(t *testing.T) {
+	c, _, _ := newCachingTestClient(t, 0)
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "test://resource"
+	result, err := c.ReadResource(context.Background(), request)
+	require.NoError(t, err)
+	result.Contents[0] = mcp.TextResourceContents{URI: "test://resource", Text: "modified"}
+
+	readTestResource(t, c)
+}
+
+func TestClient_ResourceCacheSkipsArgumentsAndAccept(t *testing.T) {
+	c, _, reads := newCachingTestClient(t, 0)
+	readTestResource(t, c)
+	require.Equal(t, int32(1), reads.Load())
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "test://resource"
+	request.Params.Accept = mcp.ContentTypePreferenceBlob
+	_, err := c.ReadResource(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), reads.Load(), "read with Accept should bypass the cache")
+
+	request.Params.Accept = ""
+	request.Params.Arguments = map[string]any{"page": 2}
+	_, err = c.ReadResource(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), reads.Load(), "read with arguments should bypass the cache")
+
+	// The cached result of the plain URI is kept
+	readTestResource(t, c)
+	assert.Equal(t, int32(3), reads.Load())
+}
+
+func TestClient_ResourceCacheTTL(t *testing.T) {
+	c, _, reads := newCachingTestClient(t, 10*time.Millisecond)
+
+	readTestResource(t, c)
+	assert.True(t, c.ResourceIsCached("test://resource"))
+
+	assert.Eventually(t, func() bool {
+		return !c.ResourceIsCached("test://resource")
+	}, time.Second, 5*time.Millisecond)
+	readTestResource(t, c)
+	assert.Equal(t, int32(2), reads.Load())
+}
+
+func TestResourceCache_EvictsExpiredEntries(t *testing.T) {
+	rc := &resourceCache{ttl: 10 * time.Millisecond, entries: make(map[string]resourceCacheEntry)}
+	result := &mcp.ReadResourceResult{}
+
+	rc.put("test://a", result, rc.currentGeneration())
+	rc.put("test://b", result, rc.currentGeneration())
+	time.Sleep(20 * time.Millisecond)
+
+	// Reading an expired entry evicts it
+	_, ok := rc.get("test://a")
+	assert.False(t, ok)
+	assert.NotContains(t, rc.entries, "test://a")
+
+	// Caching another entry evicts the expired ones never read again
+	rc.put("test://c", result, rc.currentGeneration())
+	assert.NotContains(t, rc.entries, "test://b")
+	assert.Contains(t, rc.entries, "test://c")
+}
+
+func TestClient_ResourceCacheDiscardsReadsStartedBeforeInvalidation(t *testing.T) {
+	var reads atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mcpServer := server.NewMCPServer("test-server", "1.0.0")
+	mcpServer.AddResource(
+		mcp.NewResource("test://resource", "resource"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			if reads.Add(1) == 1 {
+				close(started)
+				<-release
+			}
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "contents"}}, nil
+		},
+	)
+
+	c := NewClient(transport.NewInProcessTransport(mcpServer), WithResourceCache(0))
+	require.NoError(t, c.Start(context.Background()))
+	t.Cleanup(func() { c.Close() })
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	_, err := c.Initialize(context.Background(), initRequest)
+	require.NoError(t, err)
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = "test://resource"
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.ReadResource(context.Background(), request)
+		errCh <- err
+	}()
+	<-started
+	// The resource changes while the read is in flight
+	c.InvalidateResourceCache("test://resource")
+	close(release)
+	require.NoError(t, <-errCh)
+
+	assert.False(t, c.ResourceIsCached("test://resource"), "a read started before the invalidation must not be cached")
+	readTestResource(t, c)
+	assert.True(t, c.ResourceIsCached("test://resource"))
+	assert.Equal(t, int32(2), reads.Load())
+}
+
+func TestClient_ResourceCacheUpdatedNotification(t *testing.T) {
+	c, _, _ := newCachingTestClient(t, 0)
+
+	readTestResource(t, c)
+	require.True(t, c.ResourceIsCached("test://resource"))
+
+	notification := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: mcp.MethodNotificationResourceUpdated,
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{"uri": "test://other"},
+			},
+		},
+	}
+	c.resourceCache.handleNotification(notification)
+	assert.True(t, c.ResourceIsCached("test://resource"), "updates of other resources keep the entry")
+
+	notification.Params.AdditionalFields["uri"] = "test://resource"
+	c.resourceCache.handleNotification(notification)
+	assert.False(t, c.ResourceIsCached("test://resource"))
+}
+
+func TestClient_ResourceCacheDisabled(t *testing.T) {
+	mcpServer := server.NewMCPServer("test-server", "1.0.0")
+	c := NewClient(transport.NewInProcessTransport(mcpServer))
+	assert.False(t, c.ResourceIsCached("test://resource"))
+	c.InvalidateResourceCache("test://resource")
+}
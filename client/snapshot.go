@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerSnapshot holds everything a server offers, as fetched by
+// BatchListAll.
+type ServerSnapshot struct {
+	Tools      []mcp.Tool
+	Resources  []mcp.Resource
+	Prompts    []mcp.Prompt
+	ServerInfo mcp.Implementation
+}
+
+// snapshotList is a list fetched by BatchListAll, with the cursor of its next
+// page.
+type snapshotList struct {
+	method mcp.MCPMethod
+	cursor mcp.Cursor
+	// collect adds the items of a page of the list to the snapshot and
+	// returns the cursor of the next page.
+	collect func(result json.RawMessage) (mcp.Cursor, error)
+}
+
+// BatchListAll fetches the tools, resources and prompts of the server, each
+// of them if the server advertised its capability at initialization. The
+// first pages of the lists are requested together in a single JSON-RPC batch
+// when the transport implements transport.BatchInterface, then the following
+// pages in further batches. Only the in-process transport batches requests;
+// over stdio, SSE and streamable HTTP the requests are sent one after
+// another.
+func (c *Client) BatchListAll(ctx context.Context) (*ServerSnapshot, error) {
+	if !c.initialized {
+		return nil, fmt.Errorf("client not initialized")
+	}
+
+	snapshot := &ServerSnapshot{ServerInfo: c.serverInfo}
+	var lists []*snapshotList
+	if c.serverCapabilities.Tools != nil {
+		lists = append(lists, &snapshotList{
+			method: mcp.MethodToolsList,
+			collect: collectPage(func(page mcp.ListToolsResult) mcp.Cursor {
+				snapshot.Tools = append(snapshot.Tools, page.Tools...)
+				return page.NextCursor
+			}),
+		})
+	}
+	if c.serverCapabilities.Resources != nil {
+		lists = append(lists, &snapshotList{
+			method: mcp.MethodResourcesList,
+			collect: collectPage(func(page mcp.ListResourcesResult) mcp.Cursor {
+				snapshot.Resources = append(snapshot.Resources, page.Resources...)
+				return page.NextCursor
+			}),
+		})
+	}
+	if c.serverCapabilities.Prompts != nil {
+		lists = append(lists, &snapshotList{
+			method: mcp.MethodPromptsList,
+			collect: collectPage(func(page mcp.ListPromptsResult) mcp.Cursor {
+				snapshot.Prompts = append(snapshot.Prompts, page.Prompts...)
+				return page.NextCursor
+			}),
+		})
+	}
+
+	for len(lists) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		requests := make([]transport.JSONRPCRequest, len(lists))
+		for i, list := range lists {
+			requests[i] = transport.JSONRPCRequest{
+				JSONRPC: mcp.JSONRPC_VERSION,
+				ID:      mcp.NewRequestId(c.requestID.Add(1)),
+				Method:  string(list.method),
+				Params:  mcp.PaginatedParams{Cursor: list.cursor},
+			}
+		}
+		responses, err := c.sendBatch(ctx, requests)
+		if err != nil {
+			return nil, err
+		}
+
+		var remaining []*snapshotList
+		for i, list := range lists {
+			if responses[i].Error != nil {
+				return nil, fmt.Errorf("%s: %w", list.method, responses[i].Error.AsError())
+			}
+			cursor, err := list.collect(responses[i].Result)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", list.method, err)
+			}
+			if cursor != "" {
+				list.cursor = cursor
+				remaining = append(remaining, list)
+			}
+		}
+		lists = remaining
+	}
+	return snapshot, nil
+}
+
+// collectPage returns the collect function of a snapshotList whose pages are
+// of type T.
+func collectPage[T any](add func(page T) mcp.Cursor) func(json.RawMessage) (mcp.Cursor, error) {
+	return func(result json.RawMessage) (mcp.Cursor, error) {
+		var page T
+		if err := json.Unmarshal(result, &page); err != nil {
+			return "", fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return add(page), nil
+	}
+}
+
+// sendBatch sends requests as a JSON-RPC batch if the transport supports it,
+// or one after another otherwise, and returns their responses in order.
+func (c *Client) sendBatch(ctx context.Context, requests []transport.JSONRPCRequest) ([]*transport.JSONRPCResponse, error) {
+	if batcher, ok := c.transport.(transport.BatchInterface); ok {
+		responses, err := batcher.SendBatch(ctx, requests)
+		if err != nil {
+			return nil, transport.NewError(err)
+		}
+		return responses, nil
+	}
+
+	responses := make([]*transport.JSONRPCResponse, len(requests))
+	for i, request := range requests {
+		response, err := c.transport.SendRequest(ctx, request)
+		if err != nil {
+			return nil, transport.NewError(err)
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the requests and batches sent to the server.
+type countingTransport struct {
+	*transport.InProcessTransport
+	requests int
+	batches  int
+}
+
+func (c *countingTransport) SendRequest(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+	c.requests++
+	return c.InProcessTransport.SendRequest(ctx, request)
+}
+
+func (c *countingTransport) SendBatch(ctx context.Context, requests []transport.JSONRPCRequest) ([]*transport.JSONRPCResponse, error) {
+	c.batches++
+	return c.InProcessTransport.SendBatch(ctx, requests)
+}
+
+// sequentialTransport hides the batch support of its transport.
+type sequentialTransport struct {
+	transport.Interface
+}
+
+func newSnapshotServer() *server.MCPServer {
+	mcpServer := server.NewMCPServer("snapshot-server", "1.2.3",
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		server.WithPaginationLimit(2),
+	)
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	for i := 1; i <= 3; i++ {
+		mcpServer.AddTool(mcp.NewTool(fmt.Sprintf("tool-%d", i)), handler)
+	}
+	for i := 1; i <= 2; i++ {
+		mcpServer.AddResource(mcp.NewResource(fmt.Sprintf("test://resource-%d", i), fmt.Sprintf("resource-%d", i)),
+			func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				return nil, nil
+			})
+	}
+	mcpServer.AddPrompt(mcp.NewPrompt("prompt-1"), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{}, nil
+	})
+	return mcpServer
+}
+
+func initializeSnapshotClient(t *testing.T, trans transport.Interface) *Client {
+	t.Helper()
+	c := NewClient(trans)
+	require.NoError(t, c.Start(context.Background()))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	_, err := c.Initialize(context.Background(), initRequest)
+	require.NoError(t, err)
+	return c
+}
+
+func assertSnapshot(t *testing.T, snapshot *ServerSnapshot) {
+	t.Helper()
+	var names []string
+	for _, tool := range snapshot.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.ElementsMatch(t, []string{"tool-1", "tool-2", "tool-3"}, names)
+	names = nil
+	for _, resource := range snapshot.Resources {
+		names = append(names, resource.URI)
+	}
+	assert.ElementsMatch(t, []string{"test://resource-1", "test://resource-2"}, names)
+	require.Len(t, snapshot.Prompts, 1)
+	assert.Equal(t, "prompt-1", snapshot.Prompts[0].Name)
+	assert.Equal(t, mcp.Implementation{Name: "snapshot-server", Version: "1.2.3"}, snapshot.ServerInfo)
+}
+
+func TestClient_BatchListAll(t *testing.T) {
+	trans := &countingTransport{InProcessTransport: transport.NewInProcessTransport(newSnapshotServer())}
+	c := initializeSnapshotClient(t, trans)
+	trans.requests = 0
+
+	snapshot, err := c.BatchListAll(context.Background())
+	require.NoError(t, err)
+	assertSnapshot(t, snapshot)
+
+	// The first pages are fetched in one batch, then the last page of the tools
+	assert.Equal(t, 2, trans.batches)
+	assert.Zero(t, trans.requests)
+}
+
+func TestClient_BatchListAll_WithoutBatchSupport(t *testing.T) {
+	c := initializeSnapshotClient(t, sequentialTransport{transport.NewInProcessTransport(newSnapshotServer())})
+
+	snapshot, err := c.BatchListAll(context.Background())
+	require.NoError(t, err)
+	assertSnapshot(t, snapshot)
+}
+
+func TestClient_BatchListAll_SkipsUnsupportedLists(t *testing.T) {
+	mcpServer := server.NewMCPServer("tools-only", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("tool-1"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	c := initializeSnapshotClient(t, transport.NewInProcessTransport(mcpServer))
+
+	snapshot, err := c.BatchListAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snapshot.Tools, 1)
+	assert.Empty(t, snapshot.Resources)
+	assert.Empty(t, snapshot.Prompts)
+
+	_, err = NewClient(transport.NewInProcessTransport(mcpServer)).BatchListAll(context.Background())
+	assert.Error(t, err)
+}
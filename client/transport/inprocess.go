@@ -111,6 +111,36 @@ func (c *InProcessTransport) SendRequest(ctx context.Context, request JSONRPCReq
 	return &rpcResp, nil
 }
 
+// SendBatch sends requests to the server as a JSON-RPC batch, handled by the
+// server in a single call to HandleMessage.
+func (c *InProcessTransport) SendBatch(ctx context.Context, requests []JSONRPCRequest) ([]*JSONRPCResponse, error) {
+	requestBytes, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal requests: %w", err)
+	}
+
+	// Add session to context if available
+	if c.session != nil {
+		ctx = c.server.WithContext(ctx, c.session)
+	}
+
+	respMessage := c.server.HandleMessage(ctx, requestBytes)
+	respByte, err := json.Marshal(respMessage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response message: %w", err)
+	}
+	var rpcResps []*JSONRPCResponse
+	if err := json.Unmarshal(respByte, &rpcResps); err != nil {
+		// The server answers a batch it cannot handle with a single error
+		var rpcResp JSONRPCResponse
+		if json.Unmarshal(respByte, &rpcResp) == nil && rpcResp.Error != nil {
+			return nil, rpcResp.Error.AsError()
+		}
+		return nil, fmt.Errorf("failed to unmarshal response message: %w", err)
+	}
+	return orderBatchResponses(requests, rpcResps)
+}
+
 func (c *InProcessTransport) SendNotification(ctx context.Context, notification mcp.JSONRPCNotification) error {
 	notificationBytes, err := json.Marshal(notification)
 	if err != nil {
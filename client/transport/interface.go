@@ -35,6 +35,25 @@ type Interface interface {
 	GetSessionId() string
 }
 
+// DisconnectNotifier is implemented by transports reporting the end of their
+// connection to the server, for example to reconnect.
+type DisconnectNotifier interface {
+	// OnDisconnect registers fn to be called with the reason the connection
+	// ended, unless it ended because the transport was closed.
+	OnDisconnect(fn func(err error))
+}
+
+// BatchInterface is implemented by transports able to send several requests
+// to the server at once, as a JSON-RPC batch. Only InProcessTransport
+// implements it: the stdio, SSE and streamable HTTP transports send every
+// request on its own, as JSON-RPC batching was removed from the MCP
+// specification in its 2025-06-18 revision.
+type BatchInterface interface {
+	// SendBatch sends requests as a JSON-RPC batch and returns their
+	// responses, in the order of the requests.
+	SendBatch(ctx context.Context, requests []JSONRPCRequest) ([]*JSONRPCResponse, error)
+}
+
 // RequestHandler defines a function that handles incoming requests from the server.
 type RequestHandler func(ctx context.Context, request JSONRPCRequest) (*JSONRPCResponse, error)
 
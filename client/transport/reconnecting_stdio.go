@@ -0,0 +1,315 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/util"
+)
+
+// ErrServerRestarting is returned for messages sent while the subprocess of a
+// ReconnectingStdioTransport is being restarted.
+var ErrServerRestarting = errors.New("server process is restarting")
+
+const (
+	defaultReconnectMinDelay = 100 * time.Millisecond
+	defaultReconnectMaxDelay = 30 * time.Second
+)
+
+// ReconnectingStdioTransport is a stdio transport for long-lived, managed
+// server subprocesses. It restarts the subprocess whenever it exits, waiting
+// with exponential back-off between attempts.
+//
+// Requests in flight when the subprocess exits, and requests sent before it
+// has been restarted, fail immediately with a REQUEST_INTERRUPTED error
+// response, which the client reports as mcp.ErrRequestInterrupted and which
+// can be retried. The restarted server is a new process that has to be
+// initialized again; use WithRestartHandler to be told when to do so.
+type ReconnectingStdioTransport struct {
+	command  string
+	env      []string
+	args     []string
+	opts     []StdioOption
+	minDelay time.Duration
+	maxDelay time.Duration
+	logger   util.Logger
+
+	onRestart func()
+
+	mu             sync.RWMutex
+	current        *Stdio // nil while the subprocess is restarting
+	onNotification func(mcp.JSONRPCNotification)
+	onRequest      RequestHandler
+
+	started   bool
+	startedMu sync.Mutex
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// ReconnectingStdioOption configures a ReconnectingStdioTransport.
+type ReconnectingStdioOption func(*ReconnectingStdioTransport)
+
+// WithReconnectDelay sets the back-off between restarts of the subprocess. The
+// delay starts at minDelay and doubles after each failed or short-lived
+// attempt, up to maxDelay. The defaults are 100ms and 30s.
+func WithReconnectDelay(minDelay, maxDelay time.Duration) ReconnectingStdioOption {
+	return func(t *ReconnectingStdioTransport) {
+		t.minDelay = minDelay
+		t.maxDelay = max(minDelay, maxDelay)
+	}
+}
+
+// WithRestartHandler sets a function called after the subprocess has been
+// restarted, typically to initialize the new server session.
+func WithRestartHandler(handler func()) ReconnectingStdioOption {
+	return func(t *ReconnectingStdioTransport) {
+		t.onRestart = handler
+	}
+}
+
+// WithReconnectStdioOptions sets options applied to the stdio transport of
+// every subprocess, such as WithCommandFunc.
+func WithReconnectStdioOptions(opts ...StdioOption) ReconnectingStdioOption {
+	return func(t *ReconnectingStdioTransport) {
+		t.opts = append(t.opts, opts...)
+	}
+}
+
+// NewReconnectingStdioTransport creates a transport running command with the
+// given environment and arguments, restarting it whenever it exits.
+func NewReconnectingStdioTransport(
+	command string,
+	env []string,
+	args []string,
+	opts ...ReconnectingStdioOption,
+) *ReconnectingStdioTransport {
+	t := &ReconnectingStdioTransport{
+		command:  command,
+		env:      env,
+		args:     args,
+		minDelay: defaultReconnectMinDelay,
+		maxDelay: defaultReconnectMaxDelay,
+		logger:   util.DefaultLogger(),
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Start spawns the subprocess and starts monitoring it. The context is used
+// for the subprocess and every restart of it; once it is done, the subprocess
+// is no longer restarted.
+func (t *ReconnectingStdioTransport) Start(ctx context.Context) error {
+	t.startedMu.Lock()
+	defer t.startedMu.Unlock()
+	if t.started {
+		return nil
+	}
+
+	stdio, err := t.spawn(ctx)
+	if err != nil {
+		return err
+	}
+	t.started = true
+
+	go t.monitor(ctx, stdio)
+	return nil
+}
+
+// spawn starts a new subprocess and makes it the current one.
+func (t *ReconnectingStdioTransport) spawn(ctx context.Context) (*Stdio, error) {
+	stdio := NewStdioWithOptions(t.command, t.env, t.args, t.opts...)
+	if err := stdio.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	select {
+	case <-t.done:
+		_ = stdio.Close()
+		return nil, errors.New("transport closed")
+	default:
+	}
+	if t.onNotification != nil {
+		stdio.SetNotificationHandler(t.onNotification)
+	}
+	if t.onRequest != nil {
+		stdio.SetRequestHandler(t.onRequest)
+	}
+	t.current = stdio
+	return stdio, nil
+}
+
+// monitor waits for the subprocess to exit and restarts it with exponential
+// back-off until the transport is closed or ctx is done.
+func (t *ReconnectingStdioTransport) monitor(ctx context.Context, stdio *Stdio) {
+	delay := t.minDelay
+	for {
+		started := time.Now()
+		select {
+		case <-t.done:
+			return
+		case <-stdio.exited:
+		}
+
+		t.mu.Lock()
+		if t.current != stdio {
+			// Closed concurrently
+			t.mu.Unlock()
+			return
+		}
+		t.current = nil
+		t.mu.Unlock()
+		if err := stdio.Close(); err != nil {
+			t.logger.Infof("MCP server process exited: %v", err)
+		}
+
+		// A process that ran for a while resets the back-off
+		if time.Since(started) > t.maxDelay {
+			delay = t.minDelay
+		}
+
+		for {
+			select {
+			case <-t.done:
+				return
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, t.maxDelay)
+
+			var err error
+			if stdio, err = t.spawn(ctx); err == nil {
+				break
+			}
+			t.logger.Errorf("Failed to restart MCP server process: %v", err)
+		}
+
+		if t.onRestart != nil {
+			t.onRestart()
+		}
+	}
+}
+
+// active returns the current subprocess transport, or nil while restarting.
+func (t *ReconnectingStdioTransport) active() *Stdio {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.current
+}
+
+// SendRequest sends a request to the current subprocess. If there is none, or
+// it exits before responding, a REQUEST_INTERRUPTED error response is returned.
+func (t *ReconnectingStdioTransport) SendRequest(
+	ctx context.Context,
+	request JSONRPCRequest,
+) (*JSONRPCResponse, error) {
+	stdio := t.active()
+	if stdio == nil {
+		return restartingResponse(request.ID), nil
+	}
+
+	requestCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stdio.exited:
+			cancel()
+		case <-requestCtx.Done():
+		}
+	}()
+
+	response, err := stdio.SendRequest(requestCtx, request)
+	if err != nil && ctx.Err() == nil && hasExited(stdio) {
+		return restartingResponse(request.ID), nil
+	}
+	return response, err
+}
+
+// SendNotification sends a notification to the current subprocess. It
+// returns ErrServerRestarting while the subprocess is being restarted.
+func (t *ReconnectingStdioTransport) SendNotification(
+	ctx context.Context,
+	notification mcp.JSONRPCNotification,
+) error {
+	stdio := t.active()
+	if stdio == nil || hasExited(stdio) {
+		return ErrServerRestarting
+	}
+	return stdio.SendNotification(ctx, notification)
+}
+
+// SetNotificationHandler sets the handler for notifications of the current
+// and all future subprocesses.
+func (t *ReconnectingStdioTransport) SetNotificationHandler(handler func(notification mcp.JSONRPCNotification)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onNotification = handler
+	if t.current != nil {
+		t.current.SetNotificationHandler(handler)
+	}
+}
+
+// SetRequestHandler sets the handler for requests from the current and all
+// future subprocesses.
+func (t *ReconnectingStdioTransport) SetRequestHandler(handler RequestHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onRequest = handler
+	if t.current != nil {
+		t.current.SetRequestHandler(handler)
+	}
+}
+
+// Close stops restarting the subprocess and shuts the current one down.
+func (t *ReconnectingStdioTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.done)
+
+		t.mu.Lock()
+		stdio := t.current
+		t.current = nil
+		t.mu.Unlock()
+
+		if stdio != nil {
+			err = stdio.Close()
+		}
+	})
+	return err
+}
+
+// GetSessionId returns the session ID of the transport.
+// Since stdio does not maintain a session ID, it returns an empty string.
+func (t *ReconnectingStdioTransport) GetSessionId() string {
+	return ""
+}
+
+func hasExited(stdio *Stdio) bool {
+	select {
+	case <-stdio.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+func restartingResponse(id mcp.RequestId) *JSONRPCResponse {
+	return NewJSONRPCErrorResponse(
+		id,
+		mcp.REQUEST_INTERRUPTED,
+		fmt.Sprintf("%v, retry the request", ErrServerRestarting),
+		nil,
+	)
+}
@@ -0,0 +1,125 @@
+package transport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func sendReconnectingPing(t *testing.T, tr *ReconnectingStdioTransport, id int64) *JSONRPCResponse {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := tr.SendRequest(ctx, JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId(id),
+		Method:  "ping",
+	})
+	require.NoError(t, err)
+	return response
+}
+
+func TestReconnectingStdioTransport_Restart(t *testing.T) {
+	mockServerPath := filepath.Join(t.TempDir(), "mockstdio_server")
+	if runtime.GOOS == "windows" {
+		mockServerPath += ".exe"
+	}
+	require.NoError(t, compileTestServer(mockServerPath))
+
+	var restarts atomic.Int32
+	tr := NewReconnectingStdioTransport(mockServerPath, nil, nil,
+		WithReconnectDelay(200*time.Millisecond, time.Second),
+		WithRestartHandler(func() { restarts.Add(1) }),
+	)
+	require.NoError(t, tr.Start(context.Background()))
+	defer tr.Close()
+
+	response := sendReconnectingPing(t, tr, 1)
+	require.Nil(t, response.Error)
+
+	first := tr.active()
+	require.NotNil(t, first)
+	require.NoError(t, first.cmd.Process.Kill())
+	<-first.exited
+
+	// Requests during the restart fail with a retriable error
+	response = sendReconnectingPing(t, tr, 2)
+	require.NotNil(t, response.Error)
+	require.Equal(t, mcp.REQUEST_INTERRUPTED, response.Error.Code)
+	require.ErrorIs(t, response.Error.AsError(), mcp.ErrRequestInterrupted)
+	require.ErrorIs(t, tr.SendNotification(context.Background(), mcp.JSONRPCNotification{}), ErrServerRestarting)
+
+	require.Eventually(t, func() bool { return restarts.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	second := tr.active()
+	require.NotNil(t, second)
+	require.NotEqual(t, first.cmd.Process.Pid, second.cmd.Process.Pid)
+
+	response = sendReconnectingPing(t, tr, 3)
+	require.Nil(t, response.Error)
+}
+
+func TestReconnectingStdioTransport_InFlightRequest(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	// The server exits as soon as it receives a request
+	tr := NewReconnectingStdioTransport("sh", nil, []string{"-c", "read line; exit 1"},
+		WithReconnectDelay(time.Hour, time.Hour),
+	)
+	require.NoError(t, tr.Start(context.Background()))
+	defer tr.Close()
+
+	start := time.Now()
+	response := sendReconnectingPing(t, tr, 1)
+	require.NotNil(t, response.Error)
+	require.Equal(t, mcp.REQUEST_INTERRUPTED, response.Error.Code)
+	require.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestReconnectingStdioTransport_Backoff(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	var mu sync.Mutex
+	var restartTimes []time.Time
+	tr := NewReconnectingStdioTransport("sh", nil, []string{"-c", "exit 1"},
+		WithReconnectDelay(20*time.Millisecond, 80*time.Millisecond),
+		WithRestartHandler(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			restartTimes = append(restartTimes, time.Now())
+		}),
+	)
+	require.NoError(t, tr.Start(context.Background()))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(restartTimes) >= 5
+	}, 5*time.Second, 10*time.Millisecond)
+	// Close reports the exit status of a crashed process, if any
+	_ = tr.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Delays are 20ms, 40ms, 80ms, 80ms, ... between restarts
+	for i := 2; i < 5; i++ {
+		require.GreaterOrEqual(t, restartTimes[i].Sub(restartTimes[i-1]), 70*time.Millisecond)
+	}
+}
+
+func TestReconnectingStdioTransport_StartFailure(t *testing.T) {
+	tr := NewReconnectingStdioTransport(filepath.Join(os.TempDir(), "does-not-exist-mcp-server"), nil, nil)
+	require.Error(t, tr.Start(context.Background()))
+	require.NoError(t, tr.Close())
+}
@@ -36,11 +36,14 @@ type SSE struct {
 	headerFunc     HTTPHeaderFunc
 	logger         util.Logger
 
+	insecureSkipVerify bool
+
 	started          atomic.Bool
 	closed           atomic.Bool
 	cancelSSEStream  context.CancelFunc
 	protocolVersion  atomic.Value // string
 	onConnectionLost func(error)
+	onDisconnect     func(error)
 	connectionLostMu sync.RWMutex
 
 	// OAuth support
@@ -74,6 +77,16 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithInsecureSkipVerify makes the SSE client skip the verification of the
+// certificate of the server, such as the self-signed certificate of an
+// SSEServer created with WithSelfSignedDevCertificate. It must not be used in
+// production.
+func WithInsecureSkipVerify() ClientOption {
+	return func(sc *SSE) {
+		sc.insecureSkipVerify = true
+	}
+}
+
 func WithOAuth(config OAuthConfig) ClientOption {
 	return func(sc *SSE) {
 		sc.oauthHandler = NewOAuthHandler(config)
@@ -101,6 +114,10 @@ func NewSSE(baseURL string, options ...ClientOption) (*SSE, error) {
 		opt(smc)
 	}
 
+	if smc.insecureSkipVerify {
+		smc.httpClient = insecureHTTPClient(smc.httpClient)
+	}
+
 	// If OAuth is configured, set the base URL for metadata discovery
 	if smc.oauthHandler != nil {
 		// Extract base URL from server URL for metadata discovery
@@ -212,6 +229,7 @@ func (c *SSE) readSSE(reader io.ReadCloser) {
 					}
 					c.handleSSEEvent(event, data)
 				}
+				c.notifyDisconnect(io.EOF)
 				break
 			}
 			// Checking whether the connection was terminated due to NO_ERROR in HTTP2 based on RFC9113
@@ -224,12 +242,14 @@ func (c *SSE) readSSE(reader io.ReadCloser) {
 				if handler != nil {
 					// This is not actually an error - HTTP2 idle timeout disconnection
 					handler(err)
+					c.notifyDisconnect(err)
 					return
 				}
 			}
 			if !c.closed.Load() {
 				c.logger.Errorf("SSE stream error: %v", err)
 			}
+			c.notifyDisconnect(err)
 			return
 		}
 
@@ -323,6 +343,26 @@ func (c *SSE) SetConnectionLostHandler(handler func(error)) {
 	c.onConnectionLost = handler
 }
 
+// OnDisconnect registers fn to be called when the SSE stream ends, unless it
+// ends because the transport was closed.
+func (c *SSE) OnDisconnect(fn func(err error)) {
+	c.connectionLostMu.Lock()
+	defer c.connectionLostMu.Unlock()
+	c.onDisconnect = fn
+}
+
+func (c *SSE) notifyDisconnect(err error) {
+	if c.closed.Load() {
+		return
+	}
+	c.connectionLostMu.RLock()
+	fn := c.onDisconnect
+	c.connectionLostMu.RUnlock()
+	if fn != nil {
+		fn(err)
+	}
+}
+
 // SendRequest sends a JSON-RPC request to the server and waits for a response.
 // Returns the raw JSON response message or an error if the request fails.
 func (c *SSE) SendRequest(
@@ -913,3 +913,52 @@ func TestSSEErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestSSE_OnDisconnect(t *testing.T) {
+	url, closeF := startMockSSEEchoServer()
+	defer closeF()
+
+	trans, err := NewSSE(url)
+	require.NoError(t, err)
+	disconnected := make(chan error, 1)
+	trans.OnDisconnect(func(err error) {
+		disconnected <- err
+	})
+
+	streamErr := errors.New("connection reset")
+	trans.readSSE(&mockReaderWithError{data: []byte(": ping\n"), err: streamErr})
+	require.ErrorIs(t, <-disconnected, streamErr)
+
+	trans.readSSE(io.NopCloser(strings.NewReader("")))
+	require.ErrorIs(t, <-disconnected, io.EOF)
+
+	// Streams ending because the transport was closed are not reported
+	require.NoError(t, trans.Close())
+	trans.readSSE(io.NopCloser(strings.NewReader("")))
+	require.Empty(t, disconnected)
+}
+
+func TestSSE_WithInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "event: endpoint\ndata: %s\n\n", "/message")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The certificate of the test server is not trusted
+	trans, err := NewSSE(server.URL)
+	require.NoError(t, err)
+	require.Error(t, trans.Start(ctx))
+
+	trans, err = NewSSE(server.URL, WithInsecureSkipVerify())
+	require.NoError(t, err)
+	require.NoError(t, trans.Start(ctx))
+	defer trans.Close()
+	require.Equal(t, server.URL+"/message", trans.GetEndpoint().String())
+}
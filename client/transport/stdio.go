@@ -42,6 +42,12 @@ type Stdio struct {
 	logger         util.Logger
 	started        bool
 	startedMu      sync.Mutex
+	exited         chan struct{} // closed once reading from the subprocess stops
+	exitCode       chan int      // receives the exit code of the subprocess
+	onDisconnect   func(error)
+	disconnectMu   sync.RWMutex
+	waitOnce       sync.Once
+	waitErr        error
 }
 
 // StdioOption defines a function that configures a Stdio transport instance.
@@ -80,6 +86,7 @@ func NewIO(input io.Reader, output io.WriteCloser, logging io.ReadCloser) *Stdio
 
 		responses: make(map[string]chan *JSONRPCResponse),
 		done:      make(chan struct{}),
+		exitCode:  make(chan int, 1),
 		ctx:       context.Background(),
 		logger:    util.DefaultLogger(),
 	}
@@ -114,6 +121,7 @@ func NewStdioWithOptions(
 
 		responses: make(map[string]chan *JSONRPCResponse),
 		done:      make(chan struct{}),
+		exitCode:  make(chan int, 1),
 		ctx:       context.Background(),
 		logger:    util.DefaultLogger(),
 	}
@@ -147,9 +155,19 @@ func (c *Stdio) Start(ctx context.Context) error {
 	}
 
 	ready := make(chan struct{})
+	exited := make(chan struct{})
+	c.exited = exited
 	go func() {
+		defer close(exited)
 		close(ready)
 		c.readResponses()
+		var err error
+		if c.cmd != nil {
+			// Reading stops once the subprocess closed its output, usually
+			// because it exited: reap it so WaitExit reports its exit code
+			err = c.wait()
+		}
+		c.notifyDisconnect(err)
 	}()
 	<-ready
 
@@ -161,7 +179,7 @@ func (c *Stdio) Start(ctx context.Context) error {
 // otherwise, the default behavior uses exec.CommandContext with the merged environment.
 // Initializes stdin, stdout, and stderr pipes for JSON-RPC communication.
 func (c *Stdio) spawnCommand(ctx context.Context) error {
-	if c.command == "" {
+	if c.command == "" || c.cmd != nil {
 		return nil
 	}
 
@@ -176,6 +194,12 @@ func (c *Stdio) spawnCommand(ctx context.Context) error {
 		return err
 	}
 
+	return c.startCommand(cmd)
+}
+
+// startCommand starts cmd with pipes to its standard streams for JSON-RPC
+// communication.
+func (c *Stdio) startCommand(cmd *exec.Cmd) error {
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
 		return fmt.Errorf("failed to create stdin pipe: %w", err)
@@ -191,16 +215,64 @@ func (c *Stdio) spawnCommand(ctx context.Context) error {
 		return fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
 	c.cmd = cmd
 	c.stdin = stdin
 	c.stderr = stderr
 	c.stdout = bufio.NewReader(stdout)
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start command: %w", err)
+	return nil
+}
+
+// NewStdioFromCommand starts cmd and returns a stdio transport communicating
+// with it through its standard input and output, for subprocesses that need
+// more control than NewStdioWithOptions gives. The standard streams of cmd
+// must not be set. The transport still has to be started with Start, and
+// closing it waits for the subprocess to exit.
+func NewStdioFromCommand(cmd *exec.Cmd) (*Stdio, error) {
+	s := &Stdio{
+		command: cmd.Path,
+		args:    cmd.Args,
+		env:     cmd.Env,
+
+		responses: make(map[string]chan *JSONRPCResponse),
+		done:      make(chan struct{}),
+		exitCode:  make(chan int, 1),
+		ctx:       context.Background(),
+		logger:    util.DefaultLogger(),
+	}
+	if err := s.startCommand(cmd); err != nil {
+		return nil, err
 	}
+	return s, nil
+}
 
-	return nil
+// NewCommandTransport starts the named program with the given arguments and
+// returns a stdio transport communicating with it, as NewStdioFromCommand
+// does. The program inherits the environment of the current process.
+func NewCommandTransport(name string, args ...string) (*Stdio, error) {
+	return NewStdioFromCommand(exec.Command(name, args...))
+}
+
+// Kill forcefully terminates the subprocess the transport communicates with.
+// The transport should still be closed to release its resources.
+func (c *Stdio) Kill() error {
+	if c.cmd == nil || c.cmd.Process == nil {
+		return fmt.Errorf("no subprocess to kill")
+	}
+	return c.cmd.Process.Kill()
+}
+
+// PID returns the process ID of the subprocess the transport communicates
+// with, or 0 if it did not start one, for example when created with NewIO.
+func (c *Stdio) PID() int {
+	if c.cmd == nil || c.cmd.Process == nil {
+		return 0
+	}
+	return c.cmd.Process.Pid
 }
 
 // Close shuts down the stdio client, closing the stdin pipe and waiting for the subprocess to exit.
@@ -226,12 +298,62 @@ func (c *Stdio) Close() error {
 	}
 
 	if c.cmd != nil {
-		return c.cmd.Wait()
+		return c.wait()
 	}
 
 	return nil
 }
 
+// wait waits for the subprocess to exit and sends its exit code to the
+// channel returned by WaitExit. The subprocess is waited for only once, later
+// calls return the same error.
+func (c *Stdio) wait() error {
+	c.waitOnce.Do(func() {
+		c.waitErr = c.cmd.Wait()
+		code := -1
+		if c.cmd.ProcessState != nil {
+			code = c.cmd.ProcessState.ExitCode()
+		}
+		c.exitCode <- code
+	})
+	return c.waitErr
+}
+
+// WaitExit returns a channel receiving the exit code of the subprocess once
+// it exits, or -1 if it was terminated by a signal. The exit code is sent
+// once, before reading from the subprocess is reported as stopped. The
+// channel never receives for transports without a subprocess, such as those
+// created with NewIO.
+func (c *Stdio) WaitExit() <-chan int {
+	return c.exitCode
+}
+
+// OnDisconnect registers fn to be called when the subprocess exits or closes
+// its output, unless the transport was closed. The error is the one of
+// waiting for the subprocess, or io.EOF if it exited successfully.
+func (c *Stdio) OnDisconnect(fn func(err error)) {
+	c.disconnectMu.Lock()
+	defer c.disconnectMu.Unlock()
+	c.onDisconnect = fn
+}
+
+func (c *Stdio) notifyDisconnect(err error) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	if err == nil {
+		err = io.EOF
+	}
+	c.disconnectMu.RLock()
+	fn := c.onDisconnect
+	c.disconnectMu.RUnlock()
+	if fn != nil {
+		fn(err)
+	}
+}
+
 // GetSessionId returns the session ID of the transport.
 // Since stdio does not maintain a session ID, it returns an empty string.
 func (c *Stdio) GetSessionId() string {
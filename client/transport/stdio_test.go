@@ -820,3 +820,162 @@ func generateRandomString(size int) string {
 	}
 	return string(b)
 }
+
+func TestNewStdioFromCommand(t *testing.T) {
+	mockServerPath := filepath.Join(t.TempDir(), "mockstdio_server")
+	if runtime.GOOS == "windows" {
+		mockServerPath += ".exe"
+	}
+	require.NoError(t, compileTestServer(mockServerPath))
+
+	cmd := exec.Command(mockServerPath)
+	stdio, err := NewStdioFromCommand(cmd)
+	require.NoError(t, err)
+	require.NotNil(t, cmd.Process)
+	require.Equal(t, cmd.Process.Pid, stdio.PID())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, stdio.Start(ctx))
+	defer stdio.Close()
+	// Starting does not launch another process
+	require.Equal(t, cmd.Process.Pid, stdio.PID())
+
+	response, err := stdio.SendRequest(ctx, JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      mcp.NewRequestId(int64(1)),
+		Method:  "debug/echo",
+		Params:  map[string]any{"hello": "world"},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+}
+
+func TestStdio_PIDWithoutSubprocess(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	stdio := NewIO(reader, writer, io.NopCloser(reader))
+	require.Equal(t, 0, stdio.PID())
+}
+
+func TestStdio_WaitExit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	stdio := NewStdio("sh", nil, "-c", "sleep 0.1; exit 3")
+	require.NoError(t, stdio.Start(context.Background()))
+	defer stdio.Close()
+
+	select {
+	case <-stdio.exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading from the subprocess did not stop")
+	}
+	// The exit code is available by the time reading stops
+	select {
+	case code := <-stdio.WaitExit():
+		require.Equal(t, 3, code)
+	default:
+		t.Fatal("exit code not received before reading stopped")
+	}
+}
+
+func TestStdio_WaitExitKilled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	stdio := NewStdio("sh", nil, "-c", "exec sleep 10")
+	require.NoError(t, stdio.Start(context.Background()))
+	defer stdio.Close()
+	require.NoError(t, stdio.cmd.Process.Kill())
+
+	select {
+	case code := <-stdio.WaitExit():
+		require.Equal(t, -1, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("exit code not received")
+	}
+}
+
+func TestNewCommandTransport(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses cat")
+	}
+
+	stdio, err := NewCommandTransport("cat")
+	require.NoError(t, err)
+	require.NotZero(t, stdio.PID())
+
+	received := make(chan mcp.JSONRPCNotification, 1)
+	stdio.SetNotificationHandler(func(notification mcp.JSONRPCNotification) {
+		received <- notification
+	})
+	require.NoError(t, stdio.Start(context.Background()))
+
+	// cat echoes the notification back
+	notification := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: "notifications/echo",
+			Params: mcp.NotificationParams{AdditionalFields: map[string]any{"n": float64(1)}},
+		},
+	}
+	require.NoError(t, stdio.SendNotification(context.Background(), notification))
+	select {
+	case got := <-received:
+		require.Equal(t, "notifications/echo", got.Method)
+		require.Equal(t, map[string]any{"n": float64(1)}, got.Params.AdditionalFields)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not echoed")
+	}
+
+	require.NoError(t, stdio.Kill())
+	select {
+	case code := <-stdio.WaitExit():
+		require.Equal(t, -1, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subprocess did not exit")
+	}
+	require.Error(t, stdio.Close())
+}
+
+func TestStdio_KillWithoutSubprocess(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	require.Error(t, NewIO(reader, writer, io.NopCloser(reader)).Kill())
+}
+
+func TestStdio_OnDisconnect(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	stdio := NewStdio("sh", nil, "-c", "exit 2")
+	disconnected := make(chan error, 1)
+	stdio.OnDisconnect(func(err error) {
+		disconnected <- err
+	})
+	require.NoError(t, stdio.Start(context.Background()))
+	defer stdio.Close()
+
+	select {
+	case err := <-disconnected:
+		var exitErr *exec.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		require.Equal(t, 2, exitErr.ExitCode())
+	case <-time.After(5 * time.Second):
+		t.Fatal("disconnect not reported")
+	}
+
+	// Closing the transport is not reported as a disconnect
+	stdio = NewStdio("cat", nil)
+	stdio.OnDisconnect(func(err error) {
+		disconnected <- err
+	})
+	require.NoError(t, stdio.Start(context.Background()))
+	require.NoError(t, stdio.Close())
+	<-stdio.exited
+	require.Empty(t, disconnected)
+}
@@ -0,0 +1,29 @@
+package transport
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// insecureHTTPClient returns a copy of client that does not verify the
+// certificates of servers. Clients with a custom RoundTripper other than an
+// *http.Transport are returned unchanged.
+func insecureHTTPClient(client *http.Client) *http.Client {
+	var transport *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return client
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = true
+
+	insecure := *client
+	insecure.Transport = transport
+	return &insecure
+}
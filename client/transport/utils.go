@@ -2,6 +2,7 @@ package transport
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -24,3 +25,22 @@ func NewJSONRPCResultResponse(id mcp.RequestId, result json.RawMessage) *JSONRPC
 		Result:  result,
 	}
 }
+
+// orderBatchResponses returns the responses to a JSON-RPC batch in the order
+// of its requests, matching them by ID. It returns an error if a request has
+// no response.
+func orderBatchResponses(requests []JSONRPCRequest, responses []*JSONRPCResponse) ([]*JSONRPCResponse, error) {
+	byID := make(map[string]*JSONRPCResponse, len(responses))
+	for _, response := range responses {
+		byID[response.ID.String()] = response
+	}
+	ordered := make([]*JSONRPCResponse, len(requests))
+	for i, request := range requests {
+		response, ok := byID[request.ID.String()]
+		if !ok {
+			return nil, fmt.Errorf("no response to request %v of the batch", request.ID.Value())
+		}
+		ordered[i] = response
+	}
+	return ordered, nil
+}
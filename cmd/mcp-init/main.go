@@ -0,0 +1,167 @@
+// Command mcp-init generates the skeleton of a new MCP server project: a
+// main.go creating the server with a sample tool, a go.mod and a Makefile.
+//
+// Install it with:
+//
+//	go install github.com/mark3labs/mcp-go/cmd/mcp-init@latest
+//
+// Then create a project with:
+//
+//	mcp-init --name github.com/you/weather-server --transport stdio --with-resources --with-prompts
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+)
+
+const libraryPath = "github.com/mark3labs/mcp-go"
+
+var (
+	//go:embed templates/main.go.tmpl
+	mainTemplate string
+	//go:embed templates/go.mod.tmpl
+	goModTemplate string
+	//go:embed templates/Makefile.tmpl
+	makefileTemplate string
+)
+
+// options are the options of a generated project.
+type options struct {
+	// Module path of the project, whose last element names the server
+	Name string
+	// Transport serving the server: stdio, sse or http
+	Transport string
+	// Whether the server has a sample resource
+	WithResources bool
+	// Whether the server has a sample prompt
+	WithPrompts bool
+	// Version of mcp-go required by go.mod, none if empty
+	MCPVersion string
+}
+
+// ServerName returns the name of the generated server and binary.
+func (o options) ServerName() string {
+	return path.Base(o.Name)
+}
+
+func (o options) validate() error {
+	if o.Name == "" {
+		return errors.New("--name is required")
+	}
+	switch o.Transport {
+	case "stdio", "sse", "http":
+	default:
+		return fmt.Errorf("unsupported transport %q, expected stdio, sse or http", o.Transport)
+	}
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "mcp-init: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	var opts options
+	var dir string
+	flags := flag.NewFlagSet("mcp-init", flag.ContinueOnError)
+	flags.StringVar(&opts.Name, "name", "", "module path of the project, e.g. github.com/you/my-server")
+	flags.StringVar(&opts.Transport, "transport", "stdio", "transport serving the server: stdio, sse or http")
+	flags.BoolVar(&opts.WithResources, "with-resources", false, "add a sample resource")
+	flags.BoolVar(&opts.WithPrompts, "with-prompts", false, "add a sample prompt")
+	flags.StringVar(&opts.MCPVersion, "mcp-version", defaultMCPVersion(), "version of mcp-go to require, resolved by go mod tidy if empty")
+	flags.StringVar(&dir, "dir", "", "directory of the project, the last element of --name by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = opts.ServerName()
+	}
+
+	if err := generate(dir, opts); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Created %s in %s\n\nNext steps:\n\tcd %s\n\tmake build\n", opts.Name, dir, dir)
+	return nil
+}
+
+// defaultMCPVersion returns the version of mcp-go mcp-init was installed
+// from, or an empty string for development builds.
+func defaultMCPVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path != libraryPath || !strings.HasPrefix(info.Main.Version, "v") {
+		return ""
+	}
+	return info.Main.Version
+}
+
+// generate writes the files of a project to dir, refusing to overwrite
+// existing files.
+func generate(dir string, opts options) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name     string
+		template string
+		gofmt    bool
+	}{
+		{name: "main.go", template: mainTemplate, gofmt: true},
+		{name: "go.mod", template: goModTemplate},
+		{name: "Makefile", template: makefileTemplate},
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create project directory: %w", err)
+	}
+	for _, file := range files {
+		content, err := render(file.name, file.template, opts)
+		if err != nil {
+			return err
+		}
+		if file.gofmt {
+			if content, err = format.Source(content); err != nil {
+				return fmt.Errorf("format %s: %w", file.name, err)
+			}
+		}
+
+		filePath := filepath.Join(dir, file.name)
+		if _, err := os.Stat(filePath); err == nil {
+			return fmt.Errorf("%s already exists", filePath)
+		}
+		if err := os.WriteFile(filePath, content, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", file.name, err)
+		}
+	}
+	return nil
+}
+
+func render(name, text string, opts options) ([]byte, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template of %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, opts); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "weather")
+	opts := options{
+		Name:          "example.com/weather",
+		Transport:     "http",
+		WithResources: true,
+		WithPrompts:   true,
+		MCPVersion:    "v1.2.3",
+	}
+	require.NoError(t, generate(dir, opts))
+
+	main, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(main), `server.NewMCPServer(
+		"weather",`)
+	assert.Contains(t, string(main), "server.NewStreamableHTTPServer(s)")
+	assert.Contains(t, string(main), "s.AddResource(")
+	assert.Contains(t, string(main), "s.AddPrompt(")
+
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/weather\n\ngo 1.23\n\nrequire github.com/mark3labs/mcp-go v1.2.3\n", string(goMod))
+
+	makefile, err := os.ReadFile(filepath.Join(dir, "Makefile"))
+	require.NoError(t, err)
+	assert.Contains(t, string(makefile), "\tgo build -o bin/weather .\n")
+
+	// Existing projects are not overwritten
+	assert.ErrorContains(t, generate(dir, opts), "already exists")
+}
+
+func TestGenerateInvalidOptions(t *testing.T) {
+	dir := t.TempDir()
+	assert.ErrorContains(t, generate(dir, options{Transport: "stdio"}), "--name is required")
+	assert.ErrorContains(t, generate(dir, options{Name: "x", Transport: "websocket"}), "unsupported transport")
+}
+
+func TestRun(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project")
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{"--name", "example.com/tools", "--dir", dir}, &stdout))
+	assert.Contains(t, stdout.String(), "Created example.com/tools in "+dir)
+
+	main, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(main), "server.ServeStdio(s)")
+	assert.NotContains(t, string(main), "AddResource")
+	assert.NotContains(t, string(main), "AddPrompt")
+}
+
+// TestGeneratedProjectsBuild vets the generated projects against this
+// checkout of mcp-go.
+func TestGeneratedProjectsBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping build of generated projects in short mode")
+	}
+	_, file, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	root := filepath.Join(filepath.Dir(file), "..", "..")
+
+	for _, transport := range []string{"stdio", "sse", "http"} {
+		for _, extras := range []bool{false, true} {
+			dir := filepath.Join(t.TempDir(), "server")
+			require.NoError(t, generate(dir, options{
+				Name:          "example.com/server",
+				Transport:     transport,
+				WithResources: extras,
+				WithPrompts:   extras,
+			}))
+			goMod, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0)
+			require.NoError(t, err)
+			_, err = goMod.WriteString("\nrequire github.com/mark3labs/mcp-go v0.0.0\n\nreplace github.com/mark3labs/mcp-go => " + root + "\n")
+			require.NoError(t, err)
+			require.NoError(t, goMod.Close())
+
+			cmd := exec.Command("go", "vet", "./...")
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, "transport %s, extras %v: %s", transport, extras, strings.TrimSpace(string(output)))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// This example shows how to trace MCP requests with OpenTelemetry using
+// server.SetRequestHook. The directory name starts with an underscore so that
+// it is skipped by `go build ./...`, as OpenTelemetry is not a dependency of
+// mcp-go. To run it, copy it into a module that requires
+// go.opentelemetry.io/otel and an exporter of your choice.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// tracingHook starts a span for every MCP request and ends it once the
+// handler has returned, recording any error on the span.
+func tracingHook(tracer trace.Tracer) server.RequestHook {
+	return func(ctx context.Context, method string, req any) (context.Context, func(resp any, err error)) {
+		ctx, span := tracer.Start(ctx, "mcp "+method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("mcp.method", method)),
+		)
+
+		if call, ok := req.(*mcp.CallToolRequest); ok {
+			span.SetAttributes(attribute.String("mcp.tool", call.Params.Name))
+		}
+
+		return ctx, func(resp any, err error) {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// main serves a single "greet" tool over stdio with every request traced.
+// Configure a tracer provider with otel.SetTracerProvider before starting to
+// export the spans.
+func main() {
+	s := server.NewMCPServer(
+		"OpenTelemetry Demo",
+		"1.0.0",
+		server.WithToolCapabilities(false),
+	)
+	s.SetRequestHook(tracingHook(otel.Tracer("github.com/mark3labs/mcp-go/examples/otel")))
+
+	s.AddTool(mcp.NewTool("greet",
+		mcp.WithDescription("Greet someone by name"),
+		mcp.WithString("name", mcp.Required()),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Spans started here become children of the request span
+		_, span := otel.Tracer("greet").Start(ctx, "build greeting")
+		defer span.End()
+
+		name, err := request.RequireString("name")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Hello, %s!", name)), nil
+	})
+
+	if err := server.ServeStdio(s); err != nil {
+		fmt.Printf("Server error: %v\n", err)
+	}
+}
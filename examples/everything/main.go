@@ -423,7 +423,7 @@ func handleLongRunningOperationTool(
 
 	for i := 1; i < int(steps)+1; i++ {
 		time.Sleep(time.Duration(stepDuration * float64(time.Second)))
-		if progressToken != nil {
+		if !progressToken.IsZero() {
 			err := server.SendNotificationToClient(
 				ctx,
 				"notifications/progress",
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"maps"
+)
+
+// CompletionProvider suggests values for an argument of a tool or prompt,
+// answering completion/complete requests.
+type CompletionProvider interface {
+	// Complete returns the values of the argument starting with prefix, the
+	// value the user has typed so far.
+	Complete(ctx context.Context, prefix string) ([]string, error)
+}
+
+// CompletionProviderFunc adapts a function to a CompletionProvider.
+type CompletionProviderFunc func(ctx context.Context, prefix string) ([]string, error)
+
+// Complete implements CompletionProvider.
+func (f CompletionProviderFunc) Complete(ctx context.Context, prefix string) ([]string, error) {
+	return f(ctx, prefix)
+}
+
+// WithArgumentCompleter returns a copy of the tool completing the values of
+// the named argument with provider. The server must declare the completions
+// capability for clients to request completions.
+func (t Tool) WithArgumentCompleter(argName string, provider CompletionProvider) Tool {
+	t.completers = withCompleter(t.completers, argName, provider)
+	return t
+}
+
+// ArgumentCompleter returns the completion provider of the named argument, or
+// nil if it has none.
+func (t Tool) ArgumentCompleter(argName string) CompletionProvider {
+	return t.completers[argName]
+}
+
+// WithArgumentCompleter returns a copy of the prompt completing the values of
+// the named argument with provider. The server must declare the completions
+// capability for clients to request completions.
+func (p Prompt) WithArgumentCompleter(argName string, provider CompletionProvider) Prompt {
+	p.completers = withCompleter(p.completers, argName, provider)
+	return p
+}
+
+// ArgumentCompleter returns the completion provider of the named argument, or
+// nil if it has none.
+func (p Prompt) ArgumentCompleter(argName string) CompletionProvider {
+	return p.completers[argName]
+}
+
+// withCompleter returns a copy of completers with provider set for argName,
+// leaving the original map untouched since it may be shared by other copies.
+func withCompleter(completers map[string]CompletionProvider, argName string, provider CompletionProvider) map[string]CompletionProvider {
+	updated := make(map[string]CompletionProvider, len(completers)+1)
+	maps.Copy(updated, completers)
+	updated[argName] = provider
+	return updated
+}
@@ -27,6 +27,12 @@ var (
 
 	// ErrResourceNotFound indicates a requested resource was not found (code: RESOURCE_NOT_FOUND).
 	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrTooManyRequests indicates the client exceeded a rate limit (code: TOO_MANY_REQUESTS).
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrServiceUnavailable indicates the server cannot handle requests for now (code: SERVICE_UNAVAILABLE).
+	ErrServiceUnavailable = errors.New("service unavailable")
 )
 
 // UnsupportedProtocolVersionError is returned when the server responds with
@@ -51,28 +57,39 @@ func IsUnsupportedProtocolVersion(err error) bool {
 	return ok
 }
 
-// AsError maps JSONRPCErrorDetails to a Go error.
-// Returns sentinel errors wrapped with custom messages for known codes.
-// Defaults to a generic error with the original message when the code is not mapped.
-func (e *JSONRPCErrorDetails) AsError() error {
-	var err error
-
-	switch e.Code {
+// sentinelForCode returns the sentinel error for a known JSON-RPC error code,
+// or nil if the code is not mapped.
+func sentinelForCode(code int) error {
+	switch code {
 	case PARSE_ERROR:
-		err = ErrParseError
+		return ErrParseError
 	case INVALID_REQUEST:
-		err = ErrInvalidRequest
+		return ErrInvalidRequest
 	case METHOD_NOT_FOUND:
-		err = ErrMethodNotFound
+		return ErrMethodNotFound
 	case INVALID_PARAMS:
-		err = ErrInvalidParams
+		return ErrInvalidParams
 	case INTERNAL_ERROR:
-		err = ErrInternalError
+		return ErrInternalError
 	case REQUEST_INTERRUPTED:
-		err = ErrRequestInterrupted
+		return ErrRequestInterrupted
 	case RESOURCE_NOT_FOUND:
-		err = ErrResourceNotFound
+		return ErrResourceNotFound
+	case TOO_MANY_REQUESTS:
+		return ErrTooManyRequests
+	case SERVICE_UNAVAILABLE:
+		return ErrServiceUnavailable
 	default:
+		return nil
+	}
+}
+
+// AsError maps JSONRPCErrorDetails to a Go error.
+// Returns sentinel errors wrapped with custom messages for known codes.
+// Defaults to a generic error with the original message when the code is not mapped.
+func (e *JSONRPCErrorDetails) AsError() error {
+	err := sentinelForCode(e.Code)
+	if err == nil {
 		return errors.New(e.Message)
 	}
 
@@ -83,3 +100,88 @@ func (e *JSONRPCErrorDetails) AsError() error {
 
 	return err
 }
+
+// MCPError is a structured JSON-RPC error. Use the standard codes such as
+// PARSE_ERROR, INVALID_REQUEST, METHOD_NOT_FOUND, INVALID_PARAMS and
+// INTERNAL_ERROR, or an application-defined code.
+//
+// Handlers may return an *MCPError (optionally wrapped) to control the code,
+// message and data of the JSON-RPC error response sent by the server.
+// errors.Is reports a match against the sentinel error for the code, e.g.
+// ErrInvalidParams for INVALID_PARAMS.
+type MCPError struct {
+	// The error type that occurred.
+	Code int `json:"code"`
+	// A short description of the error.
+	Message string `json:"message"`
+	// Additional information about the error.
+	Data any `json:"data,omitempty"`
+}
+
+// NewMCPError creates a new MCPError with the given code, message, and data.
+func NewMCPError(code int, message string, data any) *MCPError {
+	return &MCPError{
+		Code:    code,
+		Message: message,
+		Data:    data,
+	}
+}
+
+func (e *MCPError) Error() string {
+	if e.Message == "" {
+		if sentinel := sentinelForCode(e.Code); sentinel != nil {
+			return sentinel.Error()
+		}
+		return fmt.Sprintf("json-rpc error %d", e.Code)
+	}
+	return e.Message
+}
+
+// Is implements the errors.Is interface, matching the sentinel error for the code.
+func (e *MCPError) Is(target error) bool {
+	sentinel := sentinelForCode(e.Code)
+	return sentinel != nil && sentinel == target
+}
+
+// Details converts the error to JSONRPCErrorDetails for use in a response.
+func (e *MCPError) Details() JSONRPCErrorDetails {
+	return NewJSONRPCErrorDetails(e.Code, e.Error(), e.Data)
+}
+
+// ValidationError describes a field of the parameters of a request that
+// failed validation.
+type ValidationError struct {
+	// Field is the JSON pointer of the invalid field, such as "/query", or ""
+	// for the parameters themselves.
+	Field string `json:"field"`
+	// Code identifies the problem, such as the JSON Schema keyword the field
+	// does not satisfy.
+	Code string `json:"code"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// ValidationErrorData is the data of an INVALID_PARAMS error listing the
+// fields that failed validation, in the style of RFC 7807 problem details.
+type ValidationErrorData struct {
+	// Title is a short summary of the problem.
+	Title string `json:"title"`
+	// Detail describes this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Errors lists the fields that failed validation and why.
+	Errors []ValidationError `json:"errors"`
+}
+
+// NewValidationErrorData creates the data of an INVALID_PARAMS error
+// reporting the given validation errors.
+func NewValidationErrorData(errors []ValidationError) any {
+	detail := "1 field failed validation"
+	if len(errors) != 1 {
+		detail = fmt.Sprintf("%d fields failed validation", len(errors))
+	}
+	return ValidationErrorData{
+		Title:  "Invalid params",
+		Detail: detail,
+		Errors: errors,
+	}
+}
@@ -1,7 +1,9 @@
 package mcp
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -70,6 +72,24 @@ func TestJSONRPCErrorDetails_AsError(t *testing.T) {
 			expectedType:    ErrResourceNotFound,
 			expectedMessage: "resource not found: resource 'foo' not found",
 		},
+		{
+			name: "too many requests",
+			details: JSONRPCErrorDetails{
+				Code:    TOO_MANY_REQUESTS,
+				Message: "too many requests",
+			},
+			expectedType:    ErrTooManyRequests,
+			expectedMessage: "too many requests",
+		},
+		{
+			name: "service unavailable",
+			details: JSONRPCErrorDetails{
+				Code:    SERVICE_UNAVAILABLE,
+				Message: "circuit breaker is open",
+			},
+			expectedType:    ErrServiceUnavailable,
+			expectedMessage: "service unavailable: circuit breaker is open",
+		},
 		{
 			name: "unknown error code",
 			details: JSONRPCErrorDetails{
@@ -168,3 +188,74 @@ func TestErrorChaining(t *testing.T) {
 	// But the original error should
 	require.True(t, errors.Is(err, ErrMethodNotFound))
 }
+
+func TestMCPError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("implements error with message", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewMCPError(INVALID_PARAMS, "missing field 'name'", map[string]any{"field": "name"})
+		require.EqualError(t, err, "missing field 'name'")
+		require.Equal(t, INVALID_PARAMS, err.Code)
+	})
+
+	t.Run("falls back to sentinel message", func(t *testing.T) {
+		t.Parallel()
+
+		require.EqualError(t, NewMCPError(METHOD_NOT_FOUND, "", nil), "method not found")
+		require.EqualError(t, NewMCPError(-1, "", nil), "json-rpc error -1")
+	})
+
+	t.Run("matches sentinel for code", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewMCPError(PARSE_ERROR, "bad json", nil)
+		require.ErrorIs(t, err, ErrParseError)
+		require.NotErrorIs(t, err, ErrInvalidRequest)
+		require.NotErrorIs(t, NewMCPError(-1, "custom", nil), ErrInternalError)
+	})
+
+	t.Run("errors.As through wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		wrapped := fmt.Errorf("tool failed: %w", NewMCPError(INTERNAL_ERROR, "boom", nil))
+
+		var mcpErr *MCPError
+		require.True(t, errors.As(wrapped, &mcpErr))
+		require.Equal(t, INTERNAL_ERROR, mcpErr.Code)
+		require.ErrorIs(t, wrapped, ErrInternalError)
+	})
+
+	t.Run("details", func(t *testing.T) {
+		t.Parallel()
+
+		details := NewMCPError(INVALID_REQUEST, "nope", "extra").Details()
+		require.Equal(t, JSONRPCErrorDetails{Code: INVALID_REQUEST, Message: "nope", Data: "extra"}, details)
+	})
+}
+
+func TestNewValidationErrorData(t *testing.T) {
+	t.Parallel()
+
+	data := NewValidationErrorData([]ValidationError{
+		{Field: "/query", Code: "required", Message: "is required"},
+		{Field: "/limit", Code: "minimum", Message: "must be at least 1"},
+	})
+	encoded, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"title": "Invalid params",
+		"detail": "2 fields failed validation",
+		"errors": [
+			{"field": "/query", "code": "required", "message": "is required"},
+			{"field": "/limit", "code": "minimum", "message": "must be at least 1"}
+		]
+	}`, string(encoded))
+
+	// The errors of schema validation convert to validation errors
+	err = ValidateAgainstSchema(json.RawMessage(`{"type": "object", "required": ["query"]}`), map[string]any{})
+	var schemaErr *SchemaValidationError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Equal(t, ValidationError{Field: "/query", Code: "required", Message: "is required"}, schemaErr.ValidationError())
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// assertIdempotentRoundTrip checks that decoding and encoding again the
+// encoding of a value gives the same encoding.
+func assertIdempotentRoundTrip(t *testing.T, encoded []byte, decode func([]byte) (any, error)) {
+	t.Helper()
+	decoded, err := decode(encoded)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", encoded, err)
+	}
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("failed to encode %#v: %v", decoded, err)
+	}
+	if string(reencoded) != string(encoded) {
+		t.Fatalf("round trip is not idempotent:\n  first:  %s\n  second: %s", encoded, reencoded)
+	}
+}
+
+func FuzzMarshalMeta(f *testing.F) {
+	for _, seed := range []string{
+		`{}`,
+		`{"progressToken":"abc123"}`,
+		`{"progressToken":42}`,
+		`{"progressToken":null}`,
+		`{"custom":"value","count":1.5}`,
+		`{"progressToken":"token","nested":{"deep":[1,"two",null,true]}}`,
+		`{"":""}`,
+		`{"unicode":"é😀","escaped":"<a href=\"x\">&</a>"}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	decode := func(data []byte) (any, error) {
+		var meta Meta
+		err := json.Unmarshal(data, &meta)
+		return &meta, err
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := decode(data)
+		if err != nil {
+			return
+		}
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("failed to encode meta decoded from %q: %v", data, err)
+		}
+		assertIdempotentRoundTrip(t, encoded, decode)
+	})
+}
+
+func FuzzMarshalCallToolResult(f *testing.F) {
+	for _, seed := range []string{
+		`{"content":[]}`,
+		`{"content":[{"type":"text","text":"hello"}]}`,
+		`{"content":[{"type":"text","text":"failed"}],"isError":true}`,
+		`{"content":[{"type":"image","data":"aW1hZ2U=","mimeType":"image/png"}]}`,
+		`{"content":[{"type":"audio","data":"YXVkaW8=","mimeType":"audio/wav"}]}`,
+		`{"content":[{"type":"resource","resource":{"uri":"file:///a.txt","text":"a","mimeType":"text/plain"}}]}`,
+		`{"content":[{"type":"resource","resource":{"uri":"file:///a.bin","blob":"YQ=="}}]}`,
+		`{"content":[{"type":"resource_link","uri":"file:///a.txt","name":"a"}]}`,
+		`{"content":[{"type":"text","text":"x"}],"structuredContent":{"value":1}}`,
+		`{"_meta":{"progressToken":"t","key":"v"},"content":[{"type":"text","text":"x"}]}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	decode := func(data []byte) (any, error) {
+		var result CallToolResult
+		err := json.Unmarshal(data, &result)
+		return &result, err
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := decode(data)
+		if err != nil {
+			return
+		}
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			// Decoded values are not guaranteed to be encodable, for example
+			// resources with neither text nor blob
+			return
+		}
+		assertIdempotentRoundTrip(t, encoded, decode)
+	})
+}
+
+func FuzzMarshalResourceContents(f *testing.F) {
+	for _, seed := range []string{
+		`{"uri":"file:///a.txt","text":"content"}`,
+		`{"uri":"file:///a.txt","text":"content","mimeType":"text/plain"}`,
+		`{"uri":"file:///a.bin","blob":"YmxvYg==","mimeType":"application/octet-stream"}`,
+		`{"uri":"file:///a.txt","text":"content","_meta":{"revision":9007199254740993}}`,
+		`{"uri":"file:///a.txt"}`,
+		`{"text":"no uri"}`,
+		`{"uri":"file:///a.txt","_meta":"not an object","text":"x"}`,
+		`[]`,
+		`null`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	decode := func(data []byte) (any, error) {
+		return ParseResourceContentsJSON(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := decode(data)
+		if err != nil {
+			return
+		}
+		encoded, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("failed to encode resource contents decoded from %q: %v", data, err)
+		}
+		assertIdempotentRoundTrip(t, encoded, decode)
+	})
+}
@@ -1,6 +1,11 @@
 package mcp
 
-import "net/http"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 /* Prompts */
 
@@ -42,6 +47,22 @@ type GetPromptResult struct {
 	Messages    []PromptMessage `json:"messages"`
 }
 
+// SystemPrompt returns the text of the first message of the prompt with the
+// conventional role RoleSystem, or an empty string if there is none or its
+// content is not text.
+func (r GetPromptResult) SystemPrompt() string {
+	for _, message := range r.Messages {
+		if message.Role != RoleSystem {
+			continue
+		}
+		if text, ok := AsTextContent(message.Content); ok {
+			return text.Text
+		}
+		return ""
+	}
+	return ""
+}
+
 // Prompt represents a prompt or prompt template that the server offers.
 // If Arguments is non-nil and non-empty, this indicates the prompt is a template
 // that requires argument values to be provided when calling prompts/get.
@@ -56,6 +77,9 @@ type Prompt struct {
 	// A list of arguments to use for templating the prompt.
 	// The presence of arguments indicates this is a template prompt.
 	Arguments []PromptArgument `json:"arguments,omitempty"`
+
+	// Completion providers of the arguments, by argument name
+	completers map[string]CompletionProvider
 }
 
 // GetName returns the name of the prompt.
@@ -63,6 +87,37 @@ func (p Prompt) GetName() string {
 	return p.Name
 }
 
+// SetMeta sets key in the _meta of the prompt, for example to carry UI hints
+// or versioning information.
+func (p *Prompt) SetMeta(key string, value any) {
+	p.Meta = metaWithField(p.Meta, key, value)
+}
+
+// GetMeta returns the value of key in the _meta of the prompt.
+func (p Prompt) GetMeta(key string) (any, bool) {
+	return metaField(p.Meta, key)
+}
+
+// ValidatePromptArguments checks that args contains a value for every argument
+// the prompt marks as required. Optional arguments may be omitted. The returned
+// error wraps ErrInvalidParams and lists the missing argument names.
+func ValidatePromptArguments(prompt Prompt, args map[string]string) error {
+	var missing []string
+	for _, arg := range prompt.Arguments {
+		if !arg.Required {
+			continue
+		}
+		if _, ok := args[arg.Name]; !ok {
+			missing = append(missing, arg.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing required arguments for prompt '%s': %s",
+			ErrInvalidParams, prompt.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // PromptArgument describes an argument that a prompt template can accept.
 // When a prompt includes arguments, clients must provide values for all
 // required arguments when making a prompts/get request.
@@ -83,6 +138,9 @@ type Role string
 const (
 	RoleUser      Role = "user"
 	RoleAssistant Role = "assistant"
+	// RoleSystem is not part of the MCP specification, but is conventionally
+	// used for a system prompt among the messages of a prompt.
+	RoleSystem Role = "system"
 )
 
 // PromptMessage describes a message returned as part of a prompt.
@@ -94,6 +152,31 @@ type PromptMessage struct {
 	Content Content `json:"content"` // Can be TextContent, ImageContent, AudioContent or EmbeddedResource
 }
 
+// UnmarshalJSON implements custom JSON unmarshaling for PromptMessage,
+// resolving Content to its concrete type from the "type" field.
+func (m *PromptMessage) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Role    Role            `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Role = raw.Role
+	m.Content = nil
+
+	if len(raw.Content) > 0 && string(raw.Content) != "null" {
+		content, err := UnmarshalContent(raw.Content)
+		if err != nil {
+			return err
+		}
+		m.Content = content
+	}
+
+	return nil
+}
+
 // PromptListChangedNotification is an optional notification from the server
 // to the client, informing it that the list of prompts it offers has changed. This
 // may be issued by servers without any previous subscription from the client.
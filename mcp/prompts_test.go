@@ -285,3 +285,165 @@ func TestPromptJSONStructure(t *testing.T) {
 	assert.Equal(t, "Second argument", arg2["description"])
 	// Optional arguments may not have "required" field or it's false
 }
+
+func TestGetPromptResultUnmarshalJSON(t *testing.T) {
+	original := NewGetPromptResult("Mixed content prompt", []PromptMessage{
+		NewPromptMessage(RoleUser, NewTextContent("Describe this image")),
+		NewPromptMessage(RoleUser, NewImageContent("base64data", "image/png")),
+		NewPromptMessage(RoleAssistant, NewEmbeddedResource(TextResourceContents{
+			URI:      "file:///notes.txt",
+			MIMEType: "text/plain",
+			Text:     "notes",
+		})),
+	})
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var result GetPromptResult
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, "Mixed content prompt", result.Description)
+	require.Len(t, result.Messages, 3)
+
+	assert.Equal(t, RoleUser, result.Messages[0].Role)
+	assert.Equal(t, NewTextContent("Describe this image"), result.Messages[0].Content)
+
+	assert.Equal(t, RoleUser, result.Messages[1].Role)
+	assert.Equal(t, NewImageContent("base64data", "image/png"), result.Messages[1].Content)
+
+	assert.Equal(t, RoleAssistant, result.Messages[2].Role)
+	embedded, ok := result.Messages[2].Content.(EmbeddedResource)
+	require.True(t, ok)
+	assert.Equal(t, ContentTypeResource, embedded.Type)
+	resource, ok := embedded.Resource.(TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "file:///notes.txt", resource.URI)
+	assert.Equal(t, "notes", resource.Text)
+}
+
+func TestPromptMessageUnmarshalJSONUnknownType(t *testing.T) {
+	var msg PromptMessage
+	err := json.Unmarshal([]byte(`{"role": "user", "content": {"type": "video"}}`), &msg)
+	assert.ErrorContains(t, err, "unknown content type: video")
+}
+
+func TestValidatePromptArguments(t *testing.T) {
+	prompt := NewPrompt("search",
+		WithArgument("query", RequiredArgument()),
+		WithArgument("sort", RequiredArgument()),
+		WithArgument("limit"))
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		wantErr string
+	}{
+		{
+			name: "all arguments present",
+			args: map[string]string{"query": "go", "sort": "asc", "limit": "10"},
+		},
+		{
+			name: "optional argument omitted",
+			args: map[string]string{"query": "go", "sort": "asc"},
+		},
+		{
+			name:    "one required argument missing",
+			args:    map[string]string{"query": "go"},
+			wantErr: "sort",
+		},
+		{
+			name:    "nil arguments",
+			args:    nil,
+			wantErr: "query, sort",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromptArguments(prompt, tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidParams)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("prompt without arguments", func(t *testing.T) {
+		assert.NoError(t, ValidatePromptArguments(NewPrompt("static"), nil))
+	})
+}
+
+func TestPromptSetMeta(t *testing.T) {
+	prompt := NewPrompt("greeting")
+	_, ok := prompt.GetMeta("version")
+	assert.False(t, ok)
+
+	prompt.SetMeta("version", "1.2.0")
+
+	data, err := json.Marshal(prompt)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"version":"1.2.0"}`)
+
+	var decoded Prompt
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	version, ok := decoded.GetMeta("version")
+	require.True(t, ok)
+	assert.Equal(t, "1.2.0", version)
+}
+
+func TestGetPromptResult_SystemPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []PromptMessage
+		expected string
+	}{
+		{
+			name: "system message first",
+			messages: []PromptMessage{
+				NewPromptMessage(RoleSystem, NewTextContent("You are a helpful assistant.")),
+				NewPromptMessage(RoleUser, NewTextContent("Hello")),
+			},
+			expected: "You are a helpful assistant.",
+		},
+		{
+			name: "first of several system messages",
+			messages: []PromptMessage{
+				NewPromptMessage(RoleUser, NewTextContent("Hello")),
+				NewPromptMessage(RoleSystem, NewTextContent("Be brief.")),
+				NewPromptMessage(RoleSystem, NewTextContent("Be polite.")),
+			},
+			expected: "Be brief.",
+		},
+		{
+			name: "no system message",
+			messages: []PromptMessage{
+				NewPromptMessage(RoleUser, NewTextContent("Hello")),
+				NewPromptMessage(RoleAssistant, NewTextContent("Hi")),
+			},
+			expected: "",
+		},
+		{
+			name: "system message without text",
+			messages: []PromptMessage{
+				NewPromptMessage(RoleSystem, NewImageContent("data", "image/png")),
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGetPromptResult("prompt", tt.messages)
+			assert.Equal(t, tt.expected, result.SystemPrompt())
+		})
+	}
+
+	// Decoded results have a system prompt too
+	var result GetPromptResult
+	require.NoError(t, json.Unmarshal([]byte(`{"messages": [{"role": "system", "content": {"type": "text", "text": "Be brief."}}]}`), &result))
+	assert.Equal(t, "Be brief.", result.SystemPrompt())
+}
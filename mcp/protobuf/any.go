@@ -0,0 +1,39 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// CallToolResultTypeURL is the type URL of the google.protobuf.Any messages
+// made by ToAny.
+const CallToolResultTypeURL = "type.googleapis.com/mcp.CallToolResult"
+
+// ToAny wraps the JSON encoding of result in a google.protobuf.Any with the
+// type URL CallToolResultTypeURL, for sending it over gRPC.
+func ToAny(result mcp.CallToolResult) (*anypb.Any, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool result: %w", err)
+	}
+	return &anypb.Any{TypeUrl: CallToolResultTypeURL, Value: data}, nil
+}
+
+// FromAny decodes a CallToolResult wrapped by ToAny.
+func FromAny(message *anypb.Any) (*mcp.CallToolResult, error) {
+	if message == nil {
+		return nil, fmt.Errorf("protobuf Any message is nil")
+	}
+	if message.GetTypeUrl() != CallToolResultTypeURL {
+		return nil, fmt.Errorf("unexpected protobuf Any type URL %q, want %q", message.GetTypeUrl(), CallToolResultTypeURL)
+	}
+
+	var result mcp.CallToolResult
+	if err := json.Unmarshal(message.GetValue(), &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tool result: %w", err)
+	}
+	return &result, nil
+}
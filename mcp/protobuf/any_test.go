@@ -0,0 +1,31 @@
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAnyRoundTrip(t *testing.T) {
+	result := mcp.NewToolResultBuilder().
+		AddText("Generated the chart").
+		AddImage([]byte("png-bytes"), "image/png").
+		AddResourceLink("file:///chart.csv", "chart.csv", "Chart data", "text/csv").
+		SetError(true).
+		Build()
+	result.StructuredContent = map[string]any{"points": float64(3)}
+
+	message, err := ToAny(*result)
+	require.NoError(t, err)
+	assert.Equal(t, "type.googleapis.com/mcp.CallToolResult", message.GetTypeUrl())
+
+	decoded, err := FromAny(message)
+	require.NoError(t, err)
+	assert.Equal(t, result, decoded)
+
+	_, err = FromAny(&anypb.Any{TypeUrl: "type.googleapis.com/mcp.Tool", Value: message.GetValue()})
+	assert.ErrorContains(t, err, "unexpected protobuf Any type URL")
+}
@@ -0,0 +1,130 @@
+// Package protobuf converts between MCP types and protobuf, for integrating
+// MCP servers with gRPC services. It is separate from package mcp so that
+// only its users depend on google.golang.org/protobuf.
+package protobuf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewTool creates a tool whose input schema describes the protobuf message
+// desc, such as the request message of a gRPC method. The tool is named after
+// the message and described by its leading comment, if the descriptor carries
+// source information.
+//
+// Properties use the JSON names of the fields, as protojson does. Scalar
+// fields map to the matching JSON Schema types, enums to strings restricted
+// to their value names, bytes to base64 encoded strings, repeated fields to
+// arrays and message fields to nested objects. Fields marked required in
+// proto2 are required. Map fields, oneofs and recursive messages are not
+// supported yet and cause an error.
+func NewTool(desc protoreflect.MessageDescriptor) (mcp.Tool, error) {
+	properties, required, err := messageProperties(desc, nil)
+	if err != nil {
+		return mcp.Tool{}, fmt.Errorf("failed to convert message %s: %w", desc.FullName(), err)
+	}
+
+	tool := mcp.NewTool(string(desc.Name()))
+	tool.Description = comment(desc)
+	tool.InputSchema.Properties = properties
+	tool.InputSchema.Required = required
+	return tool, nil
+}
+
+// messageProperties returns the properties of the fields of desc and
+// the names of its required fields. parents holds the messages desc is nested
+// in, guarding against recursive messages.
+func messageProperties(
+	desc protoreflect.MessageDescriptor,
+	parents []protoreflect.FullName,
+) (map[string]any, []string, error) {
+	for _, parent := range parents {
+		if parent == desc.FullName() {
+			return nil, nil, fmt.Errorf("recursive message %s is not supported", desc.FullName())
+		}
+	}
+	parents = append(parents, desc.FullName())
+
+	fields := desc.Fields()
+	properties := make(map[string]any, fields.Len())
+	var required []string
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() {
+			return nil, nil, fmt.Errorf("map field %s is not supported", field.FullName())
+		}
+		// Proto3 optional fields belong to synthetic oneofs, which are fine
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			return nil, nil, fmt.Errorf("field %s of oneof %s is not supported", field.FullName(), oneof.Name())
+		}
+
+		property, err := fieldSchema(field, parents)
+		if err != nil {
+			return nil, nil, err
+		}
+		if field.Cardinality() == protoreflect.Repeated {
+			property = map[string]any{"type": "array", "items": property}
+		}
+		if comment := comment(field); comment != "" {
+			property["description"] = comment
+		}
+
+		properties[field.JSONName()] = property
+		if field.Cardinality() == protoreflect.Required {
+			required = append(required, field.JSONName())
+		}
+	}
+	return properties, required, nil
+}
+
+// fieldSchema returns the schema of a single value of field.
+func fieldSchema(field protoreflect.FieldDescriptor, parents []protoreflect.FullName) (map[string]any, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "integer"}, nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}, nil
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}, nil
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}, nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		properties, required, err := messageProperties(field.Message(), parents)
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("field %s has unsupported kind %s", field.FullName(), field.Kind())
+	}
+}
+
+// comment returns the leading comment of desc, if its file carries
+// source information.
+func comment(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	if file == nil {
+		return ""
+	}
+	return strings.TrimSpace(file.SourceLocations().ByDescriptor(desc).LeadingComments)
+}
@@ -0,0 +1,178 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// testProtoFile builds the descriptor of:
+//
+//	syntax = "proto2";
+//	package test;
+//
+//	enum Order { ORDER_RELEVANCE = 0; ORDER_DATE = 1; }
+//	// Searches the documents.
+//	message SearchRequest {
+//	  required string query = 1;
+//	  optional int64 page_size = 2;
+//	  repeated string tags = 3;
+//	  optional bytes cursor = 4;
+//	  optional Order order = 5;
+//	  optional Filter filter = 6;
+//	}
+//	message Filter { optional bool exact = 1; optional double min_score = 2; }
+//	message WithMap { map<string, string> labels = 1; }
+//	message WithOneof { oneof target { string id = 1; string name = 2; } }
+//	message Node { optional Node next = 1; }
+func testProtoFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Type:   kind.Enum(),
+			Label:  label.Enum(),
+		}
+	}
+	typed := func(f *descriptorpb.FieldDescriptorProto, typeName string) *descriptorpb.FieldDescriptorProto {
+		f.TypeName = proto.String(typeName)
+		return f
+	}
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	stringKind := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	messageKind := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+
+	idField := field("id", 1, stringKind, optional)
+	idField.OneofIndex = proto.Int32(0)
+	nameField := field("name", 2, stringKind, optional)
+	nameField.OneofIndex = proto.Int32(0)
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto2"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Order"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("ORDER_RELEVANCE"), Number: proto.Int32(0)},
+				{Name: proto.String("ORDER_DATE"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("SearchRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("query", 1, stringKind, descriptorpb.FieldDescriptorProto_LABEL_REQUIRED),
+					field("page_size", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional),
+					field("tags", 3, stringKind, repeated),
+					field("cursor", 4, descriptorpb.FieldDescriptorProto_TYPE_BYTES, optional),
+					typed(field("order", 5, descriptorpb.FieldDescriptorProto_TYPE_ENUM, optional), ".test.Order"),
+					typed(field("filter", 6, messageKind, optional), ".test.Filter"),
+				},
+			},
+			{
+				Name: proto.String("Filter"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("exact", 1, descriptorpb.FieldDescriptorProto_TYPE_BOOL, optional),
+					field("min_score", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, optional),
+				},
+			},
+			{
+				Name: proto.String("WithMap"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					typed(field("labels", 1, messageKind, repeated), ".test.WithMap.LabelsEntry"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("LabelsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, stringKind, optional),
+						field("value", 2, stringKind, optional),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+			{
+				Name:      proto.String("WithOneof"),
+				Field:     []*descriptorpb.FieldDescriptorProto{idField, nameField},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("target")}},
+			},
+			{
+				Name: proto.String("Node"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					typed(field("next", 1, messageKind, optional), ".test.Node"),
+				},
+			},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{{
+				// The first message of the file
+				Path:            []int32{4, 0},
+				Span:            []int32{4, 0, 11, 1},
+				LeadingComments: proto.String(" Searches the documents.\n"),
+			}},
+		},
+	}
+
+	fd, err := protodesc.NewFile(file, nil)
+	require.NoError(t, err)
+	return fd
+}
+
+func TestNewTool(t *testing.T) {
+	messages := testProtoFile(t).Messages()
+
+	tool, err := NewTool(messages.ByName("SearchRequest"))
+	require.NoError(t, err)
+	assert.Equal(t, "SearchRequest", tool.Name)
+	assert.Equal(t, "Searches the documents.", tool.Description)
+
+	data, err := json.Marshal(tool.InputSchema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string"},
+			"pageSize": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"cursor": {"type": "string", "contentEncoding": "base64"},
+			"order": {"type": "string", "enum": ["ORDER_RELEVANCE", "ORDER_DATE"]},
+			"filter": {
+				"type": "object",
+				"properties": {
+					"exact": {"type": "boolean"},
+					"minScore": {"type": "number"}
+				}
+			}
+		},
+		"required": ["query"]
+	}`, string(data))
+}
+
+func TestNewTool_Unsupported(t *testing.T) {
+	messages := testProtoFile(t).Messages()
+
+	tests := []struct {
+		message protoreflect.Name
+		err     string
+	}{
+		{message: "WithMap", err: "map field test.WithMap.labels is not supported"},
+		{message: "WithOneof", err: "field test.WithOneof.id of oneof target is not supported"},
+		{message: "Node", err: "recursive message test.Node is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.message), func(t *testing.T) {
+			_, err := NewTool(messages.ByName(tt.message))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package mcp
 
-import "github.com/yosida95/uritemplate/v3"
+import (
+	"mime"
+	"strings"
+
+	"github.com/yosida95/uritemplate/v3"
+)
 
 // ResourceOption is a function that configures a Resource.
 // It provides a flexible way to set various properties of a Resource using the functional options pattern.
@@ -97,3 +102,92 @@ func WithTemplateAnnotations(audience []Role, priority float64) ResourceTemplate
 		t.Annotations.Priority = priority
 	}
 }
+
+// Keys in the _meta of a resources/list request that ask the server to only
+// return matching resources.
+const (
+	ResourceFilterMIMETypeKey  = "mimeType"
+	ResourceFilterURIPrefixKey = "uriPrefix"
+)
+
+// ListResourcesOption is a function that configures a ListResourcesRequest.
+type ListResourcesOption func(*ListResourcesRequest)
+
+// NewListResourcesRequest creates a resources/list request configured by the
+// given options.
+func NewListResourcesRequest(opts ...ListResourcesOption) ListResourcesRequest {
+	request := ListResourcesRequest{}
+	request.Method = string(MethodResourcesList)
+
+	for _, opt := range opts {
+		opt(&request)
+	}
+
+	return request
+}
+
+// WithMIMETypeFilter asks the server to only list resources with the given
+// MIME type. Parameters such as charset are ignored when comparing.
+func WithMIMETypeFilter(mimeType string) ListResourcesOption {
+	return func(r *ListResourcesRequest) {
+		r.setFilter(ResourceFilterMIMETypeKey, mimeType)
+	}
+}
+
+// WithURIPrefixFilter asks the server to only list resources whose URI starts
+// with the given prefix.
+func WithURIPrefixFilter(prefix string) ListResourcesOption {
+	return func(r *ListResourcesRequest) {
+		r.setFilter(ResourceFilterURIPrefixKey, prefix)
+	}
+}
+
+func (r *ListResourcesRequest) setFilter(key, value string) {
+	if r.Params.Meta == nil {
+		r.Params.Meta = &Meta{}
+	}
+	r.Params.Meta.SetAdditionalField(key, value)
+}
+
+// MIMETypeFilter returns the MIME type filter of the request, if any.
+func (r ListResourcesRequest) MIMETypeFilter() string {
+	return r.filter(ResourceFilterMIMETypeKey)
+}
+
+// URIPrefixFilter returns the URI prefix filter of the request, if any.
+func (r ListResourcesRequest) URIPrefixFilter() string {
+	return r.filter(ResourceFilterURIPrefixKey)
+}
+
+func (r ListResourcesRequest) filter(key string) string {
+	if r.Params.Meta == nil {
+		return ""
+	}
+	value, _ := r.Params.Meta.GetAdditionalField(key)
+	s, _ := value.(string)
+	return s
+}
+
+// MatchesFilter reports whether resource satisfies the MIME type and URI
+// prefix filters of the request. A request without filters matches every
+// resource.
+func (r ListResourcesRequest) MatchesFilter(resource Resource) bool {
+	if prefix := r.URIPrefixFilter(); prefix != "" && !strings.HasPrefix(resource.URI, prefix) {
+		return false
+	}
+	if mimeType := r.MIMETypeFilter(); mimeType != "" && !sameMediaType(resource.MIMEType, mimeType) {
+		return false
+	}
+	return true
+}
+
+// sameMediaType compares two MIME types, ignoring case and parameters.
+func sameMediaType(a, b string) bool {
+	if mediaType, _, err := mime.ParseMediaType(a); err == nil {
+		a = mediaType
+	}
+	if mediaType, _, err := mime.ParseMediaType(b); err == nil {
+		b = mediaType
+	}
+	return strings.EqualFold(a, b)
+}
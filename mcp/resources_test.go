@@ -313,3 +313,75 @@ func TestTemplateAnnotationsCreationFromNil(t *testing.T) {
 	assert.Equal(t, []Role{RoleAssistant}, template.Annotations.Audience)
 	assert.Equal(t, 2.0, template.Annotations.Priority)
 }
+
+func TestListResourcesRequestFilters(t *testing.T) {
+	request := NewListResourcesRequest(
+		WithMIMETypeFilter("text/plain"),
+		WithURIPrefixFilter("file:///docs/"),
+	)
+	assert.Equal(t, string(MethodResourcesList), request.Method)
+	assert.Equal(t, "text/plain", request.MIMETypeFilter())
+	assert.Equal(t, "file:///docs/", request.URIPrefixFilter())
+
+	data, err := json.Marshal(request)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"mimeType":"text/plain","uriPrefix":"file:///docs/"}`)
+
+	var decoded ListResourcesRequest
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "text/plain", decoded.MIMETypeFilter())
+	assert.Equal(t, "file:///docs/", decoded.URIPrefixFilter())
+
+	tests := []struct {
+		name     string
+		resource Resource
+		expected bool
+	}{
+		{
+			name:     "matches both filters",
+			resource: NewResource("file:///docs/a.txt", "a", WithMIMEType("text/plain")),
+			expected: true,
+		},
+		{
+			name:     "ignores MIME type case and parameters",
+			resource: NewResource("file:///docs/b.txt", "b", WithMIMEType("Text/Plain; charset=utf-8")),
+			expected: true,
+		},
+		{
+			name:     "different MIME type",
+			resource: NewResource("file:///docs/c.json", "c", WithMIMEType("application/json")),
+			expected: false,
+		},
+		{
+			name:     "different URI prefix",
+			resource: NewResource("file:///src/d.txt", "d", WithMIMEType("text/plain")),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, request.MatchesFilter(tt.resource))
+		})
+	}
+
+	assert.True(t, NewListResourcesRequest().MatchesFilter(NewResource("file:///any", "any")))
+}
+
+func TestResourceSetMeta(t *testing.T) {
+	resource := NewResource("file:///test.txt", "test.txt")
+	_, ok := resource.GetMeta("namespace")
+	assert.False(t, ok)
+
+	resource.SetMeta("namespace", "docs")
+
+	data, err := json.Marshal(resource)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"namespace":"docs"}`)
+
+	var decoded Resource
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	namespace, ok := decoded.GetMeta("namespace")
+	require.True(t, ok)
+	assert.Equal(t, "docs", namespace)
+}
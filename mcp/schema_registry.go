@@ -0,0 +1,281 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// schemaDefsPrefix is the JSON pointer prefix of references to definitions
+// under $defs.
+const schemaDefsPrefix = "#/$defs/"
+
+// errSchemaPointerNotFound is returned for local references to nothing.
+var errSchemaPointerNotFound = errors.New("no subschema at this location")
+
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// SchemaRegistry holds named JSON Schema definitions shared by the input
+// schemas of several tools, such as the schema of a user ID. Schemas refer to
+// a definition with Ref, and WithSchemaRegistry or Resolve copy every
+// referenced definition into the $defs of the schema.
+//
+//	registry := mcp.NewSchemaRegistry()
+//	registry.Define("UserID", json.RawMessage(`{"type":"string","pattern":"^u[0-9]+$"}`))
+//	tool := mcp.NewTool("get_user",
+//		mcp.WithAny("id", mcp.SchemaRef("UserID"), mcp.Required()),
+//		mcp.WithSchemaRegistry(registry),
+//	)
+type SchemaRegistry struct {
+	mu   sync.RWMutex
+	defs map[string]json.RawMessage
+}
+
+// NewSchemaRegistry creates an empty schema registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{defs: make(map[string]json.RawMessage)}
+}
+
+// Define registers schema under name, replacing any previous definition.
+// Definitions may refer to other definitions of the registry.
+func (r *SchemaRegistry) Define(name string, schema json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defs[name] = schema
+}
+
+// Ref returns a schema referring to the definition named name.
+func (r *SchemaRegistry) Ref(name string) json.RawMessage {
+	ref, _ := json.Marshal(map[string]string{"$ref": schemaRef(name)})
+	return ref
+}
+
+// Resolve returns schema with every definition it refers to, directly or
+// through other definitions, added under its $defs. It fails if a reference
+// names a definition that is not in the registry.
+func (r *SchemaRegistry) Resolve(schema json.RawMessage) (json.RawMessage, error) {
+	var root map[string]any
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	defs, err := r.referencedDefs(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(defs) > 0 {
+		existing, _ := root["$defs"].(map[string]any)
+		if existing == nil {
+			existing = make(map[string]any, len(defs))
+		}
+		for name, def := range defs {
+			existing[name] = def
+		}
+		root["$defs"] = existing
+	}
+	return json.Marshal(root)
+}
+
+// referencedDefs returns the definitions schema refers to, including the ones
+// referred to by those definitions, decoded from JSON.
+func (r *SchemaRegistry) referencedDefs(schema any) (map[string]any, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make(map[string]any)
+	pending := collectSchemaRefs(schema, nil)
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := defs[name]; ok {
+			continue
+		}
+
+		raw, ok := r.defs[name]
+		if !ok {
+			return nil, fmt.Errorf("schema definition %q is not defined", name)
+		}
+		var def any
+		if err := json.Unmarshal(raw, &def); err != nil {
+			return nil, fmt.Errorf("invalid schema definition %q: %w", name, err)
+		}
+		defs[name] = def
+		pending = collectSchemaRefs(def, pending)
+	}
+	return defs, nil
+}
+
+// SchemaRef returns a PropertyOption making a property refer to a definition
+// of a SchemaRegistry. Use it with WithAny, so the property gets no type of
+// its own, and add the registry with WithSchemaRegistry.
+func SchemaRef(name string) PropertyOption {
+	return func(schema map[string]any) {
+		schema["$ref"] = schemaRef(name)
+	}
+}
+
+// WithSchemaRegistry adds the definitions of registry referred to by the
+// input schema of the tool to its $defs. It must come after the options
+// defining the schema. References to undefined definitions are left as they
+// are.
+func WithSchemaRegistry(registry *SchemaRegistry) ToolOption {
+	return func(t *Tool) {
+		if t.RawInputSchema != nil {
+			if resolved, err := registry.Resolve(t.RawInputSchema); err == nil {
+				t.RawInputSchema = resolved
+			}
+			return
+		}
+
+		// Decode the properties so that json.RawMessage values, such as
+		// schemas returned by Ref, are searched too
+		data, err := json.Marshal(t.InputSchema.Properties)
+		if err != nil {
+			return
+		}
+		var properties any
+		if err := json.Unmarshal(data, &properties); err != nil {
+			return
+		}
+		defs, err := registry.referencedDefs(properties)
+		if err != nil {
+			return
+		}
+		if len(defs) == 0 {
+			return
+		}
+		if t.InputSchema.Defs == nil {
+			t.InputSchema.Defs = make(map[string]any, len(defs))
+		}
+		for name, def := range defs {
+			t.InputSchema.Defs[name] = def
+		}
+	}
+}
+
+// collectSchemaRefs appends the names of the definitions referred to by
+// schema to names.
+func collectSchemaRefs(schema any, names []string) []string {
+	switch v := schema.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, schemaDefsPrefix) {
+			name := jsonPointerUnescaper.Replace(strings.TrimPrefix(ref, schemaDefsPrefix))
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		for _, value := range v {
+			names = collectSchemaRefs(value, names)
+		}
+	case []any:
+		for _, value := range v {
+			names = collectSchemaRefs(value, names)
+		}
+	}
+	return names
+}
+
+func schemaRef(name string) string {
+	return schemaDefsPrefix + jsonPointerEscaper.Replace(name)
+}
+
+// ValidateSchemaRefs checks that every local $ref of schema, such as
+// "#/$defs/Pagination", points to a subschema of schema. Following references
+// stops at the first one seen twice, so recursive schemas are accepted, but
+// references that only lead to each other, and thus never to an actual
+// schema, are reported as cycles. References to other documents are not
+// checked.
+func ValidateSchemaRefs(schema json.RawMessage) error {
+	var root any
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	refs := collectLocalRefs(root, nil)
+	slices.Sort(refs) // Report problems in a stable order
+
+	var errs []error
+	for _, ref := range refs {
+		seen := map[string]bool{}
+		for next := ref; next != ""; {
+			if seen[next] {
+				errs = append(errs, fmt.Errorf("$ref %q is part of a reference cycle", ref))
+				break
+			}
+			seen[next] = true
+
+			target, err := resolveSchemaPointer(root, next)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("$ref %q: %w", ref, err))
+				break
+			}
+			next = ""
+			if targetSchema, ok := target.(map[string]any); ok {
+				if targetRef, ok := targetSchema["$ref"].(string); ok && strings.HasPrefix(targetRef, "#") {
+					next = targetRef
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// collectLocalRefs appends the $ref values of schema that point within the
+// same document to refs.
+func collectLocalRefs(schema any, refs []string) []string {
+	switch v := schema.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, "#") && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+		for _, value := range v {
+			refs = collectLocalRefs(value, refs)
+		}
+	case []any:
+		for _, value := range v {
+			refs = collectLocalRefs(value, refs)
+		}
+	}
+	return refs
+}
+
+// resolveSchemaPointer returns the value of root that the local reference ref,
+// a JSON pointer in a URI fragment, points to.
+func resolveSchemaPointer(root any, ref string) (any, error) {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return root, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("only JSON pointer fragments are supported")
+	}
+
+	current := root
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = jsonPointerUnescaper.Replace(token)
+		switch v := current.(type) {
+		case map[string]any:
+			value, ok := v[token]
+			if !ok {
+				return nil, errSchemaPointerNotFound
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, errSchemaPointerNotFound
+			}
+			current = v[index]
+		default:
+			return nil, errSchemaPointerNotFound
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSchemaRegistry() *SchemaRegistry {
+	registry := NewSchemaRegistry()
+	registry.Define("UserID", json.RawMessage(`{"type":"string","pattern":"^u[0-9]+$"}`))
+	registry.Define("User", json.RawMessage(`{"type":"object","properties":{"id":{"$ref":"#/$defs/UserID"},"name":{"type":"string"}}}`))
+	registry.Define("Unused", json.RawMessage(`{"type":"boolean"}`))
+	return registry
+}
+
+// assertRefsResolved checks that schema is a valid JSON Schema whose $refs
+// all point to entries of its $defs.
+func assertRefsResolved(t *testing.T, schema []byte) map[string]any {
+	t.Helper()
+	var root map[string]any
+	require.NoError(t, json.Unmarshal(schema, &root))
+	require.NoError(t, validateInputSchema(root))
+
+	defs, _ := root["$defs"].(map[string]any)
+	for _, name := range collectSchemaRefs(root, nil) {
+		assert.Contains(t, defs, name, "reference to %q is not resolved", name)
+	}
+	return root
+}
+
+func TestSchemaRegistryRef(t *testing.T) {
+	registry := NewSchemaRegistry()
+	assert.JSONEq(t, `{"$ref":"#/$defs/UserID"}`, string(registry.Ref("UserID")))
+	assert.JSONEq(t, `{"$ref":"#/$defs/a~1b~0c"}`, string(registry.Ref("a/b~c")))
+	assert.Equal(t, []string{"a/b~c"}, collectSchemaRefs(map[string]any{"$ref": "#/$defs/a~1b~0c"}, nil))
+}
+
+func TestSchemaRegistryResolve(t *testing.T) {
+	registry := newTestSchemaRegistry()
+	schema, err := json.Marshal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"owner":  registry.Ref("User"),
+			"viewer": registry.Ref("UserID"),
+		},
+	})
+	require.NoError(t, err)
+
+	resolved, err := registry.Resolve(schema)
+	require.NoError(t, err)
+	root := assertRefsResolved(t, resolved)
+
+	defs := root["$defs"].(map[string]any)
+	assert.Len(t, defs, 2)
+	assert.Equal(t, "^u[0-9]+$", defs["UserID"].(map[string]any)["pattern"])
+	assert.NotContains(t, defs, "Unused")
+
+	_, err = registry.Resolve(json.RawMessage(`{"type":"object","properties":{"x":{"$ref":"#/$defs/Missing"}}}`))
+	assert.ErrorContains(t, err, `"Missing" is not defined`)
+}
+
+func TestWithSchemaRegistry(t *testing.T) {
+	registry := newTestSchemaRegistry()
+	tool := NewTool("assign",
+		WithAny("assignee", SchemaRef("UserID"), Required()),
+		WithArray("watchers", Items(registry.Ref("User"))),
+		WithString("note"),
+		WithSchemaRegistry(registry),
+	)
+
+	data, err := json.Marshal(tool)
+	require.NoError(t, err)
+	var marshaled struct {
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+	require.NoError(t, json.Unmarshal(data, &marshaled))
+	root := assertRefsResolved(t, marshaled.InputSchema)
+
+	defs := root["$defs"].(map[string]any)
+	assert.Len(t, defs, 2)
+	assert.Contains(t, defs, "User")
+	assert.Contains(t, defs, "UserID")
+	assert.Equal(t, []any{"assignee"}, root["required"])
+
+	// Raw input schemas are resolved too
+	raw := NewToolWithRawSchema("raw", "", json.RawMessage(`{"type":"object","properties":{"id":{"$ref":"#/$defs/UserID"}}}`))
+	WithSchemaRegistry(registry)(&raw)
+	assert.Contains(t, string(raw.RawInputSchema), `"$defs"`)
+	assertRefsResolved(t, raw.RawInputSchema)
+}
+
+func TestValidateSchemaRefs(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		errors []string
+	}{
+		{
+			name:   "no references",
+			schema: `{"type":"object","properties":{"name":{"type":"string"}}}`,
+		},
+		{
+			name: "references to definitions",
+			schema: `{"type":"object","$defs":{"Page":{"type":"integer"},"Pagination":{"type":"object","properties":{"page":{"$ref":"#/$defs/Page"}}}},
+				"properties":{"first":{"$ref":"#/$defs/Pagination"},"next":{"$ref":"#/$defs/Pagination"}}}`,
+		},
+		{
+			name:   "recursive definition",
+			schema: `{"type":"object","$defs":{"Node":{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/Node"}}}}},"properties":{"tree":{"$ref":"#/$defs/Node"}}}`,
+		},
+		{
+			name:   "reference to another document",
+			schema: `{"type":"object","properties":{"user":{"$ref":"https://example.com/user.json"}}}`,
+		},
+		{
+			name:   "undefined definition",
+			schema: `{"type":"object","properties":{"page":{"$ref":"#/$defs/Pagination"}}}`,
+			errors: []string{`$ref "#/$defs/Pagination": no subschema at this location`},
+		},
+		{
+			name:   "reference cycle",
+			schema: `{"type":"object","$defs":{"A":{"$ref":"#/$defs/B"},"B":{"$ref":"#/$defs/A"}},"properties":{"a":{"$ref":"#/$defs/A"}}}`,
+			errors: []string{
+				`$ref "#/$defs/A" is part of a reference cycle`,
+				`$ref "#/$defs/B" is part of a reference cycle`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchemaRefs(json.RawMessage(tt.schema))
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, msg := range tt.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestWithInputSchemaRefs(t *testing.T) {
+	type Pagination struct {
+		Page int `json:"page"`
+	}
+	type Node struct {
+		Name     string `json:"name"`
+		Children []Node `json:"children,omitempty"`
+	}
+	type Args struct {
+		Query string     `json:"query"`
+		First Pagination `json:"first"`
+		Next  Pagination `json:"next"`
+		Tree  Node       `json:"tree"`
+	}
+
+	tool := NewTool("search", WithInputSchemaRefs[Args]())
+	assert.Nil(t, tool.RawInputSchema)
+	assert.Equal(t, "object", tool.InputSchema.Type)
+	assert.Contains(t, tool.InputSchema.Defs, "Pagination")
+	assert.Contains(t, tool.InputSchema.Defs, "Node")
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/Pagination"}, tool.InputSchema.Properties["first"])
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/Pagination"}, tool.InputSchema.Properties["next"])
+
+	data, err := json.Marshal(tool)
+	require.NoError(t, err)
+	var encoded struct {
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+	require.NoError(t, json.Unmarshal(data, &encoded))
+	assertRefsResolved(t, encoded.InputSchema)
+	assert.NoError(t, ValidateSchemaRefs(encoded.InputSchema))
+}
@@ -0,0 +1,329 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/invopop/jsonschema"
+)
+
+// applySchemaTags renames the properties of schema, generated from t, after
+// the schema tags of their fields, as used by gorilla/schema. The name of a
+// property is taken from the schema tag of its field, then from its json tag,
+// then from the field name. A schema tag of "-" omits the field. Only the name
+// of a schema tag is used: whether a field is required is still decided by
+// its json and jsonschema tags. CallToolRequest.BindArguments follows the
+// same names, with argumentsFromSchemaTags.
+func applySchemaTags(schema *jsonschema.Schema, t reflect.Type) {
+	if schema == nil || t == nil {
+		return
+	}
+	tagger := schemaTagger{
+		definitions: schema.Definitions,
+		seen:        make(map[*jsonschema.Schema]bool),
+	}
+	tagger.apply(schema, t)
+}
+
+// schemaTagger walks a schema along the Go type it was generated from.
+type schemaTagger struct {
+	definitions jsonschema.Definitions
+	// Schemas already walked, guarding against recursive types
+	seen map[*jsonschema.Schema]bool
+}
+
+func (s *schemaTagger) apply(schema *jsonschema.Schema, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema.Ref != "" {
+		definition, ok := s.definitions[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+		if !ok {
+			return
+		}
+		schema = definition
+	}
+	if schema == nil || s.seen[schema] {
+		return
+	}
+	s.seen[schema] = true
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s.applyFields(schema, t)
+	case reflect.Slice, reflect.Array:
+		if schema.Items != nil {
+			s.apply(schema.Items, t.Elem())
+		}
+	case reflect.Map:
+		if schema.AdditionalProperties != nil {
+			s.apply(schema.AdditionalProperties, t.Elem())
+		}
+	case reflect.Interface:
+		for _, variantSchema := range schema.OneOf {
+			if variant, ok := variantOf(t, variantSchema); ok {
+				s.apply(variantSchema, variant)
+			}
+		}
+	}
+}
+
+// variantOf returns the registered variant of the interface type iface that
+// variantSchema, generated by schemaVariantMapper, describes.
+func variantOf(iface reflect.Type, variantSchema *jsonschema.Schema) (reflect.Type, bool) {
+	if variantSchema == nil || variantSchema.Properties == nil {
+		return nil, false
+	}
+	discriminator, ok := variantSchema.Properties.Get(schemaVariantDiscriminator)
+	if !ok || discriminator == nil {
+		return nil, false
+	}
+	name, _ := discriminator.Const.(string)
+	return variantNamed(iface, name)
+}
+
+// variantNamed returns the registered variant of the interface type iface
+// with the discriminator name.
+func variantNamed(iface reflect.Type, name string) (reflect.Type, bool) {
+	schemaVariantsMu.RLock()
+	defer schemaVariantsMu.RUnlock()
+	for _, variant := range schemaVariants[iface] {
+		if variant.discriminator == name {
+			return variant.typ, true
+		}
+	}
+	return nil, false
+}
+
+// applyFields renames the properties of the fields of the struct type t,
+// including those of its embedded structs, which are inlined in schema.
+func (s *schemaTagger) applyFields(schema *jsonschema.Schema, t reflect.Type) {
+	if schema.Properties == nil {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, jsonOptions, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" && jsonOptions == "" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		inlined := field.Anonymous && jsonName == "" || slices.Contains(strings.Split(jsonOptions, ","), "inline")
+		if inlined && fieldType.Kind() == reflect.Struct {
+			s.applyFields(schema, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		property, ok := schema.Properties.Get(jsonName)
+		if !ok {
+			continue
+		}
+		s.apply(property, field.Type)
+
+		schemaName, _, _ := strings.Cut(field.Tag.Get("schema"), ",")
+		switch schemaName {
+		case "", jsonName:
+		case "-":
+			schema.Properties.Delete(jsonName)
+			schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool { return name == jsonName })
+		default:
+			renameProperty(schema, jsonName, schemaName)
+		}
+	}
+}
+
+// renameProperty renames a property of schema, keeping the order of its
+// properties.
+func renameProperty(schema *jsonschema.Schema, from, to string) {
+	properties := jsonschema.NewProperties()
+	for pair := schema.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		key := pair.Key
+		if key == from {
+			key = to
+		}
+		properties.Set(key, pair.Value)
+	}
+	schema.Properties = properties
+
+	for i, name := range schema.Required {
+		if name == from {
+			schema.Required[i] = to
+		}
+	}
+}
+
+// schemaTagTypes caches whether types use schema tags, by reflect.Type.
+var schemaTagTypes sync.Map
+
+// usesSchemaTags reports whether t or a type it contains has a field with a
+// schema tag renaming or omitting it, so that arguments following its schema
+// must go through argumentsFromSchemaTags to be decoded into it.
+func usesSchemaTags(t reflect.Type) bool {
+	if uses, ok := schemaTagTypes.Load(t); ok {
+		return uses.(bool)
+	}
+	uses := typeUsesSchemaTags(t, make(map[reflect.Type]bool))
+	schemaTagTypes.Store(t, uses)
+	return uses
+}
+
+func typeUsesSchemaTags(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if schemaName, _, _ := strings.Cut(field.Tag.Get("schema"), ","); schemaName != "" && schemaName != jsonName {
+				return true
+			}
+			if typeUsesSchemaTags(field.Type, seen) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return typeUsesSchemaTags(t.Elem(), seen)
+	case reflect.Interface:
+		schemaVariantsMu.RLock()
+		variants := slices.Clone(schemaVariants[t])
+		schemaVariantsMu.RUnlock()
+		for _, variant := range variants {
+			if typeUsesSchemaTags(variant.typ, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// argumentsFromSchemaTags returns value, decoded from JSON arguments named
+// as in the schema generated from t, with the properties renamed after the
+// json names of their fields, so that value can be decoded into t with
+// encoding/json. Properties of fields with a schema tag of "-" are dropped.
+func argumentsFromSchemaTags(value any, t reflect.Type) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		renamed := make(map[string]any, len(object))
+		for name, property := range object {
+			renamed[name] = property
+		}
+		renameFieldArguments(object, renamed, t)
+		return renamed
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		renamed := make([]any, len(items))
+		for i, item := range items {
+			renamed[i] = argumentsFromSchemaTags(item, t.Elem())
+		}
+		return renamed
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		renamed := make(map[string]any, len(object))
+		for key, item := range object {
+			renamed[key] = argumentsFromSchemaTags(item, t.Elem())
+		}
+		return renamed
+	case reflect.Interface:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+		name, _ := object[schemaVariantDiscriminator].(string)
+		if variant, ok := variantNamed(t, name); ok {
+			return argumentsFromSchemaTags(value, variant)
+		}
+	}
+	return value
+}
+
+// renameFieldArguments sets in renamed the properties of object for the
+// fields of the struct type t, including those of its inlined embedded
+// structs, under the json names of the fields.
+func renameFieldArguments(object, renamed map[string]any, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName, jsonOptions, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" && jsonOptions == "" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		inlined := field.Anonymous && jsonName == "" || slices.Contains(strings.Split(jsonOptions, ","), "inline")
+		if inlined && fieldType.Kind() == reflect.Struct {
+			renameFieldArguments(object, renamed, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		name := jsonName
+		schemaName, _, _ := strings.Cut(field.Tag.Get("schema"), ",")
+		switch schemaName {
+		case "", jsonName:
+		case "-":
+			delete(renamed, jsonName)
+			continue
+		default:
+			name = schemaName
+			delete(renamed, schemaName)
+			delete(renamed, jsonName)
+		}
+		if property, ok := object[name]; ok {
+			renamed[jsonName] = argumentsFromSchemaTags(property, field.Type)
+		}
+	}
+}
+
+// decodeArguments decodes JSON arguments into a value for
+// argumentsFromSchemaTags, keeping numbers exact.
+func decodeArguments(data []byte) (any, error) {
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
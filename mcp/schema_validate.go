@@ -0,0 +1,395 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaValidationError reports a value that does not match a JSON Schema.
+type SchemaValidationError struct {
+	// Path is the JSON pointer of the invalid part of the value, "" for the
+	// value itself.
+	Path string
+	// Keyword is the schema keyword the value does not satisfy, such as
+	// "required" or "oneOf".
+	Keyword string
+	// Message describes the problem. For oneOf, anyOf and allOf, it tells why
+	// the value does not match the subschemas.
+	Message string
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", schemaPath(e.Path), e.Message)
+}
+
+// ValidationError converts the error to a ValidationError, the field being
+// the path of the error and the code its keyword.
+func (e *SchemaValidationError) ValidationError() ValidationError {
+	return ValidationError{Field: e.Path, Code: e.Keyword, Message: e.Message}
+}
+
+// SchemaValidationErrors reports a value that does not match a JSON Schema in
+// several places, such as several missing or invalid properties.
+type SchemaValidationErrors []*SchemaValidationError
+
+func (e SchemaValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors, so that errors.As finds the first of them.
+func (e SchemaValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ValidationErrors converts the errors to ValidationErrors, as
+// SchemaValidationError.ValidationError does.
+func (e SchemaValidationErrors) ValidationErrors() []ValidationError {
+	errs := make([]ValidationError, len(e))
+	for i, err := range e {
+		errs[i] = err.ValidationError()
+	}
+	return errs
+}
+
+// ValidateAgainstSchema checks that value, once encoded to JSON, matches
+// schema. It supports the keywords describing types, objects, arrays, strings,
+// numbers, enum and const, the composition keywords allOf, anyOf, oneOf and
+// not, and local $ref; other keywords are ignored. The error returned for a
+// mismatch is a *SchemaValidationError, or a SchemaValidationErrors if the
+// value fails in several places, such as several invalid properties.
+func ValidateAgainstSchema(schema json.RawMessage, value any) error {
+	var root any
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	v := schemaValidator{root: root}
+	switch errs := v.validate(root, decoded, "", 0); len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return SchemaValidationErrors(errs)
+	}
+}
+
+// ValidateArguments checks that arguments match the input schema of the tool,
+// as ValidateAgainstSchema does.
+func (t Tool) ValidateArguments(arguments any) error {
+	schema := t.RawInputSchema
+	if schema == nil {
+		var err error
+		if schema, err = json.Marshal(t.InputSchema); err != nil {
+			return fmt.Errorf("invalid input schema: %w", err)
+		}
+	}
+	return ValidateAgainstSchema(schema, arguments)
+}
+
+// ValidateStructuredContent checks that the structured content of a result
+// matches the output schema of the tool, as ValidateAgainstSchema does. It
+// returns nil if the tool has no output schema.
+func (t Tool) ValidateStructuredContent(structuredContent any) error {
+	schema := t.RawOutputSchema
+	if schema == nil {
+		if t.OutputSchema.Type == "" {
+			return nil
+		}
+		var err error
+		if schema, err = json.Marshal(t.OutputSchema); err != nil {
+			return fmt.Errorf("invalid output schema: %w", err)
+		}
+	}
+	return ValidateAgainstSchema(schema, structuredContent)
+}
+
+// maxSchemaRefDepth bounds how many $ref a validation follows, so that
+// references that only lead to each other do not loop forever.
+const maxSchemaRefDepth = 64
+
+type schemaValidator struct {
+	root any
+}
+
+func (v schemaValidator) validate(schema, value any, path string, refDepth int) []*SchemaValidationError {
+	fail := func(keyword, format string, args ...any) []*SchemaValidationError {
+		return []*SchemaValidationError{{Path: path, Keyword: keyword, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	if allowed, ok := schema.(bool); ok {
+		if !allowed {
+			return fail("false", "no value is allowed")
+		}
+		return nil
+	}
+	object, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if ref, ok := object["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+		if refDepth >= maxSchemaRefDepth {
+			return fail("$ref", "too many nested references following %q", ref)
+		}
+		target, err := resolveSchemaPointer(v.root, ref)
+		if err != nil {
+			return fail("$ref", "$ref %q: %v", ref, err)
+		}
+		if errs := v.validate(target, value, path, refDepth+1); errs != nil {
+			return errs
+		}
+	}
+
+	if types, ok := schemaTypes(object["type"]); ok && !slices.ContainsFunc(types, func(t string) bool { return jsonTypeMatches(t, value) }) {
+		return fail("type", "expected %s, got %s", strings.Join(types, " or "), jsonTypeOf(value))
+	}
+	if enum, ok := object["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		return fail("enum", "must be one of %s", formatJSONValues(enum))
+	}
+	if constant, ok := object["const"]; ok && !reflect.DeepEqual(constant, value) {
+		return fail("const", "must be %s", formatJSONValues([]any{constant}))
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		if errs := v.validateObject(object, val, path, refDepth); errs != nil {
+			return errs
+		}
+	case []any:
+		if errs := v.validateArray(object, val, path, refDepth); errs != nil {
+			return errs
+		}
+	case string:
+		length := utf8.RuneCountInString(val)
+		if n, ok := object["minLength"].(float64); ok && float64(length) < n {
+			return fail("minLength", "must be at least %v characters long", n)
+		}
+		if n, ok := object["maxLength"].(float64); ok && float64(length) > n {
+			return fail("maxLength", "must be at most %v characters long", n)
+		}
+		if pattern, ok := object["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(val) {
+				return fail("pattern", "must match pattern %q", pattern)
+			}
+		}
+	case float64:
+		if n, ok := object["minimum"].(float64); ok && val < n {
+			return fail("minimum", "must be at least %v", n)
+		}
+		if n, ok := object["maximum"].(float64); ok && val > n {
+			return fail("maximum", "must be at most %v", n)
+		}
+		if n, ok := object["exclusiveMinimum"].(float64); ok && val <= n {
+			return fail("exclusiveMinimum", "must be greater than %v", n)
+		}
+		if n, ok := object["exclusiveMaximum"].(float64); ok && val >= n {
+			return fail("exclusiveMaximum", "must be less than %v", n)
+		}
+	}
+
+	if err := v.validateComposition(object, value, path, refDepth); err != nil {
+		return []*SchemaValidationError{err}
+	}
+	return nil
+}
+
+// validateObject checks the required fields and the properties of object,
+// reporting every field that fails rather than only the first.
+func (v schemaValidator) validateObject(schema, object map[string]any, path string, refDepth int) []*SchemaValidationError {
+	var errs []*SchemaValidationError
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := object[name]; !present {
+					errs = append(errs, &SchemaValidationError{
+						Path:    path + "/" + jsonPointerEscaper.Replace(name),
+						Keyword: "required",
+						Message: "is required",
+					})
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional, hasAdditional := schema["additionalProperties"]
+	// Check properties in a stable order, so errors are reproducible
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		propertyPath := path + "/" + jsonPointerEscaper.Replace(name)
+		if propertySchema, ok := properties[name]; ok {
+			errs = append(errs, v.validate(propertySchema, object[name], propertyPath, refDepth)...)
+			continue
+		}
+		if hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				errs = append(errs, &SchemaValidationError{Path: propertyPath, Keyword: "additionalProperties", Message: "is not allowed"})
+				continue
+			}
+			errs = append(errs, v.validate(additional, object[name], propertyPath, refDepth)...)
+		}
+	}
+	return errs
+}
+
+// validateArray checks the length and the items of array, reporting every
+// item that fails rather than only the first.
+func (v schemaValidator) validateArray(schema map[string]any, array []any, path string, refDepth int) []*SchemaValidationError {
+	var errs []*SchemaValidationError
+	if n, ok := schema["minItems"].(float64); ok && float64(len(array)) < n {
+		errs = append(errs, &SchemaValidationError{Path: path, Keyword: "minItems", Message: fmt.Sprintf("must have at least %v items", n)})
+	}
+	if n, ok := schema["maxItems"].(float64); ok && float64(len(array)) > n {
+		errs = append(errs, &SchemaValidationError{Path: path, Keyword: "maxItems", Message: fmt.Sprintf("must have at most %v items", n)})
+	}
+	if items, ok := schema["items"]; ok {
+		for i, item := range array {
+			errs = append(errs, v.validate(items, item, path+"/"+strconv.Itoa(i), refDepth)...)
+		}
+	}
+	return errs
+}
+
+func (v schemaValidator) validateComposition(schema map[string]any, value any, path string, refDepth int) *SchemaValidationError {
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for i, subschema := range allOf {
+			if errs := v.validate(subschema, value, path, refDepth); errs != nil {
+				return &SchemaValidationError{
+					Path:    path,
+					Keyword: "allOf",
+					Message: fmt.Sprintf("does not match allOf/%d: %v", i, SchemaValidationErrors(errs)),
+				}
+			}
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		matched, failures := v.matchSubschemas("anyOf", anyOf, value, path, refDepth)
+		if len(matched) == 0 {
+			return &SchemaValidationError{
+				Path:    path,
+				Keyword: "anyOf",
+				Message: fmt.Sprintf("does not match any schema of anyOf (%s)", strings.Join(failures, "; ")),
+			}
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matched, failures := v.matchSubschemas("oneOf", oneOf, value, path, refDepth)
+		switch {
+		case len(matched) == 0:
+			return &SchemaValidationError{
+				Path:    path,
+				Keyword: "oneOf",
+				Message: fmt.Sprintf("does not match any schema of oneOf (%s)", strings.Join(failures, "; ")),
+			}
+		case len(matched) > 1:
+			return &SchemaValidationError{
+				Path:    path,
+				Keyword: "oneOf",
+				Message: fmt.Sprintf("matches more than one schema of oneOf (%s)", strings.Join(matched, ", ")),
+			}
+		}
+	}
+
+	if not, ok := schema["not"]; ok {
+		if errs := v.validate(not, value, path, refDepth); errs == nil {
+			return &SchemaValidationError{Path: path, Keyword: "not", Message: "must not match the schema of not"}
+		}
+	}
+	return nil
+}
+
+// matchSubschemas validates value against each subschema of the composition
+// keyword, returning the subschemas it matches, as "keyword/index", and why it
+// does not match the others.
+func (v schemaValidator) matchSubschemas(keyword string, subschemas []any, value any, path string, refDepth int) (matched, failures []string) {
+	for i, subschema := range subschemas {
+		name := fmt.Sprintf("%s/%d", keyword, i)
+		if errs := v.validate(subschema, value, path, refDepth); errs != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, SchemaValidationErrors(errs)))
+		} else {
+			matched = append(matched, name)
+		}
+	}
+	return matched, failures
+}
+
+// schemaTypes returns the types allowed by the value of a type keyword.
+func schemaTypes(value any) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []any:
+		types := make([]string, 0, len(v))
+		for _, t := range v {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+		return types, len(types) > 0
+	}
+	return nil, false
+}
+
+func jsonTypeMatches(schemaType string, value any) bool {
+	if schemaType == "integer" {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	if schemaType == "number" {
+		_, ok := value.(float64)
+		return ok
+	}
+	return jsonTypeOf(value) == schemaType
+}
+
+// jsonTypeOf returns the JSON Schema type of a value decoded from JSON.
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func formatJSONValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		data, _ := json.Marshal(value)
+		formatted[i] = string(data)
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAgainstSchema(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"count": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2},
+			"mode": {"enum": ["fast", "slow"]}
+		},
+		"required": ["name"],
+		"additionalProperties": false
+	}`)
+
+	tests := []struct {
+		name    string
+		value   any
+		path    string
+		keyword string
+	}{
+		{name: "valid", value: map[string]any{"name": "x", "count": 2, "tags": []string{"a"}, "mode": "fast"}},
+		{name: "missing required", value: map[string]any{}, path: "/name", keyword: "required"},
+		{name: "wrong type", value: map[string]any{"name": 1}, path: "/name", keyword: "type"},
+		{name: "not an integer", value: map[string]any{"name": "x", "count": 1.5}, path: "/count", keyword: "type"},
+		{name: "below minimum", value: map[string]any{"name": "x", "count": -1}, path: "/count", keyword: "minimum"},
+		{name: "too short", value: map[string]any{"name": ""}, path: "/name", keyword: "minLength"},
+		{name: "invalid item", value: map[string]any{"name": "x", "tags": []any{1}}, path: "/tags/0", keyword: "type"},
+		{name: "too many items", value: map[string]any{"name": "x", "tags": []string{"a", "b", "c"}}, path: "/tags", keyword: "maxItems"},
+		{name: "not in enum", value: map[string]any{"name": "x", "mode": "other"}, path: "/mode", keyword: "enum"},
+		{name: "additional property", value: map[string]any{"name": "x", "extra": true}, path: "/extra", keyword: "additionalProperties"},
+		{name: "not an object", value: "x", path: "", keyword: "type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgainstSchema(schema, tt.value)
+			if tt.keyword == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var verr *SchemaValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tt.path, verr.Path)
+			assert.Equal(t, tt.keyword, verr.Keyword)
+		})
+	}
+}
+
+func TestValidateAgainstSchema_Composition(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"shape": {
+				"oneOf": [
+					{"$ref": "#/$defs/Circle"},
+					{"type": "object", "properties": {"type": {"const": "square"}, "side": {"type": "number"}}, "required": ["type", "side"]}
+				]
+			},
+			"id": {"anyOf": [{"type": "string"}, {"type": "integer"}]},
+			"size": {"allOf": [{"type": "number"}, {"maximum": 10}]}
+		},
+		"$defs": {
+			"Circle": {"type": "object", "properties": {"type": {"const": "circle"}, "radius": {"type": "number"}}, "required": ["type", "radius"]}
+		}
+	}`)
+
+	assert.NoError(t, ValidateAgainstSchema(schema, map[string]any{
+		"shape": map[string]any{"type": "circle", "radius": 1},
+		"id":    "a",
+		"size":  3,
+	}))
+	assert.NoError(t, ValidateAgainstSchema(schema, map[string]any{
+		"shape": map[string]any{"type": "square", "side": 2},
+		"id":    3,
+	}))
+
+	err := ValidateAgainstSchema(schema, map[string]any{"shape": map[string]any{"type": "circle"}})
+	var verr *SchemaValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "/shape", verr.Path)
+	assert.Equal(t, "oneOf", verr.Keyword)
+	assert.EqualError(t, err, `/shape: does not match any schema of oneOf (`+
+		`oneOf/0: /shape/radius: is required; `+
+		`oneOf/1: /shape/side: is required; /shape/type: must be "square")`)
+
+	err = ValidateAgainstSchema(schema, map[string]any{"id": true})
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "anyOf", verr.Keyword)
+	assert.Contains(t, err.Error(), "anyOf/0: /id: expected string, got boolean")
+	assert.Contains(t, err.Error(), "anyOf/1: /id: expected integer, got boolean")
+
+	err = ValidateAgainstSchema(schema, map[string]any{"size": 11})
+	require.ErrorAs(t, err, &verr)
+	assert.EqualError(t, err, "/size: does not match allOf/1: /size: must be at most 10")
+}
+
+func TestValidateAgainstSchema_OneOfMatchesSeveral(t *testing.T) {
+	schema := json.RawMessage(`{"oneOf": [{"type": "number"}, {"type": "integer"}]}`)
+	assert.NoError(t, ValidateAgainstSchema(schema, 1.5))
+	assert.EqualError(t, ValidateAgainstSchema(schema, 1), "/: matches more than one schema of oneOf (oneOf/0, oneOf/1)")
+}
+
+func TestValidateAgainstSchema_SeveralErrors(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["name", "email"]
+	}`)
+
+	err := ValidateAgainstSchema(schema, map[string]any{"age": -1, "tags": []any{"a", 1, true}})
+	var errs SchemaValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []ValidationError{
+		{Field: "/name", Code: "required", Message: "is required"},
+		{Field: "/email", Code: "required", Message: "is required"},
+		{Field: "/age", Code: "minimum", Message: "must be at least 0"},
+		{Field: "/tags/1", Code: "type", Message: "expected string, got number"},
+		{Field: "/tags/2", Code: "type", Message: "expected string, got boolean"},
+	}, errs.ValidationErrors())
+
+	// errors.As still finds the first error
+	var verr *SchemaValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "/name", verr.Path)
+}
+
+func TestTool_ValidateArguments(t *testing.T) {
+	tool := NewTool("pay",
+		WithString("currency", Required()),
+		WithAny("amount", OneOf(
+			map[string]any{"type": "number"},
+			map[string]any{"type": "string", "pattern": "^[0-9]+$"},
+		)),
+	)
+	assert.NoError(t, tool.ValidateArguments(map[string]any{"currency": "EUR", "amount": "12"}))
+	assert.NoError(t, tool.ValidateArguments(map[string]any{"currency": "EUR", "amount": 12}))
+	assert.ErrorContains(t, tool.ValidateArguments(map[string]any{"currency": "EUR", "amount": "twelve"}),
+		`oneOf/1: /amount: must match pattern "^[0-9]+$"`)
+	assert.ErrorContains(t, tool.ValidateArguments(map[string]any{}), "/currency: is required")
+}
+
+func TestTool_ValidateStructuredContent(t *testing.T) {
+	type output struct {
+		Count int `json:"count" jsonschema:"required"`
+	}
+	tool := NewTool("count", WithOutputSchema[output]())
+	result := NewStructuredToolResult(map[string]any{"count": 3})
+	assert.NoError(t, tool.ValidateStructuredContent(result.StructuredContent))
+	assert.ErrorContains(t, tool.ValidateStructuredContent(map[string]any{}), "/count: is required")
+
+	// Without an output schema, any content is accepted
+	assert.NoError(t, NewTool("free").ValidateStructuredContent(map[string]any{"x": 1}))
+}
@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/invopop/jsonschema"
+)
+
+// schemaVariantDiscriminator is the property telling apart the variants of
+// an interface type in generated schemas.
+const schemaVariantDiscriminator = "type"
+
+type schemaVariant struct {
+	discriminator string
+	typ           reflect.Type
+}
+
+var (
+	schemaVariantsMu sync.RWMutex
+	schemaVariants   = make(map[reflect.Type][]schemaVariant)
+)
+
+// RegisterSchemaVariant registers variant, whose dynamic type implements the
+// interface type I, as one of the types values of type I can have. Schemas
+// generated from Go types by WithInputSchema, WithInputSchemaRefs and
+// WithOutputSchema describe a value of type I as oneOf the schemas of its
+// variants, told apart by a "type" property that must equal discriminator.
+//
+//	type Shape interface{ Area() float64 }
+//
+//	mcp.RegisterSchemaVariant[Shape]("circle", Circle{})
+//	mcp.RegisterSchemaVariant[Shape]("square", Square{})
+//
+// Encoding the discriminator in values and decoding values into the right
+// variant is up to the Go types. It panics if I is not an interface type or
+// variant is nil. Registering a discriminator again replaces its variant.
+func RegisterSchemaVariant[I any](discriminator string, variant I) {
+	iface := reflect.TypeFor[I]()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("mcp: RegisterSchemaVariant of non-interface type %s", iface))
+	}
+	typ := reflect.TypeOf(any(variant))
+	if typ == nil {
+		panic("mcp: RegisterSchemaVariant of nil variant")
+	}
+
+	schemaVariantsMu.Lock()
+	defer schemaVariantsMu.Unlock()
+	variants := slices.DeleteFunc(schemaVariants[iface], func(v schemaVariant) bool {
+		return v.discriminator == discriminator
+	})
+	schemaVariants[iface] = append(variants, schemaVariant{discriminator: discriminator, typ: typ})
+}
+
+// schemaVariantMapper is a jsonschema.Reflector Mapper generating the schema
+// of interface types with registered variants.
+func schemaVariantMapper(t reflect.Type) *jsonschema.Schema {
+	schemaVariantsMu.RLock()
+	variants := slices.Clone(schemaVariants[t])
+	schemaVariantsMu.RUnlock()
+	if len(variants) == 0 {
+		return nil
+	}
+
+	schema := &jsonschema.Schema{}
+	for _, variant := range variants {
+		reflector := jsonschema.Reflector{
+			DoNotReference:            true,
+			Anonymous:                 true,
+			AllowAdditionalProperties: true,
+			Mapper:                    schemaVariantMapper,
+		}
+		variantSchema := reflector.ReflectFromType(variant.typ)
+		variantSchema.Version = ""
+		if variantSchema.Properties == nil {
+			variantSchema.Properties = jsonschema.NewProperties()
+		}
+		variantSchema.Properties.Set(schemaVariantDiscriminator, &jsonschema.Schema{Const: variant.discriminator})
+		if !slices.Contains(variantSchema.Required, schemaVariantDiscriminator) {
+			variantSchema.Required = append([]string{schemaVariantDiscriminator}, variantSchema.Required...)
+		}
+		schema.OneOf = append(schema.OneOf, variantSchema)
+	}
+	return schema
+}
@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type variantTestShape interface {
+	area() float64
+}
+
+type variantTestCircle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (c variantTestCircle) area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type variantTestSquare struct {
+	Side float64 `json:"side"`
+}
+
+func (s variantTestSquare) area() float64 { return s.Side * s.Side }
+
+func TestRegisterSchemaVariant(t *testing.T) {
+	RegisterSchemaVariant[variantTestShape]("circle", variantTestCircle{})
+	RegisterSchemaVariant[variantTestShape]("square", &variantTestSquare{})
+
+	type input struct {
+		Shape variantTestShape `json:"shape" jsonschema:"required"`
+	}
+	tool := NewTool("area", WithInputSchema[input]())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(tool.RawInputSchema, &schema))
+	shape := schema["properties"].(map[string]any)["shape"].(map[string]any)
+	oneOf, ok := shape["oneOf"].([]any)
+	require.True(t, ok, "shape schema has no oneOf: %v", shape)
+	require.Len(t, oneOf, 2)
+
+	circle := oneOf[0].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "circle"}, circle["properties"].(map[string]any)["type"])
+	assert.Contains(t, circle["properties"], "radius")
+	assert.Contains(t, circle["required"], "type")
+	square := oneOf[1].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "square"}, square["properties"].(map[string]any)["type"])
+	assert.Contains(t, square["properties"], "side")
+
+	// The generated schema tells the variants apart
+	assert.NoError(t, tool.ValidateArguments(map[string]any{"shape": map[string]any{"type": "circle", "radius": 1}}))
+	assert.NoError(t, tool.ValidateArguments(map[string]any{"shape": map[string]any{"type": "square", "side": 1}}))
+	assert.ErrorContains(t, tool.ValidateArguments(map[string]any{"shape": map[string]any{"type": "triangle"}}),
+		"does not match any schema of oneOf")
+}
+
+func TestRegisterSchemaVariant_PanicsForNonInterface(t *testing.T) {
+	assert.Panics(t, func() { RegisterSchemaVariant[variantTestCircle]("circle", variantTestCircle{}) })
+	assert.Panics(t, func() { RegisterSchemaVariant[variantTestShape]("none", nil) })
+}
+
+func TestToolArgumentsSchema_MarshalComposition(t *testing.T) {
+	schema := ToolInputSchema{
+		Type:  "object",
+		OneOf: []any{map[string]any{"required": []string{"a"}}, map[string]any{"required": []string{"b"}}},
+	}
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"object","oneOf":[{"required":["a"]},{"required":["b"]}]}`, string(data))
+
+	var decoded ToolInputSchema
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.OneOf, 2)
+	assert.Nil(t, decoded.AnyOf)
+}
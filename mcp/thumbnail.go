@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Thumbnail returns a copy of an image resource scaled down to fit within
+// maxWidth x maxHeight while preserving its aspect ratio. Images that already
+// fit are returned at their original size. PNG, JPEG and GIF images are
+// supported; the thumbnail is encoded in the same format as the original.
+// An error is returned for non-image MIME types.
+func (b BlobResourceContents) Thumbnail(maxWidth, maxHeight int) (BlobResourceContents, error) {
+	if !strings.HasPrefix(b.MIMEType, "image/") {
+		return BlobResourceContents{}, fmt.Errorf("cannot create thumbnail for MIME type %q", b.MIMEType)
+	}
+	if maxWidth <= 0 || maxHeight <= 0 {
+		return BlobResourceContents{}, fmt.Errorf("invalid thumbnail size %dx%d", maxWidth, maxHeight)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(b.Blob)
+	if err != nil {
+		return BlobResourceContents{}, fmt.Errorf("failed to decode blob: %w", err)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return BlobResourceContents{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	width, height := thumbnailSize(src.Bounds().Dx(), src.Bounds().Dy(), maxWidth, maxHeight)
+	dst := resizeImage(src, width, height)
+
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		err = png.Encode(&buf, dst)
+	case "jpeg":
+		err = jpeg.Encode(&buf, dst, nil)
+	case "gif":
+		err = gif.Encode(&buf, dst, nil)
+	default:
+		return BlobResourceContents{}, fmt.Errorf("unsupported image format: %s", format)
+	}
+	if err != nil {
+		return BlobResourceContents{}, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return BlobResourceContents{
+		Meta:     b.Meta,
+		URI:      b.URI,
+		MIMEType: "image/" + format,
+		Blob:     base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// thumbnailSize scales width x height down to fit within maxWidth x maxHeight,
+// keeping the aspect ratio and never enlarging the image.
+func thumbnailSize(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	// Compare width/maxWidth with height/maxHeight without floating point
+	if width*maxHeight >= height*maxWidth {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+	return max(1, width*maxHeight/height), maxHeight
+}
+
+// resizeImage scales src to width x height with Catmull-Rom resampling.
+func resizeImage(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	if bounds.Dx() == width && bounds.Dy() == height {
+		return src
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
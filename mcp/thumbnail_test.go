@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redPNG4x4 is a 4x4 solid red PNG image.
+const redPNG4x4 = "iVBORw0KGgoAAAANSUhEUgAAAAQAAAAECAIAAAAmkwkpAAAAQUlEQVR4nAA0AMv/BP8AAAAAAAAAAAAAAAIAAAAAAAAAAAAAAAACAAAAAAAAAAAAAAAAAgAAAAAAAAAAAAAAAAMANG0BCj0WINoAAAAASUVORK5CYII="
+
+func decodeThumbnail(t *testing.T, blob BlobResourceContents) image.Image {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(blob.Blob)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func TestBlobResourceContentsThumbnail(t *testing.T) {
+	original := BlobResourceContents{
+		URI:      "file:///red.png",
+		MIMEType: "image/png",
+		Blob:     redPNG4x4,
+	}
+
+	tests := []struct {
+		name           string
+		maxWidth       int
+		maxHeight      int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{name: "square", maxWidth: 2, maxHeight: 2, expectedWidth: 2, expectedHeight: 2},
+		{name: "keeps aspect ratio", maxWidth: 1, maxHeight: 3, expectedWidth: 1, expectedHeight: 1},
+		{name: "does not enlarge", maxWidth: 10, maxHeight: 10, expectedWidth: 4, expectedHeight: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, err := original.Thumbnail(tt.maxWidth, tt.maxHeight)
+			require.NoError(t, err)
+
+			assert.Equal(t, original.URI, thumb.URI)
+			assert.Equal(t, "image/png", thumb.MIMEType)
+
+			img := decodeThumbnail(t, thumb)
+			assert.Equal(t, tt.expectedWidth, img.Bounds().Dx())
+			assert.Equal(t, tt.expectedHeight, img.Bounds().Dy())
+
+			r, g, b, a := img.At(0, 0).RGBA()
+			assert.Equal(t, []uint32{0xffff, 0, 0, 0xffff}, []uint32{r, g, b, a})
+		})
+	}
+}
+
+func TestBlobResourceContentsThumbnailErrors(t *testing.T) {
+	_, err := BlobResourceContents{MIMEType: "application/pdf", Blob: redPNG4x4}.Thumbnail(2, 2)
+	assert.ErrorContains(t, err, "application/pdf")
+
+	_, err = BlobResourceContents{MIMEType: "image/png", Blob: redPNG4x4}.Thumbnail(0, 2)
+	assert.Error(t, err)
+
+	_, err = BlobResourceContents{MIMEType: "image/png", Blob: "not base64!"}.Thumbnail(2, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,284 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// toolDefinition is a tool as described in a file read by LoadToolsFromFile.
+type toolDefinition struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// LoadToolsFromFile reads tool definitions from a JSON or YAML file holding an
+// array of objects with a name, a description and an inputSchema. The format
+// is chosen by the file extension: .json, .yaml or .yml.
+//
+// Each input schema is checked against the JSON Schema meta-schema before the
+// tools are returned, and is kept as is in the RawInputSchema of its tool. The
+// tools have no handlers; register them with the server together with one.
+func LoadToolsFromFile(path string) ([]Tool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool definitions: %w", err)
+	}
+
+	var definitions []toolDefinition
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &definitions)
+	case ".yaml", ".yml":
+		definitions, err = decodeYAMLToolDefinitions(data)
+	default:
+		return nil, fmt.Errorf("unsupported tool definition file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tool definitions in %s: %w", path, err)
+	}
+
+	tools := make([]Tool, 0, len(definitions))
+	seen := make(map[string]bool, len(definitions))
+	for i, definition := range definitions {
+		if definition.Name == "" {
+			return nil, fmt.Errorf("tool at index %d has no name", i)
+		}
+		if seen[definition.Name] {
+			return nil, fmt.Errorf("tool %q is defined more than once", definition.Name)
+		}
+		seen[definition.Name] = true
+
+		if definition.InputSchema == nil {
+			return nil, fmt.Errorf("tool %q has no inputSchema", definition.Name)
+		}
+		if err := validateInputSchema(definition.InputSchema); err != nil {
+			return nil, fmt.Errorf("tool %q has an invalid inputSchema: %w", definition.Name, err)
+		}
+
+		schema, err := json.Marshal(definition.InputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("tool %q has an invalid inputSchema: %w", definition.Name, err)
+		}
+		tools = append(tools, NewToolWithRawSchema(definition.Name, definition.Description, schema))
+	}
+
+	return tools, nil
+}
+
+// decodeYAMLToolDefinitions decodes YAML tool definitions by converting them
+// to JSON, so that both formats are interpreted the same way.
+func decodeYAMLToolDefinitions(data []byte) ([]toolDefinition, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []toolDefinition
+	if err := json.Unmarshal(jsonData, &definitions); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// validateInputSchema checks that schema is a valid JSON Schema describing an
+// object, as required for tool input schemas.
+func validateInputSchema(schema map[string]any) error {
+	if schema["type"] != "object" {
+		return fmt.Errorf("type must be \"object\"")
+	}
+	return validateSchema(schema, "")
+}
+
+// jsonSchemaTypes are the values allowed for the type keyword.
+var jsonSchemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "string": true, "integer": true,
+}
+
+// validateSchema checks the keywords of a (sub)schema against the rules of the
+// JSON Schema meta-schema. Unknown keywords are allowed, as in JSON Schema.
+// path is the JSON pointer of the schema, used in error messages.
+func validateSchema(schema any, path string) error {
+	if _, ok := schema.(bool); ok {
+		return nil
+	}
+	object, ok := schema.(map[string]any)
+	if !ok {
+		return &schemaError{path: path, err: errors.New("schema must be an object or a boolean")}
+	}
+
+	for keyword, value := range object {
+		keywordPath := path + "/" + keyword
+		var err error
+		switch keyword {
+		case "type":
+			err = validateSchemaType(value)
+		case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+			err = validateSchemaMap(value, keywordPath)
+		case "items", "additionalItems", "additionalProperties", "unevaluatedItems",
+			"unevaluatedProperties", "contains", "propertyNames", "not", "if", "then", "else":
+			err = validateSchema(value, keywordPath)
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			err = validateSchemaArray(value, keywordPath)
+		case "required":
+			err = validateUniqueStrings(value)
+		case "enum":
+			if _, ok := value.([]any); !ok {
+				err = fmt.Errorf("must be an array")
+			}
+		case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties",
+			"minContains", "maxContains":
+			err = validateNonNegativeInteger(value)
+		case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+			if _, ok := value.(float64); !ok {
+				err = fmt.Errorf("must be a number")
+			}
+		case "multipleOf":
+			if n, ok := value.(float64); !ok || n <= 0 {
+				err = fmt.Errorf("must be a number greater than 0")
+			}
+		case "pattern":
+			err = validatePattern(value)
+		case "uniqueItems":
+			if _, ok := value.(bool); !ok {
+				err = fmt.Errorf("must be a boolean")
+			}
+		case "$schema", "$id", "$ref", "$anchor", "title", "description", "format":
+			if _, ok := value.(string); !ok {
+				err = fmt.Errorf("must be a string")
+			}
+		}
+		if err != nil {
+			// Errors from nested schemas already carry their path
+			var nested *schemaError
+			if errors.As(err, &nested) {
+				return err
+			}
+			return &schemaError{path: keywordPath, err: err}
+		}
+	}
+	return nil
+}
+
+// schemaError reports an invalid keyword in a schema.
+type schemaError struct {
+	path string
+	err  error
+}
+
+func (e *schemaError) Error() string {
+	return fmt.Sprintf("%s: %v", schemaPath(e.path), e.err)
+}
+
+func (e *schemaError) Unwrap() error {
+	return e.err
+}
+
+func schemaPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func validateSchemaType(value any) error {
+	switch v := value.(type) {
+	case string:
+		if !jsonSchemaTypes[v] {
+			return fmt.Errorf("unknown type %q", v)
+		}
+		return nil
+	case []any:
+		if len(v) == 0 {
+			return fmt.Errorf("must not be empty")
+		}
+		if err := validateUniqueStrings(v); err != nil {
+			return err
+		}
+		for _, t := range v {
+			if !jsonSchemaTypes[t.(string)] {
+				return fmt.Errorf("unknown type %q", t)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("must be a string or an array of strings")
+	}
+}
+
+func validateSchemaMap(value any, path string) error {
+	schemas, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("must be an object")
+	}
+	for name, schema := range schemas {
+		if err := validateSchema(schema, path+"/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSchemaArray(value any, path string) error {
+	schemas, ok := value.([]any)
+	if !ok || len(schemas) == 0 {
+		return fmt.Errorf("must be a non-empty array")
+	}
+	for i, schema := range schemas {
+		if err := validateSchema(schema, fmt.Sprintf("%s/%d", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateUniqueStrings(value any) error {
+	values, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("must be an array of strings")
+	}
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("must be an array of strings")
+		}
+		if seen[s] {
+			return fmt.Errorf("duplicate value %q", s)
+		}
+		seen[s] = true
+	}
+	return nil
+}
+
+func validateNonNegativeInteger(value any) error {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return fmt.Errorf("must be a non-negative integer")
+	}
+	return nil
+}
+
+func validatePattern(value any) error {
+	pattern, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("must be a string")
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeToolFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadToolsFromFile(t *testing.T) {
+	jsonFile := writeToolFile(t, "tools.json", `[
+		{
+			"name": "get_weather",
+			"description": "Get the weather for a city",
+			"inputSchema": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "minLength": 1},
+					"units": {"type": "string", "enum": ["metric", "imperial"]}
+				},
+				"required": ["city"]
+			}
+		},
+		{
+			"name": "ping",
+			"inputSchema": {"type": "object"}
+		}
+	]`)
+	yamlFile := writeToolFile(t, "tools.yaml", `
+- name: get_weather
+  description: Get the weather for a city
+  inputSchema:
+    type: object
+    properties:
+      city:
+        type: string
+        minLength: 1
+      units:
+        type: string
+        enum: [metric, imperial]
+    required: [city]
+- name: ping
+  inputSchema:
+    type: object
+`)
+
+	for _, path := range []string{jsonFile, yamlFile} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			tools, err := LoadToolsFromFile(path)
+			require.NoError(t, err)
+			require.Len(t, tools, 2)
+
+			assert.Equal(t, "get_weather", tools[0].Name)
+			assert.Equal(t, "Get the weather for a city", tools[0].Description)
+			assert.JSONEq(t, `{
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "minLength": 1},
+					"units": {"type": "string", "enum": ["metric", "imperial"]}
+				},
+				"required": ["city"]
+			}`, string(tools[0].RawInputSchema))
+
+			assert.Equal(t, "ping", tools[1].Name)
+			assert.Empty(t, tools[1].Description)
+
+			// The loaded tools marshal like tools built in code
+			data, err := json.Marshal(tools[1])
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"inputSchema":{"type":"object"}`)
+		})
+	}
+}
+
+func TestLoadToolsFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "unsupported extension",
+			file:          "tools.txt",
+			content:       `[]`,
+			expectedError: `unsupported tool definition file extension ".txt"`,
+		},
+		{
+			name:          "malformed JSON",
+			file:          "tools.json",
+			content:       `[{"name": "broken"`,
+			expectedError: "failed to parse tool definitions",
+		},
+		{
+			name:          "missing name",
+			file:          "tools.json",
+			content:       `[{"inputSchema": {"type": "object"}}]`,
+			expectedError: "tool at index 0 has no name",
+		},
+		{
+			name:          "duplicate name",
+			file:          "tools.yml",
+			content:       "- name: a\n  inputSchema: {type: object}\n- name: a\n  inputSchema: {type: object}\n",
+			expectedError: `tool "a" is defined more than once`,
+		},
+		{
+			name:          "missing input schema",
+			file:          "tools.json",
+			content:       `[{"name": "a"}]`,
+			expectedError: `tool "a" has no inputSchema`,
+		},
+		{
+			name:          "input schema is not an object schema",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "string"}}]`,
+			expectedError: `type must be "object"`,
+		},
+		{
+			name:          "unknown property type",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "object", "properties": {"x": {"type": "text"}}}}]`,
+			expectedError: `/properties/x/type: unknown type "text"`,
+		},
+		{
+			name:          "required is not a string array",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "object", "required": "x"}}]`,
+			expectedError: "/required: must be an array of strings",
+		},
+		{
+			name:          "negative minLength",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "object", "properties": {"x": {"type": "string", "minLength": -1}}}}]`,
+			expectedError: "/properties/x/minLength: must be a non-negative integer",
+		},
+		{
+			name:          "invalid pattern",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "object", "properties": {"x": {"type": "string", "pattern": "("}}}}]`,
+			expectedError: "/properties/x/pattern: invalid regular expression",
+		},
+		{
+			name:          "subschema is not a schema",
+			file:          "tools.json",
+			content:       `[{"name": "a", "inputSchema": {"type": "object", "properties": {"x": {"type": "array", "items": 3}}}}]`,
+			expectedError: "/properties/x/items: schema must be an object or a boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadToolsFromFile(writeToolFile(t, tt.file, tt.content))
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+
+	_, err := LoadToolsFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
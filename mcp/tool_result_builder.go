@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"slices"
+)
+
+// ToolResultBuilder builds a CallToolResult with several content items, in
+// the order they are added:
+//
+//	result := mcp.NewToolResultBuilder().
+//		AddText("Generated the chart").
+//		AddImage(png, "image/png").
+//		Build()
+type ToolResultBuilder struct {
+	result CallToolResult
+}
+
+// NewToolResultBuilder creates an empty ToolResultBuilder.
+func NewToolResultBuilder() *ToolResultBuilder {
+	return &ToolResultBuilder{}
+}
+
+// AddText adds a text content item.
+func (b *ToolResultBuilder) AddText(text string) *ToolResultBuilder {
+	b.result.Content = append(b.result.Content, NewTextContent(text))
+	return b
+}
+
+// AddImage adds an image content item with the raw image data, which is
+// base64-encoded.
+func (b *ToolResultBuilder) AddImage(data []byte, mimeType string) *ToolResultBuilder {
+	b.result.Content = append(b.result.Content, NewImageContent(base64.StdEncoding.EncodeToString(data), mimeType))
+	return b
+}
+
+// AddResourceLink adds a link to a resource, as made by NewResourceLink.
+func (b *ToolResultBuilder) AddResourceLink(uri, name, description, mimeType string) *ToolResultBuilder {
+	b.result.Content = append(b.result.Content, NewResourceLink(uri, name, description, mimeType))
+	return b
+}
+
+// SetError sets whether the result reports an error of the tool.
+func (b *ToolResultBuilder) SetError(isError bool) *ToolResultBuilder {
+	b.result.IsError = isError
+	return b
+}
+
+// Build returns the result. The builder can be used again afterwards without
+// affecting the returned result.
+func (b *ToolResultBuilder) Build() *CallToolResult {
+	result := b.result
+	result.Content = slices.Clone(b.result.Content)
+	return &result
+}
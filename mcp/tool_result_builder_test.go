@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolResultBuilder(t *testing.T) {
+	builder := NewToolResultBuilder().
+		AddText("Generated the chart").
+		AddImage([]byte("png-bytes"), "image/png").
+		AddResourceLink("file:///chart.csv", "chart.csv", "Chart data", "text/csv")
+	result := builder.Build()
+
+	require.Len(t, result.Content, 3)
+	assert.Equal(t, NewTextContent("Generated the chart"), result.Content[0])
+	assert.Equal(t, NewImageContent("cG5nLWJ5dGVz", "image/png"), result.Content[1])
+	assert.Equal(t, NewResourceLink("file:///chart.csv", "chart.csv", "Chart data", "text/csv"), result.Content[2])
+	assert.False(t, result.IsError)
+
+	// Results already built are not affected by further use of the builder
+	errResult := builder.AddText("Upload failed").SetError(true).Build()
+	assert.Len(t, result.Content, 3)
+	assert.False(t, result.IsError)
+	assert.Len(t, errResult.Content, 4)
+	assert.True(t, errResult.IsError)
+
+	assert.Empty(t, NewToolResultBuilder().Build().Content)
+}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/invopop/jsonschema"
 )
@@ -50,6 +53,20 @@ type CallToolResult struct {
 	IsError bool `json:"isError,omitempty"`
 }
 
+// FilterContent returns a copy of the result that only contains the content
+// items for which predicate returns true. IsError, StructuredContent and Meta
+// are preserved.
+func (r *CallToolResult) FilterContent(predicate func(Content) bool) *CallToolResult {
+	filtered := *r
+	filtered.Content = make([]Content, 0, len(r.Content))
+	for _, content := range r.Content {
+		if predicate(content) {
+			filtered.Content = append(filtered.Content, content)
+		}
+	}
+	return &filtered
+}
+
 // CallToolRequest is used by the client to invoke a tool provided by the server.
 type CallToolRequest struct {
 	Request
@@ -63,6 +80,66 @@ type CallToolParams struct {
 	Meta      *Meta  `json:"_meta,omitempty"`
 }
 
+// Clone returns a deep copy of the request, which can be used concurrently
+// with the request, for example by goroutines a tool handler fans out to.
+// Maps and slices in the arguments and _meta are copied recursively; other
+// values are copied as is.
+func (r CallToolRequest) Clone() *CallToolRequest {
+	clone := r
+	clone.Header = r.Header.Clone()
+	clone.Request.Params.Meta = cloneMeta(r.Request.Params.Meta)
+	clone.Params.Arguments = cloneValue(r.Params.Arguments)
+	clone.Params.Meta = cloneMeta(r.Params.Meta)
+	return &clone
+}
+
+// cloneMeta returns a deep copy of m, which may be nil.
+func cloneMeta(m *Meta) *Meta {
+	if m == nil {
+		return nil
+	}
+	clone := &Meta{ProgressToken: m.ProgressToken}
+	if fields := m.GetAdditionalFields(); fields != nil {
+		for k, v := range cloneValue(fields).(map[string]any) {
+			clone.SetAdditionalField(k, v)
+		}
+	}
+	return clone
+}
+
+// cloneValue returns a deep copy of the maps and slices of a value decoded
+// from JSON.
+func cloneValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		clone := make(map[string]any, len(v))
+		for key, item := range v {
+			clone[key] = cloneValue(item)
+		}
+		return clone
+	case []any:
+		if v == nil {
+			return v
+		}
+		clone := make([]any, len(v))
+		for i, item := range v {
+			clone[i] = cloneValue(item)
+		}
+		return clone
+	case map[string]string:
+		return maps.Clone(v)
+	case []string:
+		return slices.Clone(v)
+	case json.RawMessage:
+		return slices.Clone(v)
+	default:
+		return value
+	}
+}
+
 // GetArguments returns the Arguments as map[string]any for backward compatibility
 // If Arguments is not a map, it returns an empty map
 func (r CallToolRequest) GetArguments() map[string]any {
@@ -80,19 +157,41 @@ func (r CallToolRequest) GetRawArguments() any {
 
 // BindArguments unmarshals the Arguments into the provided struct
 // This is useful for working with strongly-typed arguments
+//
+// Arguments are matched to fields by the names WithInputSchema gives their
+// properties: the `schema` tag of the field, then its `json` tag, then the
+// field name.
 func (r CallToolRequest) BindArguments(target any) error {
 	if target == nil || reflect.ValueOf(target).Kind() != reflect.Ptr {
 		return fmt.Errorf("target must be a non-nil pointer")
 	}
 
+	tagged := usesSchemaTags(reflect.TypeOf(target))
+
 	// Fast-path: already raw JSON
-	if raw, ok := r.Params.Arguments.(json.RawMessage); ok {
+	raw, isRaw := r.Params.Arguments.(json.RawMessage)
+	if isRaw && !tagged {
 		return json.Unmarshal(raw, target)
 	}
 
-	data, err := json.Marshal(r.Params.Arguments)
-	if err != nil {
-		return fmt.Errorf("failed to marshal arguments: %w", err)
+	data := []byte(raw)
+	if !isRaw {
+		var err error
+		data, err = json.Marshal(r.Params.Arguments)
+		if err != nil {
+			return fmt.Errorf("failed to marshal arguments: %w", err)
+		}
+	}
+
+	if tagged {
+		arguments, err := decodeArguments(data)
+		if err != nil {
+			return err
+		}
+		data, err = json.Marshal(argumentsFromSchemaTags(arguments, reflect.TypeOf(target)))
+		if err != nil {
+			return fmt.Errorf("failed to marshal arguments: %w", err)
+		}
 	}
 
 	return json.Unmarshal(data, target)
@@ -571,6 +670,16 @@ type Tool struct {
 	RawOutputSchema json.RawMessage `json:"-"` // Hide this from JSON marshaling
 	// Optional properties describing tool behavior
 	Annotations ToolAnnotation `json:"annotations"`
+	// Whether the tool is deprecated and should no longer be used
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Optional explanation of the deprecation, such as which tool to use instead
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// Maximum duration of a call of the tool, enforced by the server, if
+	// non-zero. It is not sent to clients.
+	Timeout time.Duration `json:"-"`
+
+	// Completion providers of the arguments, by argument name
+	completers map[string]CompletionProvider
 }
 
 // GetName returns the name of the tool.
@@ -578,6 +687,100 @@ func (t Tool) GetName() string {
 	return t.Name
 }
 
+// SetMeta sets key in the _meta of the tool, for example to carry UI hints,
+// versioning or namespace information.
+func (t *Tool) SetMeta(key string, value any) {
+	t.Meta = metaWithField(t.Meta, key, value)
+}
+
+// GetMeta returns the value of key in the _meta of the tool.
+func (t Tool) GetMeta(key string) (any, bool) {
+	return metaField(t.Meta, key)
+}
+
+// ArgumentDescription returns the description of the named argument in the
+// input schema of the tool, or "" if the argument or its description is
+// missing.
+func (t Tool) ArgumentDescription(name string) string {
+	description, _ := t.argumentSchema(name)["description"].(string)
+	return description
+}
+
+// ArgumentType returns the JSON Schema type of the named argument in the
+// input schema of the tool. Arguments that may have one of several types,
+// through oneOf, anyOf or a list of types, are of type "union". It returns ""
+// if the argument or its type is missing.
+func (t Tool) ArgumentType(name string) string {
+	schema := t.argumentSchema(name)
+	if _, ok := schema["oneOf"]; ok {
+		return "union"
+	}
+	if _, ok := schema["anyOf"]; ok {
+		return "union"
+	}
+
+	switch v := schema["type"].(type) {
+	case string:
+		return v
+	case []any:
+		// A nullable type such as ["string", "null"] is still a single type
+		var types []string
+		for _, typ := range v {
+			if s, ok := typ.(string); ok && s != "null" {
+				types = append(types, s)
+			}
+		}
+		if len(types) == 1 {
+			return types[0]
+		}
+		return "union"
+	default:
+		return ""
+	}
+}
+
+// argumentSchema returns the schema of the named property of the input schema,
+// decoded from JSON, or nil if there is no such property.
+func (t Tool) argumentSchema(name string) map[string]any {
+	var property any
+	if t.RawInputSchema != nil {
+		var schema struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(t.RawInputSchema, &schema); err != nil {
+			return nil
+		}
+		property = schema.Properties[name]
+	} else {
+		property = t.InputSchema.Properties[name]
+	}
+	if property == nil {
+		return nil
+	}
+
+	// Properties may be given as maps or as raw JSON
+	if schema, ok := property.(map[string]any); ok {
+		return schema
+	}
+	data, err := json.Marshal(property)
+	if err != nil {
+		return nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	return schema
+}
+
+// Deprecate returns a copy of the tool marked as deprecated with the given
+// reason, which is sent to clients as the deprecation message.
+func (t Tool) Deprecate(reason string) Tool {
+	t.Deprecated = true
+	t.DeprecationMessage = reason
+	return t
+}
+
 // MarshalJSON implements the json.Marshaler interface for Tool.
 // It handles marshaling either InputSchema or RawInputSchema based on which is set.
 func (t Tool) MarshalJSON() ([]byte, error) {
@@ -613,6 +816,13 @@ func (t Tool) MarshalJSON() ([]byte, error) {
 
 	m["annotations"] = t.Annotations
 
+	if t.Deprecated {
+		m["deprecated"] = true
+		if t.DeprecationMessage != "" {
+			m["deprecationMessage"] = t.DeprecationMessage
+		}
+	}
+
 	// Marshal Meta if present
 	if t.Meta != nil {
 		m["_meta"] = t.Meta
@@ -627,6 +837,11 @@ type ToolArgumentsSchema struct {
 	Type       string         `json:"type"`
 	Properties map[string]any `json:"properties,omitempty"`
 	Required   []string       `json:"required,omitempty"`
+	// OneOf, AnyOf and AllOf compose the schema with subschemas, which
+	// arguments must match exactly one of, at least one of, or all of.
+	OneOf []any `json:"oneOf,omitempty"`
+	AnyOf []any `json:"anyOf,omitempty"`
+	AllOf []any `json:"allOf,omitempty"`
 }
 
 type ToolInputSchema ToolArgumentsSchema // For retro-compatibility
@@ -650,6 +865,16 @@ func (tis ToolArgumentsSchema) MarshalJSON() ([]byte, error) {
 		m["required"] = tis.Required
 	}
 
+	if len(tis.OneOf) > 0 {
+		m["oneOf"] = tis.OneOf
+	}
+	if len(tis.AnyOf) > 0 {
+		m["anyOf"] = tis.AnyOf
+	}
+	if len(tis.AllOf) > 0 {
+		m["allOf"] = tis.AllOf
+	}
+
 	return json.Marshal(m)
 }
 
@@ -722,6 +947,80 @@ func NewToolWithRawSchema(name, description string, schema json.RawMessage) Tool
 	return tool
 }
 
+// ToolConfig holds every field of a tool, to create it at once with
+// NewToolWithOptions rather than with options.
+type ToolConfig struct {
+	// Name is the name of the tool.
+	Name string
+	// Description is a human-readable description of the tool.
+	Description string
+	// InputSchema is the JSON Schema of the arguments. If nil, the tool
+	// takes an object with no declared properties, as with NewTool.
+	InputSchema json.RawMessage
+	// OutputSchema is the JSON Schema of the structured content of results.
+	// If nil, the tool declares no output schema.
+	OutputSchema json.RawMessage
+	// Annotations are hints about the behavior of the tool. If nil, the
+	// defaults of NewTool are used.
+	Annotations *ToolAnnotation
+	// Meta is the _meta of the tool.
+	Meta *Meta
+	// Deprecated marks the tool as deprecated, with DeprecationMessage as
+	// the reason.
+	Deprecated         bool
+	DeprecationMessage string
+}
+
+// NewToolWithOptions creates a tool with every field set from cfg. Further
+// options can be applied to the returned tool with its fields or with the
+// ToolOption functions.
+func NewToolWithOptions(cfg ToolConfig) Tool {
+	opts := []ToolOption{WithDescription(cfg.Description), WithToolMeta(cfg.Meta)}
+	if cfg.InputSchema != nil {
+		opts = append(opts, WithRawInputSchema(cfg.InputSchema))
+	}
+	if cfg.OutputSchema != nil {
+		opts = append(opts, WithRawOutputSchema(cfg.OutputSchema))
+	}
+	if cfg.Annotations != nil {
+		opts = append(opts, WithToolAnnotation(*cfg.Annotations))
+	}
+	if cfg.Deprecated {
+		opts = append(opts, WithDeprecation(cfg.DeprecationMessage))
+	}
+
+	tool := NewTool(cfg.Name, opts...)
+	if tool.RawInputSchema != nil {
+		// The raw schema replaces the default one
+		tool.InputSchema = ToolInputSchema{}
+	}
+	return tool
+}
+
+// WithToolMeta sets the _meta of the Tool.
+func WithToolMeta(meta *Meta) ToolOption {
+	return func(t *Tool) {
+		t.Meta = meta
+	}
+}
+
+// WithDeprecation marks the Tool as deprecated, with reason sent to clients
+// as the deprecation message.
+func WithDeprecation(reason string) ToolOption {
+	return func(t *Tool) {
+		*t = t.Deprecate(reason)
+	}
+}
+
+// WithToolTimeout sets the maximum duration of a call of the tool. The
+// server cancels the context of the handler at the deadline and reports a
+// call that returns after it as a timeout error.
+func WithToolTimeout(timeout time.Duration) ToolOption {
+	return func(t *Tool) {
+		t.Timeout = timeout
+	}
+}
+
 // WithDescription adds a description to the Tool.
 // The description should provide a clear, human-readable explanation of what the tool does.
 func WithDescription(description string) ToolOption {
@@ -732,6 +1031,10 @@ func WithDescription(description string) ToolOption {
 
 // WithInputSchema creates a ToolOption that sets the input schema for a tool.
 // It accepts any Go type, usually a struct, and automatically generates a JSON schema from it.
+//
+// The properties of the schema are named after the `schema` tag of their
+// field, as used by gorilla/schema, then its `json` tag, then the field name.
+// A `schema:"-"` tag omits the field.
 func WithInputSchema[T any]() ToolOption {
 	return func(t *Tool) {
 		var zero T
@@ -742,8 +1045,10 @@ func WithInputSchema[T any]() ToolOption {
 			DoNotReference:            true, // Removes $defs map, outputs entire structure inline
 			Anonymous:                 true, // Hides auto-generated Schema IDs
 			AllowAdditionalProperties: true, // Removes additionalProperties: false
+			Mapper:                    schemaVariantMapper,
 		}
 		schema := reflector.Reflect(zero)
+		applySchemaTags(schema, reflect.TypeOf(zero))
 
 		// Clean up schema for MCP compliance
 		schema.Version = "" // Remove $schema field
@@ -760,6 +1065,43 @@ func WithInputSchema[T any]() ToolOption {
 	}
 }
 
+// WithInputSchemaRefs is like WithInputSchema, but the schemas of the named
+// struct types used by the fields of T are generated once under $defs and
+// referred to with $ref, rather than repeated inline wherever they are used.
+// This also supports recursive types, such as tree nodes.
+func WithInputSchemaRefs[T any]() ToolOption {
+	return func(t *Tool) {
+		var zero T
+
+		reflector := jsonschema.Reflector{
+			ExpandedStruct:            true, // Inlines T itself, so the root is an object schema
+			Anonymous:                 true, // Hides auto-generated Schema IDs
+			AllowAdditionalProperties: true, // Removes additionalProperties: false
+			Mapper:                    schemaVariantMapper,
+		}
+		schema := reflector.Reflect(zero)
+		applySchemaTags(schema, reflect.TypeOf(zero))
+
+		// Clean up schema for MCP compliance
+		schema.Version = "" // Remove $schema field
+
+		mcpSchema, err := json.Marshal(schema)
+		if err != nil {
+			// Skip and maintain backward compatibility
+			return
+		}
+
+		var inputSchema ToolInputSchema
+		if err := json.Unmarshal(mcpSchema, &inputSchema); err != nil {
+			// Skip and maintain backward compatibility
+			return
+		}
+		inputSchema.Type = "object"
+		t.InputSchema = inputSchema
+		t.RawInputSchema = nil
+	}
+}
+
 // WithRawInputSchema sets a raw JSON schema for the tool's input.
 // Use this when you need full control over the schema or when working with
 // complex schemas that can't be generated from Go types. The jsonschema library
@@ -773,6 +1115,9 @@ func WithRawInputSchema(schema json.RawMessage) ToolOption {
 
 // WithOutputSchema creates a ToolOption that sets the output schema for a tool.
 // It accepts any Go type, usually a struct, and automatically generates a JSON schema from it.
+//
+// Unlike input schemas, properties are named after the `json` tags only, as
+// structured content is encoded with encoding/json.
 func WithOutputSchema[T any]() ToolOption {
 	return func(t *Tool) {
 		var zero T
@@ -783,6 +1128,7 @@ func WithOutputSchema[T any]() ToolOption {
 			DoNotReference:            true, // Removes $defs map, outputs entire structure inline
 			Anonymous:                 true, // Hides auto-generated Schema IDs
 			AllowAdditionalProperties: true, // Removes additionalProperties: false
+			Mapper:                    schemaVariantMapper,
 		}
 		schema := reflector.Reflect(zero)
 
@@ -1193,6 +1539,34 @@ func Items(schema any) PropertyOption {
 	}
 }
 
+// OneOf requires the property to match exactly one of the given schemas.
+//
+// Example:
+//
+//	OneOf(
+//	    map[string]any{"type": "string"},
+//	    map[string]any{"type": "number"},
+//	)
+func OneOf(schemas ...any) PropertyOption {
+	return func(schema map[string]any) {
+		schema["oneOf"] = schemas
+	}
+}
+
+// AnyOf requires the property to match at least one of the given schemas.
+func AnyOf(schemas ...any) PropertyOption {
+	return func(schema map[string]any) {
+		schema["anyOf"] = schemas
+	}
+}
+
+// AllOf requires the property to match all of the given schemas.
+func AllOf(schemas ...any) PropertyOption {
+	return func(schema map[string]any) {
+		schema["allOf"] = schemas
+	}
+}
+
 // MinItems sets the minimum number of items for an array
 func MinItems(min int) PropertyOption {
 	return func(schema map[string]any) {
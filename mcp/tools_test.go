@@ -3,9 +3,14 @@ package mcp
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestToolWithBothSchemasError verifies that there will be feedback if the
@@ -1549,3 +1554,317 @@ func TestToolMetaMarshalingOmitsWhenNil(t *testing.T) {
 	// Check that _meta field is not present
 	assert.NotContains(t, result, "_meta", "Tool without Meta should not include _meta field")
 }
+
+func TestToolDeprecate(t *testing.T) {
+	tool := NewTool("old-tool", WithDescription("An old tool"))
+	deprecated := tool.Deprecate("use X instead")
+
+	// Deprecate returns a copy and leaves the original untouched
+	assert.False(t, tool.Deprecated)
+	assert.True(t, deprecated.Deprecated)
+	assert.Equal(t, "use X instead", deprecated.DeprecationMessage)
+
+	data, err := json.Marshal(deprecated)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"deprecated":true`)
+	assert.Contains(t, string(data), `"deprecationMessage":"use X instead"`)
+
+	var unmarshalled Tool
+	assert.NoError(t, json.Unmarshal(data, &unmarshalled))
+	assert.True(t, unmarshalled.Deprecated)
+	assert.Equal(t, "use X instead", unmarshalled.DeprecationMessage)
+
+	data, err = json.Marshal(tool)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "deprecated")
+}
+
+func TestCallToolResultFilterContent(t *testing.T) {
+	result := &CallToolResult{
+		Content: []Content{
+			NewTextContent("first"),
+			NewImageContent("aW1hZ2U=", "image/png"),
+			NewTextContent("second"),
+			NewAudioContent("YXVkaW8=", "audio/wav"),
+		},
+		IsError: true,
+	}
+
+	filtered := result.FilterContent(func(c Content) bool {
+		_, ok := c.(TextContent)
+		return ok
+	})
+
+	assert.Equal(t, []Content{NewTextContent("first"), NewTextContent("second")}, filtered.Content)
+	assert.True(t, filtered.IsError)
+	// The original result is left untouched
+	assert.Len(t, result.Content, 4)
+
+	none := result.FilterContent(func(Content) bool { return false })
+	assert.NotNil(t, none.Content)
+	assert.Empty(t, none.Content)
+	assert.True(t, none.IsError)
+}
+
+func TestToolSetMeta(t *testing.T) {
+	tool := NewTool("meta-tool")
+	_, ok := tool.GetMeta("ui")
+	assert.False(t, ok)
+
+	tool.SetMeta("ui", map[string]any{"icon": "wrench"})
+	tool.SetMeta("version", "2.1.0")
+
+	copied := tool
+	copied.SetMeta("version", "3.0.0")
+	version, _ := tool.GetMeta("version")
+	assert.Equal(t, "2.1.0", version, "SetMeta on a copy must not change the original")
+
+	data, err := json.Marshal(tool)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"ui":{"icon":"wrench"},"version":"2.1.0"}`)
+
+	var decoded Tool
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	version, ok = decoded.GetMeta("version")
+	assert.True(t, ok)
+	assert.Equal(t, "2.1.0", version)
+	ui, ok := decoded.GetMeta("ui")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"icon": "wrench"}, ui)
+}
+
+func TestToolArgumentDescription(t *testing.T) {
+	tool := NewTool("search",
+		WithString("query", Description("The text to search for")),
+		WithNumber("limit"),
+	)
+	assert.Equal(t, "The text to search for", tool.ArgumentDescription("query"))
+	assert.Equal(t, "", tool.ArgumentDescription("limit"))
+	assert.Equal(t, "", tool.ArgumentDescription("missing"))
+
+	raw := NewToolWithRawSchema("search", "", json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string", "description": "The text to search for"},
+			"limit": {"type": "integer"}
+		}
+	}`))
+	assert.Equal(t, "The text to search for", raw.ArgumentDescription("query"))
+	assert.Equal(t, "", raw.ArgumentDescription("limit"))
+	assert.Equal(t, "", raw.ArgumentDescription("missing"))
+}
+
+func TestToolArgumentType(t *testing.T) {
+	tool := NewToolWithRawSchema("search", "", json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string"},
+			"limit": {"type": "integer"},
+			"exact": {"type": "boolean"},
+			"cursor": {"type": ["string", "null"]},
+			"id": {"type": ["string", "integer"]},
+			"filter": {"oneOf": [{"type": "string"}, {"type": "object"}]},
+			"sort": {"anyOf": [{"type": "string"}, {"type": "array"}]},
+			"untyped": {"description": "Anything"}
+		}
+	}`))
+
+	tests := map[string]string{
+		"query":   "string",
+		"limit":   "integer",
+		"exact":   "boolean",
+		"cursor":  "string",
+		"id":      "union",
+		"filter":  "union",
+		"sort":    "union",
+		"untyped": "",
+		"missing": "",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, tool.ArgumentType(name), name)
+	}
+
+	built := NewTool("search", WithString("query"), WithNumber("limit"), WithBoolean("exact"))
+	assert.Equal(t, "string", built.ArgumentType("query"))
+	assert.Equal(t, "number", built.ArgumentType("limit"))
+	assert.Equal(t, "boolean", built.ArgumentType("exact"))
+	assert.Equal(t, "", built.ArgumentType("missing"))
+}
+
+func TestNewToolWithOptions(t *testing.T) {
+	meta := NewMetaFromMap(map[string]any{"version": "2"})
+	tool := NewToolWithOptions(ToolConfig{
+		Name:               "search",
+		Description:        "Search documents",
+		InputSchema:        json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}}}`),
+		OutputSchema:       json.RawMessage(`{"type":"object","properties":{"hits":{"type":"integer"}}}`),
+		Annotations:        &ToolAnnotation{Title: "Search", ReadOnlyHint: ToBoolPtr(true)},
+		Meta:               meta,
+		Deprecated:         true,
+		DeprecationMessage: "use search_v2",
+	})
+
+	data, err := json.Marshal(tool)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "search",
+		"description": "Search documents",
+		"inputSchema": {"type":"object","properties":{"query":{"type":"string"}}},
+		"outputSchema": {"type":"object","properties":{"hits":{"type":"integer"}}},
+		"annotations": {"title": "Search", "readOnlyHint": true},
+		"deprecated": true,
+		"deprecationMessage": "use search_v2",
+		"_meta": {"version": "2"}
+	}`, string(data))
+}
+
+func TestNewToolWithOptions_Defaults(t *testing.T) {
+	tool := NewToolWithOptions(ToolConfig{Name: "noop"})
+	assert.Equal(t, NewTool("noop"), tool)
+
+	tool = NewTool("noop", WithDeprecation("gone"), WithToolMeta(NewMetaFromMap(map[string]any{"k": "v"})))
+	assert.True(t, tool.Deprecated)
+	assert.Equal(t, "gone", tool.DeprecationMessage)
+	value, ok := tool.GetMeta("k")
+	assert.True(t, ok)
+	assert.Equal(t, "v", value)
+}
+
+func TestCallToolRequest_Clone(t *testing.T) {
+	request := CallToolRequest{Header: http.Header{"X-Trace": []string{"1"}}}
+	request.Params.Name = "search"
+	request.Params.Arguments = map[string]any{
+		"query": "go",
+		"filters": map[string]any{
+			"tags":  []any{"mcp", map[string]any{"lang": "en"}},
+			"limit": float64(10),
+		},
+	}
+	request.Params.Meta = &Meta{ProgressToken: NewStringProgressToken("p1")}
+	request.Params.Meta.SetAdditionalField("trace", map[string]any{"id": "t1"})
+
+	clone := request.Clone()
+	require.Equal(t, request.Params.Arguments, clone.Params.Arguments)
+	assert.Equal(t, "search", clone.Params.Name)
+	assert.Equal(t, NewStringProgressToken("p1"), clone.Params.Meta.ProgressToken)
+
+	// Modifying the clone does not affect the original
+	args := clone.GetArguments()
+	args["query"] = "rust"
+	filters := args["filters"].(map[string]any)
+	filters["limit"] = float64(20)
+	tags := filters["tags"].([]any)
+	tags[0] = "changed"
+	tags[1].(map[string]any)["lang"] = "fr"
+	trace, _ := clone.Params.Meta.GetAdditionalField("trace")
+	trace.(map[string]any)["id"] = "t2"
+	clone.Params.Meta.SetAdditionalField("extra", true)
+	clone.Header.Set("X-Trace", "2")
+
+	assert.Equal(t, map[string]any{
+		"query": "go",
+		"filters": map[string]any{
+			"tags":  []any{"mcp", map[string]any{"lang": "en"}},
+			"limit": float64(10),
+		},
+	}, request.Params.Arguments)
+	trace, _ = request.Params.Meta.GetAdditionalField("trace")
+	assert.Equal(t, map[string]any{"id": "t1"}, trace)
+	_, ok := request.Params.Meta.GetAdditionalField("extra")
+	assert.False(t, ok)
+	assert.Equal(t, "1", request.Header.Get("X-Trace"))
+
+	// Requests without arguments, meta or headers can be cloned too
+	empty := CallToolRequest{}.Clone()
+	assert.Nil(t, empty.Params.Arguments)
+	assert.Nil(t, empty.Params.Meta)
+	assert.Nil(t, empty.Header)
+}
+
+func TestWithToolTimeout(t *testing.T) {
+	tool := NewTool("scrape", WithToolTimeout(5*time.Second))
+	assert.Equal(t, 5*time.Second, tool.Timeout)
+
+	// The timeout is enforced by the server and not sent to clients
+	data, err := json.Marshal(tool)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "imeout")
+}
+
+func TestWithInputSchema_SchemaTags(t *testing.T) {
+	type Filter struct {
+		Field string `schema:"field_name" json:"field"`
+	}
+	type Args struct {
+		Query   string   `schema:"q" json:"query"`
+		Limit   int      `json:"limit,omitempty"`
+		Sort    string   `schema:"sort_by,required"`
+		Secret  string   `schema:"-" json:"secret"`
+		Plain   bool     // Named after the field
+		Filters []Filter `schema:"filters"`
+	}
+
+	tool := NewTool("search", WithInputSchema[Args]())
+	var schema struct {
+		Properties map[string]struct {
+			Items struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"items"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(tool.RawInputSchema, &schema))
+	assert.ElementsMatch(t, []string{"q", "limit", "sort_by", "Plain", "filters"}, slices.Collect(maps.Keys(schema.Properties)))
+	assert.ElementsMatch(t, []string{"q", "sort_by", "Plain", "filters"}, schema.Required)
+	assert.Contains(t, schema.Properties["filters"].Items.Properties, "field_name")
+
+	// Referenced definitions are renamed too
+	tool = NewTool("search", WithInputSchemaRefs[Args]())
+	assert.Contains(t, tool.InputSchema.Properties, "q")
+	assert.NotContains(t, tool.InputSchema.Properties, "secret")
+	require.Contains(t, tool.InputSchema.Defs, "Filter")
+	assert.Contains(t, tool.InputSchema.Defs["Filter"].(map[string]any)["properties"], "field_name")
+}
+
+func TestCallToolRequest_BindArgumentsSchemaTags(t *testing.T) {
+	type Filter struct {
+		Field string `schema:"field_name" json:"field"`
+	}
+	type Args struct {
+		Query   string   `schema:"q" json:"query"`
+		Limit   int      `json:"limit,omitempty"`
+		Secret  string   `schema:"-" json:"secret"`
+		Plain   bool     // Named after the field
+		Filters []Filter `schema:"filters"`
+	}
+
+	for name, arguments := range map[string]any{
+		"map": map[string]any{
+			"q":       "mcp",
+			"limit":   10,
+			"secret":  "ignored",
+			"Plain":   true,
+			"filters": []any{map[string]any{"field_name": "title"}},
+		},
+		"raw JSON": json.RawMessage(`{"q": "mcp", "limit": 10, "secret": "ignored", "Plain": true, "filters": [{"field_name": "title"}]}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			request := CallToolRequest{}
+			request.Params.Arguments = arguments
+
+			var args Args
+			require.NoError(t, request.BindArguments(&args))
+			assert.Equal(t, Args{Query: "mcp", Limit: 10, Plain: true, Filters: []Filter{{Field: "title"}}}, args)
+		})
+	}
+}
+
+func TestWithOutputSchema_IgnoresSchemaTags(t *testing.T) {
+	type Result struct {
+		Query string `schema:"q" json:"query"`
+	}
+
+	tool := NewTool("search", WithOutputSchema[Result]())
+	assert.Contains(t, tool.OutputSchema.Properties, "query")
+	assert.NotContains(t, tool.OutputSchema.Properties, "q")
+}
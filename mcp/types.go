@@ -3,12 +3,19 @@
 package mcp
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/yosida95/uritemplate/v3"
 )
@@ -52,6 +59,11 @@ const (
 	// https://modelcontextprotocol.io/specification/2024-11-05/server/tools/
 	MethodToolsCall MCPMethod = "tools/call"
 
+	// MethodCompletionComplete asks for completion options for an argument of a
+	// prompt or resource template.
+	// https://modelcontextprotocol.io/specification/2025-06-18/server/utilities/completion
+	MethodCompletionComplete MCPMethod = "completion/complete"
+
 	// MethodSetLogLevel configures the minimum log level for client
 	// https://modelcontextprotocol.io/specification/2025-03-26/server/utilities/logging
 	MethodSetLogLevel MCPMethod = "logging/setLevel"
@@ -64,6 +76,11 @@ const (
 	// https://modelcontextprotocol.io/specification/2025-06-18/client/roots
 	MethodListRoots MCPMethod = "roots/list"
 
+	// MethodNotificationInitialized notifies the server that the client is
+	// done initializing, after receiving the initialize result.
+	// https://modelcontextprotocol.io/specification/2025-06-18/basic/lifecycle#initialization
+	MethodNotificationInitialized = "notifications/initialized"
+
 	// MethodNotificationResourcesListChanged notifies when the list of available resources changes.
 	// https://modelcontextprotocol.io/specification/2025-03-26/server/resources#list-changed-notification
 	MethodNotificationResourcesListChanged = "notifications/resources/list_changed"
@@ -81,6 +98,16 @@ const (
 	// MethodNotificationRootsListChanged notifies when the list of available roots changes.
 	// https://modelcontextprotocol.io/specification/2025-06-18/client/roots#root-list-changes
 	MethodNotificationRootsListChanged = "notifications/roots/list_changed"
+
+	// MethodNotificationCancelled notifies the receiver that a previously issued request was cancelled.
+	// https://modelcontextprotocol.io/specification/2025-06-18/basic/utilities/cancellation
+	MethodNotificationCancelled = "notifications/cancelled"
+
+	// MethodNotificationCapabilitiesChanged notifies the client that the
+	// capabilities of the server changed after initialization. It is an
+	// extension to the MCP specification, with the new capabilities as
+	// "capabilities" param.
+	MethodNotificationCapabilitiesChanged = "notifications/capabilities_changed"
 )
 
 type URITemplate struct {
@@ -123,7 +150,99 @@ var ValidProtocolVersions = []string{
 const JSONRPC_VERSION = "2.0"
 
 // ProgressToken is used to associate progress notifications with the original request.
-type ProgressToken any
+// As the specification requires, it is either a string or an integer; the zero
+// ProgressToken is unset. ProgressTokens are comparable, and equal when they
+// have the same type and value.
+type ProgressToken struct {
+	value any // string or int64, nil if unset
+}
+
+// NewStringProgressToken returns the string progress token s.
+func NewStringProgressToken(s string) ProgressToken {
+	return ProgressToken{value: s}
+}
+
+// NewIntProgressToken returns the integer progress token n.
+func NewIntProgressToken(n int64) ProgressToken {
+	return ProgressToken{value: n}
+}
+
+// IsZero reports whether the progress token is unset.
+func (t ProgressToken) IsZero() bool {
+	return t.value == nil
+}
+
+// Value returns the string or int64 value of the progress token, or nil if
+// it is unset.
+func (t ProgressToken) Value() any {
+	return t.value
+}
+
+// String returns the value of the progress token formatted as a string, or
+// an empty string if it is unset.
+func (t ProgressToken) String() string {
+	switch v := t.value.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON encodes the progress token as a JSON string or integer, or
+// null if it is unset.
+func (t ProgressToken) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+
+// UnmarshalJSON decodes a JSON string or integer into the progress token,
+// returning an error for other values. null decodes into an unset token.
+func (t *ProgressToken) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	token, err := progressTokenFromValue(value)
+	if err != nil {
+		return err
+	}
+	*t = token
+	return nil
+}
+
+// progressTokenFromValue returns the progress token of a string or integer
+// value, such as decoded from JSON into an untyped map.
+func progressTokenFromValue(value any) (ProgressToken, error) {
+	switch v := value.(type) {
+	case nil:
+		return ProgressToken{}, nil
+	case ProgressToken:
+		return v, nil
+	case string:
+		return NewStringProgressToken(v), nil
+	case int:
+		return NewIntProgressToken(int64(v)), nil
+	case int64:
+		return NewIntProgressToken(v), nil
+	case json.Number:
+		n, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return ProgressToken{}, fmt.Errorf("progress token %s is not a 64-bit integer", v)
+		}
+		return NewIntProgressToken(n), nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return ProgressToken{}, fmt.Errorf("progress token %v is not a 64-bit integer", v)
+		}
+		return NewIntProgressToken(int64(v)), nil
+	default:
+		return ProgressToken{}, fmt.Errorf("progress token must be a string or an integer, got %T", value)
+	}
+}
 
 // Cursor is an opaque token used to represent a cursor for pagination.
 type Cursor string
@@ -144,7 +263,7 @@ type Meta struct {
 
 	// additionalFields are any fields present in the Meta that are not
 	// otherwise defined in the protocol. Access via GetAdditionalFields(),
-	// SetAdditionalFields(), GetAdditionalField(), or SetAdditionalField().
+	// GetAdditionalField() or SetAdditionalField().
 	additionalFields map[string]any
 
 	// mu protects additionalFields from concurrent access
@@ -153,19 +272,14 @@ type Meta struct {
 
 func (m *Meta) MarshalJSON() ([]byte, error) {
 	raw := make(map[string]any)
-	if m.ProgressToken != nil {
+	if !m.ProgressToken.IsZero() {
 		raw["progressToken"] = m.ProgressToken
 	}
-	if m.additionalFields != nil {
-		// Copy the map while holding a read lock to avoid concurrent map iteration/write issues
-		m.mu.RLock()
-		fieldsCopy := make(map[string]any, len(m.additionalFields))
-		for k, v := range m.additionalFields {
-			fieldsCopy[k] = v
-		}
-		m.mu.RUnlock()
-		maps.Copy(raw, fieldsCopy)
-	}
+	// Copy the fields while holding a read lock, as SetAdditionalField may
+	// create or update the map concurrently
+	m.mu.RLock()
+	maps.Copy(raw, m.additionalFields)
+	m.mu.RUnlock()
 
 	return json.Marshal(raw)
 }
@@ -175,7 +289,13 @@ func (m *Meta) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	m.ProgressToken = raw["progressToken"]
+	var token struct {
+		ProgressToken ProgressToken `json:"progressToken"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return err
+	}
+	m.ProgressToken = token.ProgressToken
 	delete(raw, "progressToken")
 	m.mu.Lock()
 	m.additionalFields = raw
@@ -183,11 +303,11 @@ func (m *Meta) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// NewMetaFromMap returns the Meta of fields decoded from JSON. A progress
+// token that is neither a string nor an integer is dropped.
 func NewMetaFromMap(m map[string]any) *Meta {
-	progressToken := m["progressToken"]
-	if progressToken != nil {
-		delete(m, "progressToken")
-	}
+	progressToken, _ := progressTokenFromValue(m["progressToken"])
+	delete(m, "progressToken")
 
 	// Create a copy of the map to avoid sharing the same map reference
 	fieldsCopy := make(map[string]any, len(m))
@@ -219,6 +339,9 @@ func (m *Meta) GetAdditionalFields() map[string]any {
 
 // SetAdditionalFields sets all additional fields, replacing any existing fields.
 // This method is thread-safe.
+//
+// Deprecated: Use SetAdditionalField, which updates a single field and keeps
+// the others.
 func (m *Meta) SetAdditionalFields(fields map[string]any) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -233,7 +356,8 @@ func (m *Meta) SetAdditionalFields(fields map[string]any) {
 	}
 }
 
-// SetAdditionalField safely sets a value in additional fields.
+// SetAdditionalField safely sets a value in additional fields, leaving the
+// other fields unchanged.
 // This method is thread-safe and should be used when modifying Meta concurrently.
 func (m *Meta) SetAdditionalField(key string, value any) {
 	m.mu.Lock()
@@ -256,6 +380,97 @@ func (m *Meta) GetAdditionalField(key string) (any, bool) {
 	return value, ok
 }
 
+const (
+	// HeaderProgressToken is the HTTP header carrying the progress token of a
+	// Meta.
+	HeaderProgressToken = "X-Mcp-Progress"
+	// HeaderMetaPrefix prefixes the HTTP headers carrying the additional
+	// fields of a Meta, followed by the name of the field.
+	HeaderMetaPrefix = "X-Mcp-Meta-"
+)
+
+// ToHTTPHeader returns the value of the HTTP header key for the field of the
+// Meta it carries: the progress token for HeaderProgressToken, or the
+// additional field named after HeaderMetaPrefix. Strings are returned as is
+// and other values as JSON. Header keys are case-insensitive. It returns an
+// empty string if the field is not set or key carries no field.
+func (m *Meta) ToHTTPHeader(key string) string {
+	var value any
+	if strings.EqualFold(key, HeaderProgressToken) {
+		value = m.ProgressToken.Value()
+	} else if field, ok := metaHeaderField(key); ok {
+		value, _ = m.GetAdditionalField(field)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+// FromHTTPHeader sets the field of the Meta carried by the HTTP header key
+// to value, as ToHTTPHeader serializes it. A progress token is an integer if
+// value is one, or a string otherwise; additional fields are set to value as
+// a string. It returns an error if key carries no field or value is empty.
+func (m *Meta) FromHTTPHeader(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: empty value of header %s", ErrInvalidParams, key)
+	}
+	if strings.EqualFold(key, HeaderProgressToken) {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			m.ProgressToken = NewIntProgressToken(n)
+		} else {
+			m.ProgressToken = NewStringProgressToken(value)
+		}
+		return nil
+	}
+	field, ok := metaHeaderField(key)
+	if !ok {
+		return fmt.Errorf("%w: header %s carries no meta field", ErrInvalidParams, key)
+	}
+	m.SetAdditionalField(field, value)
+	return nil
+}
+
+// metaHeaderField returns the name of the additional field carried by the
+// HTTP header key.
+func metaHeaderField(key string) (string, bool) {
+	if len(key) <= len(HeaderMetaPrefix) || !strings.EqualFold(key[:len(HeaderMetaPrefix)], HeaderMetaPrefix) {
+		return "", false
+	}
+	return key[len(HeaderMetaPrefix):], true
+}
+
+// metaWithField returns a copy of m, which may be nil, with key set to value.
+// Copying keeps values that share a Meta, such as copies of a Tool, apart.
+func metaWithField(m *Meta, key string, value any) *Meta {
+	meta := &Meta{}
+	if m != nil {
+		meta.ProgressToken = m.ProgressToken
+		for k, v := range m.GetAdditionalFields() {
+			meta.SetAdditionalField(k, v)
+		}
+	}
+	meta.SetAdditionalField(key, value)
+	return meta
+}
+
+// metaField returns the value of key in m, which may be nil.
+func metaField(m *Meta, key string) (any, bool) {
+	if m == nil {
+		return nil, false
+	}
+	return m.GetAdditionalField(key)
+}
+
 type Request struct {
 	Method string        `json:"method"`
 	Params RequestParams `json:"params,omitempty"`
@@ -477,6 +692,13 @@ const (
 const (
 	// RESOURCE_NOT_FOUND indicates a requested resource was not found.
 	RESOURCE_NOT_FOUND = -32002
+
+	// TOO_MANY_REQUESTS indicates the client exceeded the rate limit of the server.
+	TOO_MANY_REQUESTS = -32429
+
+	// SERVICE_UNAVAILABLE indicates the server cannot handle the request for
+	// now, for example because a circuit breaker is open.
+	SERVICE_UNAVAILABLE = -32000
 )
 
 /* Empty result */
@@ -605,6 +827,8 @@ type ServerCapabilities struct {
 	Elicitation *struct{} `json:"elicitation,omitempty"`
 	// Present if the server supports roots requests to the client.
 	Roots *struct{} `json:"roots,omitempty"`
+	// Present if the server supports argument autocompletion suggestions.
+	Completions *struct{} `json:"completions,omitempty"`
 }
 
 // Implementation describes the name and version of an MCP implementation.
@@ -658,6 +882,11 @@ type PaginatedParams struct {
 	// An opaque token representing the current pagination position.
 	// If provided, the server should return results starting after this cursor.
 	Cursor Cursor `json:"cursor,omitempty"`
+	// If true, deprecated tools are left out of a tools/list response. This is
+	// an extension of this library, ignored by other list requests.
+	ExcludeDeprecated bool `json:"excludeDeprecated,omitempty"`
+	// Meta is a metadata object that is reserved by MCP for storing additional information.
+	Meta *Meta `json:"_meta,omitempty"`
 }
 
 type PaginatedResult struct {
@@ -712,6 +941,61 @@ type ReadResourceParams struct {
 	URI string `json:"uri"`
 	// Arguments to pass to the resource handler
 	Arguments map[string]any `json:"arguments,omitempty"`
+	// Accept tells in which form the client prefers to receive the contents.
+	// It defaults to ContentTypePreferenceAuto.
+	Accept ContentTypePreference `json:"accept,omitempty"`
+}
+
+// ContentTypePreference is the form in which a client prefers to receive the
+// contents of a resource.
+type ContentTypePreference string
+
+const (
+	// ContentTypePreferenceAuto asks for text contents if they are valid
+	// UTF-8 text, and blob contents otherwise.
+	ContentTypePreferenceAuto ContentTypePreference = "auto"
+	// ContentTypePreferenceText asks for text contents whenever they are
+	// valid UTF-8 text.
+	ContentTypePreferenceText ContentTypePreference = "text"
+	// ContentTypePreferenceBlob asks for base64 encoded blob contents.
+	ContentTypePreferenceBlob ContentTypePreference = "blob"
+)
+
+// NegotiateResourceContents converts contents to the form asked for by
+// preference. Blob contents are converted to text contents only if they
+// decode to valid UTF-8 without NUL bytes, so binary data stays a blob.
+// Contents of other types are returned unchanged.
+func NegotiateResourceContents(contents ResourceContents, preference ContentTypePreference) ResourceContents {
+	switch c := contents.(type) {
+	case TextResourceContents:
+		if preference == ContentTypePreferenceBlob || !isResourceText(c.Text) {
+			return BlobResourceContents{
+				Meta:     c.Meta,
+				URI:      c.URI,
+				MIMEType: c.MIMEType,
+				Blob:     base64.StdEncoding.EncodeToString([]byte(c.Text)),
+			}
+		}
+	case BlobResourceContents:
+		if preference == ContentTypePreferenceBlob {
+			return c
+		}
+		data, err := base64.StdEncoding.DecodeString(c.Blob)
+		if err == nil && isResourceText(string(data)) {
+			return TextResourceContents{
+				Meta:     c.Meta,
+				URI:      c.URI,
+				MIMEType: c.MIMEType,
+				Text:     string(data),
+			}
+		}
+	}
+	return contents
+}
+
+// isResourceText reports whether data can be sent as text resource contents.
+func isResourceText(data string) bool {
+	return utf8.ValidString(data) && !strings.ContainsRune(data, 0)
 }
 
 // ReadResourceResult is the server's response to a resources/read request
@@ -795,6 +1079,17 @@ func (r Resource) GetName() string {
 	return r.Name
 }
 
+// SetMeta sets key in the _meta of the resource, for example to carry UI
+// hints or versioning information.
+func (r *Resource) SetMeta(key string, value any) {
+	r.Meta = metaWithField(r.Meta, key, value)
+}
+
+// GetMeta returns the value of key in the _meta of the resource.
+func (r Resource) GetMeta(key string) (any, bool) {
+	return metaField(r.Meta, key)
+}
+
 // ResourceTemplate represents a template description for resources available
 // on the server.
 type ResourceTemplate struct {
@@ -844,6 +1139,35 @@ type TextResourceContents struct {
 
 func (TextResourceContents) isResourceContents() {}
 
+// Encoding returns the name of the text encoding of the resource. Text is
+// carried in JSON strings, so this is always "utf-8"; Validate reports text
+// that is not valid UTF-8.
+func (t TextResourceContents) Encoding() string {
+	return "utf-8"
+}
+
+// Validate checks that Text is valid UTF-8 and that MIMEType, if it declares a
+// charset, does not declare one other than UTF-8 (or its ASCII subset).
+func (t TextResourceContents) Validate() error {
+	if !utf8.ValidString(t.Text) {
+		return fmt.Errorf("text of resource %s is not valid %s", t.URI, t.Encoding())
+	}
+
+	if t.MIMEType == "" {
+		return nil
+	}
+	_, params, err := mime.ParseMediaType(t.MIMEType)
+	if err != nil {
+		return nil
+	}
+	switch charset := strings.ToLower(params["charset"]); charset {
+	case "", "utf-8", "utf8", "us-ascii":
+		return nil
+	default:
+		return fmt.Errorf("resource %s declares charset %q but text must be %s", t.URI, charset, t.Encoding())
+	}
+}
+
 type BlobResourceContents struct {
 	// Raw per‑resource metadata; pass‑through as defined by MCP. Not the same as mcp.Meta.
 	// Allows _meta to be used for MCP-UI features for example. Does not assume any specific format.
@@ -858,6 +1182,30 @@ type BlobResourceContents struct {
 
 func (BlobResourceContents) isResourceContents() {}
 
+// mimeSniffLen is the number of bytes http.DetectContentType considers.
+const mimeSniffLen = 512
+
+// DetectMIMEType returns the MIME type of the blob. If MIMEType is set it is
+// returned unchanged; otherwise the type is detected from the first 512 bytes
+// of the decoded content using http.DetectContentType. Blobs that are not
+// valid base64 are reported as "application/octet-stream".
+func (b BlobResourceContents) DetectMIMEType() string {
+	if b.MIMEType != "" {
+		return b.MIMEType
+	}
+
+	// Only decode as much of the blob as is needed for detection
+	encoded := b.Blob
+	if n := base64.StdEncoding.EncodedLen(mimeSniffLen); len(encoded) > n {
+		encoded = encoded[:n]
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return http.DetectContentType(data)
+}
+
 /* Logging */
 
 // SetLevelRequest is a request from the client to the server, to enable or
@@ -1020,6 +1368,43 @@ type SamplingMessage struct {
 	Content any  `json:"content"` // Can be TextContent, ImageContent or AudioContent
 }
 
+// samplingMessageStringLimit is the maximum length in characters of the
+// string of a SamplingMessage.
+const samplingMessageStringLimit = 80
+
+// String returns "role: text" for a text message, truncated with "..." to 80
+// characters, or "role: [type content]" for other content, for debugging.
+func (m SamplingMessage) String() string {
+	var text, contentType string
+	switch c := m.Content.(type) {
+	case TextContent:
+		text, contentType = c.Text, ContentTypeText
+	case *TextContent:
+		text, contentType = c.Text, ContentTypeText
+	case ImageContent, *ImageContent:
+		contentType = ContentTypeImage
+	case AudioContent, *AudioContent:
+		contentType = ContentTypeAudio
+	case map[string]any:
+		// Content decoded from JSON
+		contentType, _ = c["type"].(string)
+		text, _ = c["text"].(string)
+	}
+
+	if contentType != ContentTypeText {
+		if contentType == "" {
+			contentType = "unknown"
+		}
+		return fmt.Sprintf("%s: [%s content]", m.Role, contentType)
+	}
+
+	str := fmt.Sprintf("%s: %s", m.Role, text)
+	if runes := []rune(str); len(runes) > samplingMessageStringLimit {
+		str = string(runes[:samplingMessageStringLimit-3]) + "..."
+	}
+	return str
+}
+
 type Annotations struct {
 	// Describes who the intended customer of this object or data is.
 	//
@@ -1042,6 +1427,26 @@ type Annotated struct {
 	Annotations *Annotations `json:"annotations,omitempty"`
 }
 
+// ContentAnnotations are the annotations of content items, as set with
+// WithContentAnnotations.
+type ContentAnnotations = Annotations
+
+// ContentOption is a function that configures a content item created by
+// NewTextContent, NewImageContent, NewAudioContent, NewResourceLink or
+// NewEmbeddedResource.
+type ContentOption func(*Annotated)
+
+// WithContentAnnotations sets the intended audience and the priority of a
+// content item.
+func WithContentAnnotations(audience []Role, priority float64) ContentOption {
+	return func(a *Annotated) {
+		a.Annotations = &ContentAnnotations{
+			Audience: audience,
+			Priority: priority,
+		}
+	}
+}
+
 type Content interface {
 	isContent()
 }
@@ -1092,6 +1497,8 @@ func (AudioContent) isContent() {}
 // ResourceLink represents a link to a resource that the client can access.
 type ResourceLink struct {
 	Annotated
+	// Meta is a metadata object that is reserved by MCP for storing additional information.
+	Meta *Meta  `json:"_meta,omitempty"`
 	Type string `json:"type"` // Must be "resource_link"
 	// The URI of the resource.
 	URI string `json:"uri"`
@@ -1105,6 +1512,50 @@ type ResourceLink struct {
 
 func (ResourceLink) isContent() {}
 
+// ResourceLinkExpiresKey is the _meta key holding the RFC 3339 expiry time of
+// a resource link.
+const ResourceLinkExpiresKey = "expires"
+
+// Expires returns a copy of the link that expires at t, for example because it
+// points to a pre-signed URL. The expiry is stored in _meta.
+func (l ResourceLink) Expires(t time.Time) ResourceLink {
+	l.Meta = metaWithField(l.Meta, ResourceLinkExpiresKey, t.UTC().Format(time.RFC3339))
+	return l
+}
+
+// ExpiresAt returns the expiry time of the link. The second return value is
+// false if the link has no valid expiry.
+func (l ResourceLink) ExpiresAt() (time.Time, bool) {
+	value, ok := metaField(l.Meta, ResourceLinkExpiresKey)
+	if !ok {
+		return time.Time{}, false
+	}
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// DeduplicateResourceLinks returns links without the links whose URI is the
+// same as that of an earlier link, keeping the order of the links.
+func DeduplicateResourceLinks(links []ResourceLink) []ResourceLink {
+	seen := make(map[string]bool, len(links))
+	deduplicated := make([]ResourceLink, 0, len(links))
+	for _, link := range links {
+		if seen[link.URI] {
+			continue
+		}
+		seen[link.URI] = true
+		deduplicated = append(deduplicated, link)
+	}
+	return deduplicated
+}
+
 // EmbeddedResource represents the contents of a resource, embedded into a prompt or tool call result.
 //
 // It is up to the client how best to render embedded resources for the
@@ -1185,13 +1636,16 @@ type CompleteRequest struct {
 }
 
 type CompleteParams struct {
-	Ref      any `json:"ref"` // Can be PromptReference or ResourceReference
-	Argument struct {
-		// The name of the argument
-		Name string `json:"name"`
-		// The value of the argument to use for completion matching.
-		Value string `json:"value"`
-	} `json:"argument"`
+	Ref      any              `json:"ref"` // Can be PromptReference, ResourceReference or ToolReference
+	Argument CompleteArgument `json:"argument"`
+}
+
+// CompleteArgument is the argument a completion is requested for.
+type CompleteArgument struct {
+	// The name of the argument
+	Name string `json:"name"`
+	// The value of the argument to use for completion matching.
+	Value string `json:"value"`
 }
 
 // CompleteResult is the server's response to a completion/complete request
@@ -1223,6 +1677,21 @@ type PromptReference struct {
 	Name string `json:"name"`
 }
 
+// ToolReference identifies a tool. Completing tool arguments is an extension
+// of this library; other servers may not support it.
+type ToolReference struct {
+	Type string `json:"type"`
+	// The name of the tool
+	Name string `json:"name"`
+}
+
+// Types of the references of completion requests.
+const (
+	RefTypePrompt   = "ref/prompt"
+	RefTypeResource = "ref/resource"
+	RefTypeTool     = "ref/tool"
+)
+
 /* Roots */
 
 // ListRootsRequest is sent from the server to request a list of root URIs from the client. Roots allow
@@ -1323,9 +1792,29 @@ func UnmarshalContent(data []byte) (Content, error) {
 		err := json.Unmarshal(data, &content)
 		return content, err
 	case ContentTypeResource:
-		var content EmbeddedResource
-		err := json.Unmarshal(data, &content)
-		return content, err
+		// ResourceContents is an interface, so the embedded resource is decoded separately
+		var content struct {
+			Annotated
+			Meta     *Meta          `json:"_meta,omitempty"`
+			Type     string         `json:"type"`
+			Resource map[string]any `json:"resource"`
+		}
+		if err := json.Unmarshal(data, &content); err != nil {
+			return nil, err
+		}
+		if content.Resource == nil {
+			return nil, fmt.Errorf("resource is missing")
+		}
+		resource, err := ParseResourceContents(content.Resource)
+		if err != nil {
+			return nil, err
+		}
+		return EmbeddedResource{
+			Annotated: content.Annotated,
+			Meta:      content.Meta,
+			Type:      content.Type,
+			Resource:  resource,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown content type: %s", contentType)
 	}
@@ -1,9 +1,14 @@
 package mcp
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,43 +26,43 @@ func TestMetaMarshalling(t *testing.T) {
 			name:      "empty",
 			json:      "{}",
 			setupMeta: func() *Meta { return &Meta{} },
-			expToken:  nil,
+			expToken:  ProgressToken{},
 			expFields: map[string]any{}, // Unmarshaling creates empty map, not nil
 		},
 		{
 			name:      "empty additional fields",
 			json:      "{}",
 			setupMeta: func() *Meta { m := &Meta{}; m.SetAdditionalFields(map[string]any{}); return m },
-			expToken:  nil,
+			expToken:  ProgressToken{},
 			expFields: map[string]any{},
 		},
 		{
 			name:      "string token only",
 			json:      `{"progressToken":"123"}`,
-			setupMeta: func() *Meta { return &Meta{ProgressToken: "123"} },
-			expToken:  "123",
+			setupMeta: func() *Meta { return &Meta{ProgressToken: NewStringProgressToken("123")} },
+			expToken:  NewStringProgressToken("123"),
 			expFields: map[string]any{}, // Unmarshaling creates empty map, not nil
 		},
 		{
 			name:      "string token only, empty additional fields",
 			json:      `{"progressToken":"123"}`,
-			setupMeta: func() *Meta { m := &Meta{ProgressToken: "123"}; m.SetAdditionalFields(map[string]any{}); return m },
-			expToken:  "123",
+			setupMeta: func() *Meta { m := &Meta{ProgressToken: NewStringProgressToken("123")}; m.SetAdditionalFields(map[string]any{}); return m },
+			expToken:  NewStringProgressToken("123"),
 			expFields: map[string]any{},
 		},
 		{
 			name:      "additional fields only",
 			json:      `{"a":2,"b":"1"}`,
 			setupMeta: func() *Meta { m := &Meta{}; m.SetAdditionalFields(map[string]any{"a": 2, "b": "1"}); return m },
-			expToken:  nil,
+			expToken:  ProgressToken{},
 			// For untyped map, numbers are always float64
 			expFields: map[string]any{"a": float64(2), "b": "1"},
 		},
 		{
 			name:      "progress token and additional fields",
 			json:      `{"a":2,"b":"1","progressToken":"123"}`,
-			setupMeta: func() *Meta { m := &Meta{ProgressToken: "123"}; m.SetAdditionalFields(map[string]any{"a": 2, "b": "1"}); return m },
-			expToken:  "123",
+			setupMeta: func() *Meta { m := &Meta{ProgressToken: NewStringProgressToken("123")}; m.SetAdditionalFields(map[string]any{"a": 2, "b": "1"}); return m },
+			expToken:  NewStringProgressToken("123"),
 			// For untyped map, numbers are always float64
 			expFields: map[string]any{"a": float64(2), "b": "1"},
 		},
@@ -152,6 +157,96 @@ func TestCallToolResultWithResourceLink(t *testing.T) {
 	assert.Equal(t, "application/pdf", resourceLink.MIMEType)
 }
 
+func TestEmbeddedResourceRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource ResourceContents
+	}{
+		{
+			name: "text resource",
+			resource: TextResourceContents{
+				URI:      "file:///example/notes.txt",
+				MIMEType: "text/plain",
+				Text:     "Some notes",
+			},
+		},
+		{
+			name: "blob resource",
+			resource: BlobResourceContents{
+				URI:      "file:///example/image.png",
+				MIMEType: "image/png",
+				Blob:     "aGVsbG8=",
+			},
+		},
+		{
+			name: "resource with meta",
+			resource: TextResourceContents{
+				Meta: map[string]any{"version": "1"},
+				URI:  "file:///example/meta.txt",
+				Text: "With meta",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embedded := NewEmbeddedResource(tt.resource)
+
+			data, err := json.Marshal(embedded)
+			require.NoError(t, err)
+
+			content, err := UnmarshalContent(data)
+			require.NoError(t, err)
+			assert.Equal(t, embedded, content)
+
+			result := NewToolResultResource("embedded", tt.resource)
+			data, err = json.Marshal(result)
+			require.NoError(t, err)
+
+			var unmarshalled CallToolResult
+			require.NoError(t, json.Unmarshal(data, &unmarshalled))
+			require.Len(t, unmarshalled.Content, 2)
+			assert.Equal(t, embedded, unmarshalled.Content[1])
+
+			message := NewPromptMessage(RoleAssistant, embedded)
+			data, err = json.Marshal(message)
+			require.NoError(t, err)
+
+			var unmarshalledMessage PromptMessage
+			require.NoError(t, json.Unmarshal(data, &unmarshalledMessage))
+			assert.Equal(t, message, unmarshalledMessage)
+		})
+	}
+}
+
+func TestParseCallToolResultEmbeddedResource(t *testing.T) {
+	embedded := NewEmbeddedResource(BlobResourceContents{
+		URI:      "file:///example/image.png",
+		MIMEType: "image/png",
+		Blob:     "aGVsbG8=",
+	})
+	embedded.Meta = NewMetaFromMap(map[string]any{"source": "cache"})
+
+	data, err := json.Marshal(&CallToolResult{Content: []Content{embedded}})
+	require.NoError(t, err)
+
+	raw := json.RawMessage(data)
+	result, err := ParseCallToolResult(&raw)
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+
+	parsed, ok := result.Content[0].(EmbeddedResource)
+	require.True(t, ok)
+	assert.Equal(t, embedded.Resource, parsed.Resource)
+	require.NotNil(t, parsed.Meta)
+	assert.Equal(t, "cache", parsed.Meta.GetAdditionalFields()["source"])
+}
+
+func TestUnmarshalEmbeddedResourceMissingResource(t *testing.T) {
+	_, err := UnmarshalContent([]byte(`{"type": "resource"}`))
+	assert.EqualError(t, err, "resource is missing")
+}
+
 func TestResourceContentsMetaField(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -432,3 +527,364 @@ func TestMetaConcurrentAccess(t *testing.T) {
 	wg.Wait()
 	// If we get here without a panic, the concurrent access is safe
 }
+
+func TestBlobResourceContentsDetectMIMEType(t *testing.T) {
+	encode := func(data []byte) string {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	tests := []struct {
+		name     string
+		blob     BlobResourceContents
+		expected string
+	}{
+		{
+			name:     "png header",
+			blob:     BlobResourceContents{Blob: encode([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))},
+			expected: "image/png",
+		},
+		{
+			name:     "jpeg header",
+			blob:     BlobResourceContents{Blob: encode([]byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"))},
+			expected: "image/jpeg",
+		},
+		{
+			name:     "pdf header",
+			blob:     BlobResourceContents{Blob: encode([]byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n"))},
+			expected: "application/pdf",
+		},
+		{
+			name:     "large blob only decodes the prefix",
+			blob:     BlobResourceContents{Blob: encode(append([]byte("%PDF-1.4\n"), make([]byte, 4096)...))},
+			expected: "application/pdf",
+		},
+		{
+			name:     "existing MIME type is kept",
+			blob:     BlobResourceContents{MIMEType: "image/webp", Blob: encode([]byte("%PDF-1.7\n"))},
+			expected: "image/webp",
+		},
+		{
+			name:     "invalid base64",
+			blob:     BlobResourceContents{Blob: "not base64!"},
+			expected: "application/octet-stream",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.blob.DetectMIMEType())
+		})
+	}
+}
+
+func TestTextResourceContentsEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents TextResourceContents
+		wantErr  string
+	}{
+		{
+			name:     "valid utf-8",
+			contents: TextResourceContents{URI: "file:///a.txt", Text: "héllo wörld ✓"},
+		},
+		{
+			name:     "utf-8 charset",
+			contents: TextResourceContents{URI: "file:///a.txt", MIMEType: "text/plain; charset=UTF-8", Text: "hello"},
+		},
+		{
+			name:     "invalid byte sequence",
+			contents: TextResourceContents{URI: "file:///b.txt", Text: "bad \xff\xfe bytes"},
+			wantErr:  "text of resource file:///b.txt is not valid utf-8",
+		},
+		{
+			name:     "non utf-8 charset",
+			contents: TextResourceContents{URI: "file:///c.txt", MIMEType: "text/plain; charset=ISO-8859-1", Text: "hello"},
+			wantErr:  `resource file:///c.txt declares charset "iso-8859-1" but text must be utf-8`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, "utf-8", tt.contents.Encoding())
+
+			err := tt.contents.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNegotiateResourceContents(t *testing.T) {
+	encode := func(data string) string {
+		return base64.StdEncoding.EncodeToString([]byte(data))
+	}
+	text := TextResourceContents{URI: "file:///a.txt", MIMEType: "text/plain", Text: "héllo"}
+	textBlob := BlobResourceContents{URI: "file:///a.txt", MIMEType: "text/plain", Blob: encode("héllo")}
+	binaryBlob := BlobResourceContents{URI: "file:///a.png", MIMEType: "image/png", Blob: encode("\x89PNG\r\n\x1a\n\x00")}
+	nulBlob := BlobResourceContents{URI: "file:///a.bin", Blob: encode("a\x00b")}
+
+	tests := []struct {
+		name       string
+		contents   ResourceContents
+		preference ContentTypePreference
+		expected   ResourceContents
+	}{
+		{name: "auto keeps text", contents: text, preference: ContentTypePreferenceAuto, expected: text},
+		{name: "default converts utf-8 blob", contents: textBlob, expected: text},
+		{name: "auto converts utf-8 blob", contents: textBlob, preference: ContentTypePreferenceAuto, expected: text},
+		{name: "auto keeps binary blob", contents: binaryBlob, preference: ContentTypePreferenceAuto, expected: binaryBlob},
+		{name: "auto keeps blob with NUL bytes", contents: nulBlob, preference: ContentTypePreferenceAuto, expected: nulBlob},
+		{name: "text converts utf-8 blob", contents: textBlob, preference: ContentTypePreferenceText, expected: text},
+		{name: "text keeps binary blob", contents: binaryBlob, preference: ContentTypePreferenceText, expected: binaryBlob},
+		{name: "blob converts text", contents: text, preference: ContentTypePreferenceBlob, expected: textBlob},
+		{name: "blob keeps blob", contents: textBlob, preference: ContentTypePreferenceBlob, expected: textBlob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NegotiateResourceContents(tt.contents, tt.preference))
+		})
+	}
+}
+
+func TestResourceLinkExpires(t *testing.T) {
+	link := NewResourceLink("https://example.com/signed", "report", "Signed report", "application/pdf")
+	_, ok := link.ExpiresAt()
+	assert.False(t, ok)
+
+	expiry := time.Date(2030, time.January, 2, 15, 4, 5, 0, time.UTC)
+	expiring := link.Expires(expiry)
+	assert.Nil(t, link.Meta, "Expires must not modify the original link")
+
+	data, err := json.Marshal(expiring)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"_meta":{"expires":"2030-01-02T15:04:05Z"}`)
+
+	var decoded ResourceLink
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	got, ok := decoded.ExpiresAt()
+	require.True(t, ok)
+	assert.True(t, expiry.Equal(got))
+
+	content, err := UnmarshalContent(data)
+	require.NoError(t, err)
+	got, ok = content.(ResourceLink).ExpiresAt()
+	require.True(t, ok)
+	assert.True(t, expiry.Equal(got))
+
+	var contentMap map[string]any
+	require.NoError(t, json.Unmarshal(data, &contentMap))
+	content, err = ParseContent(contentMap)
+	require.NoError(t, err)
+	got, ok = content.(ResourceLink).ExpiresAt()
+	require.True(t, ok)
+	assert.True(t, expiry.Equal(got))
+
+	expired := link.Expires(time.Now().Add(-time.Minute))
+	expiresAt, ok := expired.ExpiresAt()
+	require.True(t, ok)
+	assert.True(t, time.Now().After(expiresAt))
+
+	invalid := link
+	invalid.Meta = NewMetaFromMap(map[string]any{ResourceLinkExpiresKey: "tomorrow"})
+	_, ok = invalid.ExpiresAt()
+	assert.False(t, ok)
+}
+
+func TestSamplingMessage_String(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	tests := []struct {
+		name     string
+		message  SamplingMessage
+		expected string
+	}{
+		{
+			name:     "text",
+			message:  SamplingMessage{Role: RoleUser, Content: NewTextContent("What is the capital of France?")},
+			expected: "user: What is the capital of France?",
+		},
+		{
+			name:     "image",
+			message:  SamplingMessage{Role: RoleAssistant, Content: NewImageContent("data", "image/png")},
+			expected: "assistant: [image content]",
+		},
+		{
+			name:     "audio pointer",
+			message:  SamplingMessage{Role: RoleUser, Content: &AudioContent{Data: "data", MIMEType: "audio/wav"}},
+			expected: "user: [audio content]",
+		},
+		{
+			name:     "long text",
+			message:  SamplingMessage{Role: RoleUser, Content: NewTextContent(long)},
+			expected: "user: " + long[:71] + "...",
+		},
+		{
+			name:     "decoded text",
+			message:  SamplingMessage{Role: RoleUser, Content: map[string]any{"type": "text", "text": "hi"}},
+			expected: "user: hi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.message.String())
+			assert.LessOrEqual(t, len(tt.message.String()), 80)
+		})
+	}
+}
+
+func TestMeta_HTTPHeader(t *testing.T) {
+	t.Run("string progress token", func(t *testing.T) {
+		meta := &Meta{ProgressToken: NewStringProgressToken("abc-123")}
+		value := meta.ToHTTPHeader("x-mcp-progress")
+		assert.Equal(t, "abc-123", value)
+
+		decoded := &Meta{}
+		require.NoError(t, decoded.FromHTTPHeader("x-mcp-progress", value))
+		assert.Equal(t, NewStringProgressToken("abc-123"), decoded.ProgressToken)
+	})
+
+	t.Run("integer progress token", func(t *testing.T) {
+		meta := &Meta{ProgressToken: NewIntProgressToken(42)}
+		value := meta.ToHTTPHeader(HeaderProgressToken)
+		assert.Equal(t, "42", value)
+
+		decoded := &Meta{}
+		require.NoError(t, decoded.FromHTTPHeader(HeaderProgressToken, value))
+		assert.Equal(t, NewIntProgressToken(42), decoded.ProgressToken)
+	})
+
+	t.Run("additional fields", func(t *testing.T) {
+		meta := &Meta{}
+		meta.SetAdditionalField("traceId", "t-1")
+		meta.SetAdditionalField("attempt", 2)
+		assert.Equal(t, "t-1", meta.ToHTTPHeader("X-Mcp-Meta-traceId"))
+		assert.Equal(t, "2", meta.ToHTTPHeader("x-mcp-meta-attempt"))
+		assert.Empty(t, meta.ToHTTPHeader("X-Mcp-Meta-missing"))
+
+		decoded := &Meta{}
+		require.NoError(t, decoded.FromHTTPHeader("X-Mcp-Meta-traceId", "t-1"))
+		value, ok := decoded.GetAdditionalField("traceId")
+		require.True(t, ok)
+		assert.Equal(t, "t-1", value)
+	})
+
+	t.Run("unset or unknown fields", func(t *testing.T) {
+		meta := &Meta{}
+		assert.Empty(t, meta.ToHTTPHeader(HeaderProgressToken))
+		assert.Empty(t, meta.ToHTTPHeader("Content-Type"))
+
+		assert.ErrorIs(t, meta.FromHTTPHeader("Content-Type", "text/plain"), ErrInvalidParams)
+		assert.ErrorIs(t, meta.FromHTTPHeader(HeaderProgressToken, ""), ErrInvalidParams)
+		assert.ErrorIs(t, meta.FromHTTPHeader(HeaderMetaPrefix, "x"), ErrInvalidParams)
+	})
+}
+
+func TestProgressTokenJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		token ProgressToken
+	}{
+		{name: "string", json: `"abc"`, token: NewStringProgressToken("abc")},
+		{name: "empty string", json: `""`, token: NewStringProgressToken("")},
+		{name: "integer", json: `42`, token: NewIntProgressToken(42)},
+		{name: "zero", json: `0`, token: NewIntProgressToken(0)},
+		{name: "max int64", json: `9223372036854775807`, token: NewIntProgressToken(math.MaxInt64)},
+		{name: "min int64", json: `-9223372036854775808`, token: NewIntProgressToken(math.MinInt64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var token ProgressToken
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &token))
+			assert.Equal(t, tt.token, token)
+			assert.False(t, token.IsZero())
+
+			data, err := json.Marshal(token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.json, string(data))
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		var token ProgressToken
+		require.NoError(t, json.Unmarshal([]byte(`null`), &token))
+		assert.True(t, token.IsZero())
+	})
+
+	for _, invalid := range []string{`9223372036854775808`, `-9223372036854775809`, `1.5`, `true`, `{}`, `["a"]`} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			var token ProgressToken
+			assert.Error(t, json.Unmarshal([]byte(invalid), &token))
+		})
+	}
+
+	t.Run("string and integer tokens differ", func(t *testing.T) {
+		assert.NotEqual(t, NewStringProgressToken("1"), NewIntProgressToken(1))
+		assert.Equal(t, "1", NewStringProgressToken("1").String())
+		assert.Equal(t, "1", NewIntProgressToken(1).String())
+	})
+
+	t.Run("meta", func(t *testing.T) {
+		var meta Meta
+		require.NoError(t, json.Unmarshal([]byte(`{"progressToken": 9007199254740993}`), &meta))
+		assert.Equal(t, NewIntProgressToken(9007199254740993), meta.ProgressToken)
+		assert.Error(t, json.Unmarshal([]byte(`{"progressToken": 1.5}`), &meta))
+
+		fromMap := NewMetaFromMap(map[string]any{"progressToken": float64(7)})
+		assert.Equal(t, NewIntProgressToken(7), fromMap.ProgressToken)
+	})
+}
+
+func TestDeduplicateResourceLinks(t *testing.T) {
+	links := []ResourceLink{
+		NewResourceLink("file:///a.txt", "a", "first a", "text/plain"),
+		NewResourceLink("file:///b.txt", "b", "first b", "text/plain"),
+		NewResourceLink("file:///a.txt", "a", "second a", "text/plain"),
+		NewResourceLink("file:///c.txt", "c", "first c", "text/plain"),
+		NewResourceLink("file:///b.txt", "b", "second b", "text/plain"),
+	}
+
+	deduplicated := DeduplicateResourceLinks(links)
+	require.Len(t, deduplicated, 3)
+	assert.Equal(t, []string{"first a", "first b", "first c"}, []string{
+		deduplicated[0].Description, deduplicated[1].Description, deduplicated[2].Description,
+	})
+	assert.Len(t, links, 5, "the input must not be modified")
+
+	assert.Empty(t, DeduplicateResourceLinks(nil))
+}
+
+func TestMeta_SetAdditionalField(t *testing.T) {
+	meta := &Meta{}
+
+	// Setting fields of an empty Meta is safe while it is marshaled
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			meta.SetAdditionalField(fmt.Sprintf("key-%d", id), id)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := json.Marshal(meta)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, meta.GetAdditionalFields(), 10)
+
+	// Only the given field is updated
+	meta.SetAdditionalField("key-3", "updated")
+	value, ok := meta.GetAdditionalField("key-3")
+	assert.True(t, ok)
+	assert.Equal(t, "updated", value)
+	value, ok = meta.GetAdditionalField("key-4")
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yosida95/uritemplate/v3"
+)
+
+// URITemplateMatcher matches URIs against many URI templates at once, such as
+// the resource templates of a server. Templates are indexed by their literal
+// prefix, the text before their first expression, so matching a URI only
+// tries the templates whose prefix starts the URI: its cost grows with the
+// length of the URI rather than with the number of templates.
+type URITemplateMatcher struct {
+	templates []*uritemplate.Template
+	root      *prefixNode
+}
+
+// prefixNode is a node of the trie of the literal prefixes of the templates
+// of a URITemplateMatcher.
+type prefixNode struct {
+	children map[byte]*prefixNode
+	// Indexes of the templates whose prefix ends at the node, in order
+	templates []int
+}
+
+// NewURITemplateMatcher compiles templates into a URITemplateMatcher. It
+// returns an error if a template is invalid.
+func NewURITemplateMatcher(templates []string) (*URITemplateMatcher, error) {
+	m := &URITemplateMatcher{
+		templates: make([]*uritemplate.Template, len(templates)),
+		root:      &prefixNode{},
+	}
+	for i, raw := range templates {
+		template, err := uritemplate.New(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI template %q: %w", raw, err)
+		}
+		template.Regexp() // Compile the template ahead of matching
+		m.templates[i] = template
+
+		prefix, _, _ := strings.Cut(raw, "{")
+		node := m.root
+		for j := 0; j < len(prefix); j++ {
+			child, ok := node.children[prefix[j]]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[byte]*prefixNode)
+				}
+				child = &prefixNode{}
+				node.children[prefix[j]] = child
+			}
+			node = child
+		}
+		node.templates = append(node.templates, i)
+	}
+	return m, nil
+}
+
+// Match returns the index in the templates of the matcher of the template
+// matching uri, with the values of its variables. When several templates
+// match, the one with the longest literal prefix wins, then the first one.
+// Variables with several values, such as exploded lists, have their values
+// joined with commas.
+func (m *URITemplateMatcher) Match(uri string) (templateIndex int, variables map[string]string, ok bool) {
+	// Collect the nodes of the prefixes of uri, shortest first
+	nodes := []*prefixNode{m.root}
+	node := m.root
+	for i := 0; i < len(uri); i++ {
+		node = node.children[uri[i]]
+		if node == nil {
+			break
+		}
+		nodes = append(nodes, node)
+	}
+
+	for i := len(nodes) - 1; i >= 0; i-- {
+		for _, index := range nodes[i].templates {
+			template := m.templates[index]
+			if !template.Regexp().MatchString(uri) {
+				continue
+			}
+			values := template.Match(uri)
+			variables = make(map[string]string, len(values))
+			for name, value := range values {
+				variables[name] = strings.Join(value.V, ",")
+			}
+			return index, variables, true
+		}
+	}
+	return -1, nil, false
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURITemplateMatcher(t *testing.T) {
+	var templates []string
+	for i := 0; i < 98; i++ {
+		templates = append(templates, fmt.Sprintf("service%d://items/{id}", i))
+	}
+	templates = append(templates,
+		"file:///{+path}",
+		"file:///docs/{name}.md",
+	)
+	require.Len(t, templates, 100)
+
+	matcher, err := NewURITemplateMatcher(templates)
+	require.NoError(t, err)
+
+	tests := []struct {
+		uri       string
+		index     int
+		variables map[string]string
+	}{
+		{uri: "service0://items/a", index: 0, variables: map[string]string{"id": "a"}},
+		{uri: "service1://items/b", index: 1, variables: map[string]string{"id": "b"}},
+		{uri: "service10://items/c", index: 10, variables: map[string]string{"id": "c"}},
+		{uri: "service42://items/42", index: 42, variables: map[string]string{"id": "42"}},
+		{uri: "service50://items/x%20y", index: 50, variables: map[string]string{"id": "x y"}},
+		{uri: "service97://items/last", index: 97, variables: map[string]string{"id": "last"}},
+		{uri: "service9://items/nine", index: 9, variables: map[string]string{"id": "nine"}},
+		{uri: "file:///etc/hosts", index: 98, variables: map[string]string{"path": "etc/hosts"}},
+		// The template with the longest literal prefix wins
+		{uri: "file:///docs/readme.md", index: 99, variables: map[string]string{"name": "readme"}},
+		{uri: "file:///docs/sub/readme.md", index: 98, variables: map[string]string{"path": "docs/sub/readme.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			index, variables, ok := matcher.Match(tt.uri)
+			require.True(t, ok)
+			assert.Equal(t, tt.index, index)
+			assert.Equal(t, tt.variables, variables)
+		})
+	}
+
+	for _, uri := range []string{"service98://items/a", "service1://other/a", "http://example.com", ""} {
+		index, variables, ok := matcher.Match(uri)
+		assert.False(t, ok, uri)
+		assert.Equal(t, -1, index)
+		assert.Nil(t, variables)
+	}
+}
+
+func TestURITemplateMatcher_FirstTemplateWins(t *testing.T) {
+	matcher, err := NewURITemplateMatcher([]string{"db://{table}", "db://{name}", "db://fixed"})
+	require.NoError(t, err)
+
+	index, variables, ok := matcher.Match("db://users")
+	require.True(t, ok)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, map[string]string{"table": "users"}, variables)
+
+	index, _, ok = matcher.Match("db://fixed")
+	require.True(t, ok)
+	assert.Equal(t, 2, index)
+}
+
+func TestNewURITemplateMatcher_InvalidTemplate(t *testing.T) {
+	_, err := NewURITemplateMatcher([]string{"file:///{path"})
+	assert.ErrorContains(t, err, "invalid URI template")
+}
@@ -1,8 +1,13 @@
 package mcp
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cast"
 )
@@ -222,49 +227,134 @@ func NewPromptMessage(role Role, content Content) PromptMessage {
 
 // NewTextContent
 // Helper function to create a new TextContent
-func NewTextContent(text string) TextContent {
-	return TextContent{
+func NewTextContent(text string, opts ...ContentOption) TextContent {
+	content := TextContent{
 		Type: ContentTypeText,
 		Text: text,
 	}
+	applyContentOptions(&content.Annotated, opts)
+	return content
 }
 
 // NewImageContent
 // Helper function to create a new ImageContent
-func NewImageContent(data, mimeType string) ImageContent {
-	return ImageContent{
+func NewImageContent(data, mimeType string, opts ...ContentOption) ImageContent {
+	content := ImageContent{
 		Type:     ContentTypeImage,
 		Data:     data,
 		MIMEType: mimeType,
 	}
+	applyContentOptions(&content.Annotated, opts)
+	return content
+}
+
+// NewImageContentFromBytes creates a new ImageContent from raw image bytes,
+// base64-encoding them
+func NewImageContentFromBytes(data []byte, mimeType string) ImageContent {
+	return NewImageContent(base64.StdEncoding.EncodeToString(data), mimeType)
+}
+
+// NewImageContentFromFile reads an image file and creates a new ImageContent
+// from it. The MIME type is inferred from the file extension.
+func NewImageContentFromFile(path string) (ImageContent, error) {
+	mimeType, ok := imageMIMETypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return ImageContent{}, fmt.Errorf("cannot infer image MIME type for %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ImageContent{}, fmt.Errorf("failed to read image file: %w", err)
+	}
+
+	return NewImageContentFromBytes(data, mimeType), nil
+}
+
+// imageMIMETypes maps file extensions to the image MIME types inferred by
+// NewImageContentFromFile
+var imageMIMETypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".bmp":  "image/bmp",
+	".svg":  "image/svg+xml",
 }
 
 // Helper function to create a new AudioContent
-func NewAudioContent(data, mimeType string) AudioContent {
-	return AudioContent{
+func NewAudioContent(data, mimeType string, opts ...ContentOption) AudioContent {
+	content := AudioContent{
 		Type:     ContentTypeAudio,
 		Data:     data,
 		MIMEType: mimeType,
 	}
+	applyContentOptions(&content.Annotated, opts)
+	return content
+}
+
+// NewAudioContentFromBytes creates a new AudioContent from raw audio bytes,
+// base64-encoding them
+func NewAudioContentFromBytes(data []byte, mimeType string) AudioContent {
+	return NewAudioContent(base64.StdEncoding.EncodeToString(data), mimeType)
+}
+
+// NewAudioContentFromFile reads an audio file and creates a new AudioContent
+// from it. The MIME type is inferred from the file extension.
+func NewAudioContentFromFile(path string) (AudioContent, error) {
+	mimeType, ok := audioMIMETypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return AudioContent{}, fmt.Errorf("cannot infer audio MIME type for %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AudioContent{}, fmt.Errorf("failed to read audio file: %w", err)
+	}
+
+	return NewAudioContentFromBytes(data, mimeType), nil
+}
+
+// audioMIMETypes maps file extensions to the audio MIME types inferred by
+// NewAudioContentFromFile
+var audioMIMETypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".flac": "audio/flac",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".webm": "audio/webm",
 }
 
 // Helper function to create a new ResourceLink
-func NewResourceLink(uri, name, description, mimeType string) ResourceLink {
-	return ResourceLink{
+func NewResourceLink(uri, name, description, mimeType string, opts ...ContentOption) ResourceLink {
+	content := ResourceLink{
 		Type:        ContentTypeLink,
 		URI:         uri,
 		Name:        name,
 		Description: description,
 		MIMEType:    mimeType,
 	}
+	applyContentOptions(&content.Annotated, opts)
+	return content
 }
 
 // Helper function to create a new EmbeddedResource
-func NewEmbeddedResource(resource ResourceContents) EmbeddedResource {
-	return EmbeddedResource{
+func NewEmbeddedResource(resource ResourceContents, opts ...ContentOption) EmbeddedResource {
+	content := EmbeddedResource{
 		Type:     ContentTypeResource,
 		Resource: resource,
 	}
+	applyContentOptions(&content.Annotated, opts)
+	return content
+}
+
+func applyContentOptions(annotated *Annotated, opts []ContentOption) {
+	for _, opt := range opts {
+		opt(annotated)
+	}
 }
 
 // NewToolResultText creates a new CallToolResult with a text content
@@ -335,6 +425,12 @@ func NewToolResultStructuredOnly(structured any) *CallToolResult {
 	}
 }
 
+// NewStructuredToolResult creates a new CallToolResult with data as structured
+// content and its JSON encoding as text content for backwards compatibility.
+func NewStructuredToolResult(data map[string]any) *CallToolResult {
+	return NewToolResultStructuredOnly(data)
+}
+
 // NewToolResultImage creates a new CallToolResult with both text and image content
 func NewToolResultImage(text, imageData, mimeType string) *CallToolResult {
 	return &CallToolResult{
@@ -611,6 +707,9 @@ func ParseContent(contentMap map[string]any) (Content, error) {
 		}
 		c := NewResourceLink(uri, name, description, mimeType)
 		c.Annotations = annotations
+		if metaMap := ExtractMap(contentMap, "_meta"); metaMap != nil {
+			c.Meta = NewMetaFromMap(metaMap)
+		}
 		return c, nil
 
 	case ContentTypeResource:
@@ -626,6 +725,9 @@ func ParseContent(contentMap map[string]any) (Content, error) {
 
 		c := NewEmbeddedResource(resourceContents)
 		c.Annotations = annotations
+		if metaMap := ExtractMap(contentMap, "_meta"); metaMap != nil {
+			c.Meta = NewMetaFromMap(metaMap)
+		}
 		return c, nil
 	}
 
@@ -794,21 +896,40 @@ func ParseResourceContents(contentMap map[string]any) (ResourceContents, error)
 	return nil, fmt.Errorf("unsupported resource type")
 }
 
+// ParseResourceContentsJSON parses resource contents from their JSON
+// encoding. Unlike decoding into a map[string]any for ParseResourceContents,
+// numbers, such as the ones in _meta, are kept as json.Number, so that large
+// integers do not lose precision.
+func ParseResourceContentsJSON(raw json.RawMessage) (ResourceContents, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var content any
+	if err := decoder.Decode(&content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource contents: %w", err)
+	}
+	contentMap, ok := content.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("content is not an object")
+	}
+	return ParseResourceContents(contentMap)
+}
+
 func ParseReadResourceResult(rawMessage *json.RawMessage) (*ReadResourceResult, error) {
 	if rawMessage == nil {
 		return nil, fmt.Errorf("response is nil")
 	}
 
-	var jsonContent map[string]any
+	var jsonContent map[string]json.RawMessage
 	if err := json.Unmarshal(*rawMessage, &jsonContent); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	var result ReadResourceResult
 
-	meta, ok := jsonContent["_meta"]
-	if ok {
-		if metaMap, ok := meta.(map[string]any); ok {
+	if meta, ok := jsonContent["_meta"]; ok {
+		var metaMap map[string]any
+		if err := json.Unmarshal(meta, &metaMap); err == nil && metaMap != nil {
 			result.Meta = NewMetaFromMap(metaMap)
 		}
 	}
@@ -818,20 +939,14 @@ func ParseReadResourceResult(rawMessage *json.RawMessage) (*ReadResourceResult,
 		return nil, fmt.Errorf("contents is missing")
 	}
 
-	contentArr, ok := contents.([]any)
-	if !ok {
+	var contentArr []json.RawMessage
+	if err := json.Unmarshal(contents, &contentArr); err != nil {
 		return nil, fmt.Errorf("contents is not an array")
 	}
 
 	for _, content := range contentArr {
-		// Extract content
-		contentMap, ok := content.(map[string]any)
-		if !ok {
-			return nil, fmt.Errorf("content is not an object")
-		}
-
-		// Process content
-		content, err := ParseResourceContents(contentMap)
+		// Process content, keeping the precision of numbers
+		content, err := ParseResourceContentsJSON(content)
 		if err != nil {
 			return nil, err
 		}
@@ -977,3 +1092,149 @@ func GetTextFromContent(content any) string {
 		return fmt.Sprintf("%v", content)
 	}
 }
+
+// ContentEqual reports whether a and b are the same content, comparing the
+// fields of their concrete types. Pointers are compared as the values they
+// point to. The base64 data of images, audio and blobs is compared decoded,
+// so that encoding variations such as padding do not matter; meta and
+// annotations are compared as JSON, so that a number decoded as float64
+// equals the int it was encoded from.
+func ContentEqual(a, b Content) bool {
+	a, b = derefContent(a), derefContent(b)
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case TextContent:
+		b, ok := b.(TextContent)
+		return ok && a.Type == b.Type && a.Text == b.Text &&
+			metaEqual(a.Meta, b.Meta) && annotationsEqual(a.Annotations, b.Annotations)
+	case ImageContent:
+		b, ok := b.(ImageContent)
+		return ok && a.Type == b.Type && a.MIMEType == b.MIMEType && base64Equal(a.Data, b.Data) &&
+			metaEqual(a.Meta, b.Meta) && annotationsEqual(a.Annotations, b.Annotations)
+	case AudioContent:
+		b, ok := b.(AudioContent)
+		return ok && a.Type == b.Type && a.MIMEType == b.MIMEType && base64Equal(a.Data, b.Data) &&
+			metaEqual(a.Meta, b.Meta) && annotationsEqual(a.Annotations, b.Annotations)
+	case ResourceLink:
+		b, ok := b.(ResourceLink)
+		return ok && a.Type == b.Type && a.URI == b.URI && a.Name == b.Name &&
+			a.Description == b.Description && a.MIMEType == b.MIMEType &&
+			metaEqual(a.Meta, b.Meta) && annotationsEqual(a.Annotations, b.Annotations)
+	case EmbeddedResource:
+		b, ok := b.(EmbeddedResource)
+		return ok && a.Type == b.Type && resourceContentsEqual(a.Resource, b.Resource) &&
+			metaEqual(a.Meta, b.Meta) && annotationsEqual(a.Annotations, b.Annotations)
+	default:
+		return jsonEqual(a, b)
+	}
+}
+
+// ContentSliceEqual reports whether a and b have the same length and equal
+// content at each index, as compared by ContentEqual.
+func ContentSliceEqual(a, b []Content) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !ContentEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// derefContent returns the content a pointer to content points to, or
+// content itself.
+func derefContent(content Content) Content {
+	switch c := content.(type) {
+	case *TextContent:
+		if c != nil {
+			return *c
+		}
+	case *ImageContent:
+		if c != nil {
+			return *c
+		}
+	case *AudioContent:
+		if c != nil {
+			return *c
+		}
+	case *ResourceLink:
+		if c != nil {
+			return *c
+		}
+	case *EmbeddedResource:
+		if c != nil {
+			return *c
+		}
+	default:
+		return content
+	}
+	return nil
+}
+
+func resourceContentsEqual(a, b ResourceContents) bool {
+	switch a := a.(type) {
+	case TextResourceContents:
+		b, ok := b.(TextResourceContents)
+		return ok && a.URI == b.URI && a.MIMEType == b.MIMEType && a.Text == b.Text && jsonEqual(a.Meta, b.Meta)
+	case BlobResourceContents:
+		b, ok := b.(BlobResourceContents)
+		return ok && a.URI == b.URI && a.MIMEType == b.MIMEType && base64Equal(a.Blob, b.Blob) && jsonEqual(a.Meta, b.Meta)
+	default:
+		return jsonEqual(a, b)
+	}
+}
+
+// base64Equal reports whether a and b encode the same bytes, in standard or
+// URL-safe base64, padded or not. Strings that are not base64 are compared
+// as is.
+func base64Equal(a, b string) bool {
+	if a == b {
+		return true
+	}
+	decodedA, errA := decodeAnyBase64(a)
+	decodedB, errB := decodeAnyBase64(b)
+	return errA == nil && errB == nil && bytes.Equal(decodedA, decodedB)
+}
+
+func decodeAnyBase64(s string) ([]byte, error) {
+	var err error
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		var decoded []byte
+		if decoded, err = encoding.DecodeString(s); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, err
+}
+
+func metaEqual(a, b *Meta) bool {
+	if a == nil {
+		a = &Meta{}
+	}
+	if b == nil {
+		b = &Meta{}
+	}
+	return jsonEqual(a, b)
+}
+
+func annotationsEqual(a, b *Annotations) bool {
+	if a == nil {
+		a = &Annotations{}
+	}
+	if b == nil {
+		b = &Annotations{}
+	}
+	return jsonEqual(a, b)
+}
+
+// jsonEqual reports whether a and b have the same JSON encoding.
+func jsonEqual(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
@@ -3,6 +3,8 @@ package mcp
 import (
 	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -150,6 +152,62 @@ func TestNewJSONRPCErrorDetails(t *testing.T) {
 
 // Test helper content creation functions
 
+func TestNewImageContentFromBytes(t *testing.T) {
+	result := NewImageContentFromBytes([]byte("hello"), "image/png")
+
+	assert.Equal(t, ContentTypeImage, result.Type)
+	assert.Equal(t, "aGVsbG8=", result.Data)
+	assert.Equal(t, "image/png", result.MIMEType)
+}
+
+func TestNewImageContentFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		file     string
+		mimeType string
+	}{
+		{file: "image.png", mimeType: "image/png"},
+		{file: "photo.JPG", mimeType: "image/jpeg"},
+		{file: "anim.gif", mimeType: "image/gif"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+			result, err := NewImageContentFromFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, ContentTypeImage, result.Type)
+			assert.Equal(t, "aGVsbG8=", result.Data)
+			assert.Equal(t, tt.mimeType, result.MIMEType)
+		})
+	}
+
+	t.Run("unknown extension", func(t *testing.T) {
+		path := filepath.Join(dir, "notes.txt")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+		_, err := NewImageContentFromFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewImageContentFromFile(filepath.Join(dir, "missing.png"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestImageContentUnmarshal(t *testing.T) {
+	data, err := json.Marshal(NewImageContentFromBytes([]byte("hello"), "image/png"))
+	require.NoError(t, err)
+
+	content, err := UnmarshalContent(data)
+	require.NoError(t, err)
+	assert.Equal(t, NewImageContent("aGVsbG8=", "image/png"), content)
+}
+
 func TestNewAudioContent(t *testing.T) {
 	result := NewAudioContent("audiodata", "audio/mp3")
 
@@ -158,6 +216,61 @@ func TestNewAudioContent(t *testing.T) {
 	assert.Equal(t, "audio/mp3", result.MIMEType)
 }
 
+func TestNewAudioContentFromBytes(t *testing.T) {
+	for _, mimeType := range []string{"audio/mpeg", "audio/wav"} {
+		result := NewAudioContentFromBytes([]byte("hello"), mimeType)
+
+		assert.Equal(t, ContentTypeAudio, result.Type)
+		assert.Equal(t, "aGVsbG8=", result.Data)
+		assert.Equal(t, mimeType, result.MIMEType)
+	}
+}
+
+func TestNewAudioContentFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		file     string
+		mimeType string
+	}{
+		{file: "speech.mp3", mimeType: "audio/mpeg"},
+		{file: "preview.WAV", mimeType: "audio/wav"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+			result, err := NewAudioContentFromFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, ContentTypeAudio, result.Type)
+			assert.Equal(t, "aGVsbG8=", result.Data)
+			assert.Equal(t, tt.mimeType, result.MIMEType)
+
+			// Audio content round-trips through the content unmarshaler
+			data, err := json.Marshal(result)
+			require.NoError(t, err)
+			content, err := UnmarshalContent(data)
+			require.NoError(t, err)
+			assert.Equal(t, result, content)
+		})
+	}
+
+	t.Run("unknown extension", func(t *testing.T) {
+		path := filepath.Join(dir, "image.png")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+		_, err := NewAudioContentFromFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewAudioContentFromFile(filepath.Join(dir, "missing.wav"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
 func TestNewResourceLink(t *testing.T) {
 	result := NewResourceLink("file:///test.txt", "test.txt", "A test file", "text/plain")
 
@@ -176,6 +289,27 @@ func TestNewEmbeddedResource(t *testing.T) {
 	assert.Equal(t, resource, result.Resource)
 }
 
+func TestContentAnnotations(t *testing.T) {
+	annotated := []Content{
+		NewTextContent("hello", WithContentAnnotations([]Role{RoleUser}, 0.8)),
+		NewImageContent("aGk=", "image/png", WithContentAnnotations([]Role{RoleUser}, 0.8)),
+		NewAudioContent("aGk=", "audio/wav", WithContentAnnotations([]Role{RoleUser}, 0.8)),
+		NewEmbeddedResource(TextResourceContents{URI: "file:///a.txt", Text: "a"}, WithContentAnnotations([]Role{RoleUser}, 0.8)),
+	}
+	for _, content := range annotated {
+		data, err := json.Marshal(content)
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, map[string]any{"audience": []any{"user"}, "priority": 0.8}, decoded["annotations"], string(data))
+	}
+
+	// Annotations are omitted when not set
+	data, err := json.Marshal(NewTextContent("hello"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"text","text":"hello"}`, string(data))
+}
+
 // Test ParseResourceContents
 
 func TestParseResourceContents(t *testing.T) {
@@ -234,6 +368,41 @@ func TestParseResourceContents(t *testing.T) {
 	})
 }
 
+func TestParseResourceContentsJSON(t *testing.T) {
+	t.Run("keeps integer precision", func(t *testing.T) {
+		result, err := ParseResourceContentsJSON(json.RawMessage(`{
+			"uri": "file:///test",
+			"text": "content",
+			"_meta": {"revision": 9007199254740993}
+		}`))
+		require.NoError(t, err)
+		text, ok := result.(TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, "content", text.Text)
+		assert.Equal(t, json.Number("9007199254740993"), text.Meta["revision"])
+	})
+
+	t.Run("blob", func(t *testing.T) {
+		result, err := ParseResourceContentsJSON(json.RawMessage(`{"uri": "file:///test", "blob": "YmxvYg==", "mimeType": "application/octet-stream"}`))
+		require.NoError(t, err)
+		blob, ok := result.(BlobResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, "YmxvYg==", blob.Blob)
+		assert.Equal(t, "application/octet-stream", blob.MIMEType)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseResourceContentsJSON(json.RawMessage(`{"uri":`))
+		assert.Error(t, err)
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		_, err := ParseResourceContentsJSON(json.RawMessage(`42`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not an object")
+	})
+}
+
 // Test ParseGetPromptResult with malformed JSON
 
 func TestParseGetPromptResult_Errors(t *testing.T) {
@@ -602,3 +771,75 @@ func TestNewToolResultErrorFromErr_WithError(t *testing.T) {
 	assert.Contains(t, textContent.Text, "test error")
 	assert.Contains(t, textContent.Text, "underlying error")
 }
+
+func TestContentEqual(t *testing.T) {
+	// "hello" in padded and unpadded base64
+	padded, unpadded := "aGVsbG8=", "aGVsbG8"
+
+	decodedMeta := &Meta{}
+	decodedMeta.SetAdditionalField("n", float64(1))
+	meta := &Meta{}
+	meta.SetAdditionalField("n", 1)
+
+	tests := []struct {
+		name  string
+		a, b  Content
+		equal bool
+	}{
+		{name: "same text", a: NewTextContent("hi"), b: NewTextContent("hi"), equal: true},
+		{name: "different text", a: NewTextContent("hi"), b: NewTextContent("ho")},
+		{name: "text and pointer", a: NewTextContent("hi"), b: &TextContent{Type: ContentTypeText, Text: "hi"}, equal: true},
+		{name: "different types", a: NewTextContent("aGVsbG8="), b: NewImageContent("aGVsbG8=", "image/png")},
+		{name: "image base64 variations", a: NewImageContent(padded, "image/png"), b: NewImageContent(unpadded, "image/png"), equal: true},
+		{name: "image different data", a: NewImageContent(padded, "image/png"), b: NewImageContent("d29ybGQ=", "image/png")},
+		{name: "image different MIME type", a: NewImageContent(padded, "image/png"), b: NewImageContent(padded, "image/jpeg")},
+		{name: "audio base64 variations", a: NewAudioContent(padded, "audio/wav"), b: NewAudioContent(unpadded, "audio/wav"), equal: true},
+		{
+			name:  "annotations",
+			a:     NewTextContent("hi", WithContentAnnotations([]Role{RoleUser}, 0.5)),
+			b:     NewTextContent("hi", WithContentAnnotations([]Role{RoleUser}, 0.5)),
+			equal: true,
+		},
+		{name: "different annotations", a: NewTextContent("hi", WithContentAnnotations([]Role{RoleUser}, 0.5)), b: NewTextContent("hi")},
+		{name: "decoded meta", a: TextContent{Type: ContentTypeText, Text: "hi", Meta: meta}, b: TextContent{Type: ContentTypeText, Text: "hi", Meta: decodedMeta}, equal: true},
+		{name: "resource link", a: NewResourceLink("file:///a", "a", "", "text/plain"), b: NewResourceLink("file:///a", "a", "", "text/plain"), equal: true},
+		{
+			name:  "embedded blobs",
+			a:     NewEmbeddedResource(BlobResourceContents{URI: "file:///a", Blob: padded}),
+			b:     NewEmbeddedResource(BlobResourceContents{URI: "file:///a", Blob: unpadded}),
+			equal: true,
+		},
+		{
+			name: "embedded text and blob",
+			a:    NewEmbeddedResource(TextResourceContents{URI: "file:///a", Text: "hello"}),
+			b:    NewEmbeddedResource(BlobResourceContents{URI: "file:///a", Blob: padded}),
+		},
+		{name: "nil", a: nil, b: nil, equal: true},
+		{name: "nil and content", a: nil, b: NewTextContent("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, ContentEqual(tt.a, tt.b))
+			assert.Equal(t, tt.equal, ContentEqual(tt.b, tt.a))
+		})
+	}
+}
+
+func TestContentSliceEqual(t *testing.T) {
+	a := []Content{NewTextContent("hi"), NewImageContent("aGVsbG8=", "image/png")}
+
+	assert.True(t, ContentSliceEqual(a, []Content{NewTextContent("hi"), NewImageContent("aGVsbG8", "image/png")}))
+	assert.False(t, ContentSliceEqual(a, []Content{NewImageContent("aGVsbG8=", "image/png"), NewTextContent("hi")}))
+	assert.False(t, ContentSliceEqual(a, a[:1]))
+	assert.True(t, ContentSliceEqual(nil, []Content{}))
+
+	// Content decoded from JSON equals the content it was encoded from
+	result := NewToolResultText("hi")
+	result.Content = append(result.Content, NewAudioContent("aGVsbG8=", "audio/wav"))
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	decoded, err := ParseCallToolResult((*json.RawMessage)(&data))
+	require.NoError(t, err)
+	assert.True(t, ContentSliceEqual(result.Content, decoded.Content))
+}
@@ -10,7 +10,7 @@ import (
 // Test helper functions with 0% coverage
 
 func TestNewProgressNotification(t *testing.T) {
-	token := ProgressToken("test-token")
+	token := NewStringProgressToken("test-token")
 	progress := 50.0
 	total := 100.0
 	message := "Processing..."
@@ -25,7 +25,7 @@ func TestNewProgressNotification(t *testing.T) {
 }
 
 func TestNewProgressNotification_WithNils(t *testing.T) {
-	token := ProgressToken("test-token")
+	token := NewStringProgressToken("test-token")
 	progress := 50.0
 
 	result := NewProgressNotification(token, progress, nil, nil)
@@ -0,0 +1,228 @@
+package mcptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Limits used when a schema does not bound the size or range of a value.
+const (
+	fuzzMaxStringLength = 16
+	fuzzMaxArrayLength  = 5
+	fuzzMaxDepth        = 8
+	fuzzIntegerRange    = 1000
+)
+
+// fuzzAlphabet is the set of characters used in generated strings. It mixes
+// plain letters with characters that commonly trip up handlers.
+var fuzzAlphabet = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 _-./\\\"'<>&é漢🙂\n\t")
+
+// ToolInputFuzzer generates random tool arguments that satisfy a tool input
+// schema, for property-based testing of tool handlers.
+//
+// The string, integer, number, boolean, array and object types are supported,
+// together with the enum, const, required, minimum, maximum, minLength,
+// maxLength, minItems and maxItems keywords. Optional properties are included
+// at random.
+type ToolInputFuzzer struct {
+	// Schema is the JSON Schema of the tool input, such as the RawInputSchema
+	// of a tool or its marshaled InputSchema.
+	Schema json.RawMessage
+}
+
+// Generate returns random arguments valid against the schema. The same seed
+// always produces the same arguments.
+func (f ToolInputFuzzer) Generate(seed int64) (map[string]any, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(f.Schema, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if schemaType(schema) != "object" {
+		return nil, fmt.Errorf("tool input schema must describe an object")
+	}
+
+	g := fuzzGenerator{rand: rand.New(rand.NewSource(seed))}
+	value, err := g.generate(schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	return value.(map[string]any), nil
+}
+
+type fuzzGenerator struct {
+	rand *rand.Rand
+}
+
+func (g fuzzGenerator) generate(schema map[string]any, depth int) (any, error) {
+	if depth > fuzzMaxDepth {
+		return nil, fmt.Errorf("schema is nested more than %d levels deep", fuzzMaxDepth)
+	}
+	if value, ok := schema["const"]; ok {
+		return value, nil
+	}
+	if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+		return values[g.rand.Intn(len(values))], nil
+	}
+
+	switch t := schemaType(schema); t {
+	case "string":
+		return g.string(schema), nil
+	case "integer":
+		return g.integer(schema)
+	case "number":
+		return g.number(schema)
+	case "boolean":
+		return g.rand.Intn(2) == 1, nil
+	case "array":
+		return g.array(schema, depth)
+	case "object":
+		return g.object(schema, depth)
+	case "":
+		return nil, fmt.Errorf("schema has no type")
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", t)
+	}
+}
+
+func (g fuzzGenerator) string(schema map[string]any) string {
+	minLength := intKeyword(schema, "minLength", 0)
+	maxLength := intKeyword(schema, "maxLength", max(minLength, fuzzMaxStringLength))
+
+	length := minLength + g.rand.Intn(max(maxLength-minLength, 0)+1)
+	runes := make([]rune, length)
+	for i := range runes {
+		runes[i] = fuzzAlphabet[g.rand.Intn(len(fuzzAlphabet))]
+	}
+	return string(runes)
+}
+
+func (g fuzzGenerator) integer(schema map[string]any) (int64, error) {
+	minimum, maximum := numberRange(schema, -fuzzIntegerRange, fuzzIntegerRange)
+	lo, hi := int64(math.Ceil(minimum)), int64(math.Floor(maximum))
+	if lo > hi {
+		return 0, fmt.Errorf("integer range [%v, %v] is empty", minimum, maximum)
+	}
+	return lo + g.rand.Int63n(hi-lo+1), nil
+}
+
+func (g fuzzGenerator) number(schema map[string]any) (float64, error) {
+	minimum, maximum := numberRange(schema, -fuzzIntegerRange, fuzzIntegerRange)
+	if minimum > maximum {
+		return 0, fmt.Errorf("number range [%v, %v] is empty", minimum, maximum)
+	}
+	return minimum + g.rand.Float64()*(maximum-minimum), nil
+}
+
+func (g fuzzGenerator) array(schema map[string]any, depth int) ([]any, error) {
+	minItems := intKeyword(schema, "minItems", 0)
+	maxItems := intKeyword(schema, "maxItems", max(minItems, fuzzMaxArrayLength))
+	length := minItems + g.rand.Intn(max(maxItems-minItems, 0)+1)
+
+	items, _ := schema["items"].(map[string]any)
+	values := make([]any, 0, length)
+	for range length {
+		if items == nil {
+			// Without an item schema any value is valid
+			values = append(values, g.string(nil))
+			continue
+		}
+		value, err := g.generate(items, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (g fuzzGenerator) object(schema map[string]any, depth int) (map[string]any, error) {
+	properties, _ := schema["properties"].(map[string]any)
+	var required []string
+	if values, ok := schema["required"].([]any); ok {
+		for _, v := range values {
+			if name, ok := v.(string); ok {
+				required = append(required, name)
+			}
+		}
+	}
+
+	// Visit properties in a fixed order so that a seed is reproducible
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	object := make(map[string]any, len(names))
+	for _, name := range names {
+		if !slices.Contains(required, name) && g.rand.Intn(2) == 0 {
+			continue
+		}
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("property %q: schema must be an object", name)
+		}
+		value, err := g.generate(property, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		object[name] = value
+	}
+
+	for _, name := range required {
+		if _, ok := object[name]; !ok {
+			return nil, fmt.Errorf("required property %q has no schema", name)
+		}
+	}
+	return object, nil
+}
+
+// schemaType returns the type of a schema. A type array yields its first
+// non-null type, and object and array schemas without a type are recognized
+// by their keywords.
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func intKeyword(schema map[string]any, keyword string, fallback int) int {
+	if n, ok := schema[keyword].(float64); ok && n >= 0 {
+		return int(n)
+	}
+	return fallback
+}
+
+// numberRange returns the inclusive range allowed by the minimum and maximum
+// keywords, defaulting to [lo, hi] around any bound that is set.
+func numberRange(schema map[string]any, lo, hi float64) (float64, float64) {
+	minimum, hasMin := schema["minimum"].(float64)
+	maximum, hasMax := schema["maximum"].(float64)
+	switch {
+	case hasMin && hasMax:
+		return minimum, maximum
+	case hasMin:
+		return minimum, minimum + (hi - lo)
+	case hasMax:
+		return maximum - (hi - lo), maximum
+	default:
+		return lo, hi
+	}
+}
@@ -0,0 +1,108 @@
+package mcptest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/mcptest"
+)
+
+func TestToolInputFuzzer(t *testing.T) {
+	tool := mcp.NewTool("search",
+		mcp.WithString("query", mcp.Required(), mcp.MinLength(1), mcp.MaxLength(8)),
+		mcp.WithString("mode", mcp.Enum("fast", "exact")),
+		mcp.WithNumber("limit", mcp.Required(), mcp.Min(1), mcp.Max(50)),
+		mcp.WithBoolean("verbose"),
+		mcp.WithArray("tags", mcp.WithStringItems(), mcp.MaxItems(3)),
+		mcp.WithObject("filter", mcp.Properties(map[string]any{
+			"year": map[string]any{"type": "integer", "minimum": 1990, "maximum": 2030},
+		})),
+	)
+	schema, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fuzzer := mcptest.ToolInputFuzzer{Schema: schema}
+
+	for seed := range int64(200) {
+		input, err := fuzzer.Generate(seed)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if _, err := json.Marshal(input); err != nil {
+			t.Fatalf("seed %d: generated input does not marshal: %v", seed, err)
+		}
+
+		query, ok := input["query"].(string)
+		if n := utf8.RuneCountInString(query); !ok || n < 1 || n > 8 {
+			t.Errorf("seed %d: query %q violates the schema", seed, input["query"])
+		}
+		limit, ok := input["limit"].(float64)
+		if !ok || limit < 1 || limit > 50 {
+			t.Errorf("seed %d: limit %v violates the schema", seed, input["limit"])
+		}
+		if mode, ok := input["mode"]; ok && !slices.Contains([]any{"fast", "exact"}, mode) {
+			t.Errorf("seed %d: mode %v is not in the enum", seed, mode)
+		}
+		if verbose, ok := input["verbose"]; ok {
+			if _, isBool := verbose.(bool); !isBool {
+				t.Errorf("seed %d: verbose %v is not a boolean", seed, verbose)
+			}
+		}
+		if tags, ok := input["tags"]; ok {
+			items, isArray := tags.([]any)
+			if !isArray || len(items) > 3 {
+				t.Errorf("seed %d: tags %v violates the schema", seed, tags)
+			}
+			for _, item := range items {
+				if _, isString := item.(string); !isString {
+					t.Errorf("seed %d: tag %v is not a string", seed, item)
+				}
+			}
+		}
+		if filter, ok := input["filter"]; ok {
+			object, isObject := filter.(map[string]any)
+			if !isObject {
+				t.Fatalf("seed %d: filter %v is not an object", seed, filter)
+			}
+			if year, ok := object["year"]; ok {
+				if y, isInt := year.(int64); !isInt || y < 1990 || y > 2030 {
+					t.Errorf("seed %d: year %v violates the schema", seed, year)
+				}
+			}
+		}
+	}
+
+	first, err := fuzzer.Generate(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := fuzzer.Generate(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("the same seed generated different inputs: %v and %v", first, second)
+	}
+}
+
+func TestToolInputFuzzerErrors(t *testing.T) {
+	tests := map[string]string{
+		"invalid JSON":     `{"type":`,
+		"not an object":    `{"type": "string"}`,
+		"unsupported type": `{"type": "object", "properties": {"x": {"type": "date"}}, "required": ["x"]}`,
+		"missing required": `{"type": "object", "required": ["x"]}`,
+	}
+	for name, schema := range tests {
+		t.Run(name, func(t *testing.T) {
+			fuzzer := mcptest.ToolInputFuzzer{Schema: json.RawMessage(schema)}
+			if _, err := fuzzer.Generate(1); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package mcptest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RequestMatcher reports whether a fixture applies to a request, given the
+// request params decoded from JSON. A nil RequestMatcher matches every request.
+type RequestMatcher func(params map[string]any) bool
+
+// MatchToolName matches tools/call requests for the named tool.
+func MatchToolName(name string) RequestMatcher {
+	return func(params map[string]any) bool {
+		return params["name"] == name
+	}
+}
+
+// MockResponse builds the response of a fixture for the request with the
+// given ID.
+type MockResponse func(id mcp.RequestId) *transport.JSONRPCResponse
+
+// RespondWith responds with result, which is marshaled to JSON.
+func RespondWith(result any) MockResponse {
+	return func(id mcp.RequestId) *transport.JSONRPCResponse {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return transport.NewJSONRPCErrorResponse(id, mcp.INTERNAL_ERROR,
+				fmt.Sprintf("failed to marshal fixture result: %v", err), nil)
+		}
+		return transport.NewJSONRPCResultResponse(id, data)
+	}
+}
+
+// RespondWithTool responds to a tools/call request with result.
+func RespondWithTool(result *mcp.CallToolResult) MockResponse {
+	return RespondWith(result)
+}
+
+// RespondWithError responds with a JSON-RPC error.
+func RespondWithError(code int, message string) MockResponse {
+	return func(id mcp.RequestId) *transport.JSONRPCResponse {
+		return transport.NewJSONRPCErrorResponse(id, code, message, nil)
+	}
+}
+
+// MockCall is a request or notification received by a MockServer.
+type MockCall struct {
+	Method string
+	Params map[string]any
+}
+
+type mockFixture struct {
+	method   string
+	matcher  RequestMatcher
+	response MockResponse
+}
+
+// MockServer is a fake MCP server for testing clients. It implements
+// transport.Interface, so a client can be created on top of it without a real
+// connection, answers requests from fixtures and records every call.
+//
+// The initialize and ping requests are answered automatically unless a
+// fixture is registered for them. Requests without a matching fixture fail
+// with METHOD_NOT_FOUND.
+//
+//	mock := mcptest.NewMockServer()
+//	mock.On("tools/call", mcptest.MatchToolName("add"), mcptest.RespondWithTool(mcp.NewToolResultText("3")))
+//	c := client.NewClient(mock)
+//	// ... initialize the client and call the tool ...
+//	mock.AssertToolCalled(t, "add", map[string]any{"a": 1, "b": 2})
+type MockServer struct {
+	mu             sync.Mutex
+	fixtures       []mockFixture
+	calls          []MockCall
+	onNotification func(mcp.JSONRPCNotification)
+}
+
+var _ transport.Interface = (*MockServer)(nil)
+
+// NewMockServer creates a MockServer without fixtures.
+func NewMockServer() *MockServer {
+	return &MockServer{}
+}
+
+// On registers a fixture answering requests for method that match matcher.
+// Fixtures are tried in the order they were registered.
+func (m *MockServer) On(method string, matcher RequestMatcher, response MockResponse) *MockServer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fixtures = append(m.fixtures, mockFixture{method: method, matcher: matcher, response: response})
+	return m
+}
+
+// Calls returns the requests and notifications received so far, in order.
+func (m *MockServer) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// AssertToolCalled checks that the named tool was called with arguments equal
+// to expectedArgs, compared after a JSON round trip so that numbers of any Go
+// type match. A nil expectedArgs only checks that the tool was called.
+func (m *MockServer) AssertToolCalled(t testing.TB, toolName string, expectedArgs map[string]any) {
+	t.Helper()
+
+	expected, err := normalizeJSON(expectedArgs)
+	if err != nil {
+		t.Fatalf("invalid expected arguments: %v", err)
+	}
+
+	var called []any
+	for _, call := range m.Calls() {
+		if call.Method != string(mcp.MethodToolsCall) || call.Params["name"] != toolName {
+			continue
+		}
+		if expectedArgs == nil || reflect.DeepEqual(call.Params["arguments"], expected) {
+			return
+		}
+		called = append(called, call.Params["arguments"])
+	}
+
+	if len(called) == 0 {
+		t.Errorf("tool %q was not called", toolName)
+		return
+	}
+	t.Errorf("tool %q was not called with arguments %v, got calls with %v", toolName, expected, called)
+}
+
+// Notify sends a notification to the client.
+func (m *MockServer) Notify(method string, params map[string]any) {
+	m.mu.Lock()
+	handler := m.onNotification
+	m.mu.Unlock()
+
+	if handler != nil {
+		notification := mcp.JSONRPCNotification{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			Notification: mcp.Notification{
+				Method: method,
+				Params: mcp.NotificationParams{AdditionalFields: params},
+			},
+		}
+		handler(notification)
+	}
+}
+
+// Start implements transport.Interface.
+func (m *MockServer) Start(ctx context.Context) error {
+	return nil
+}
+
+// SendRequest implements transport.Interface. It records the request and
+// answers it from the first matching fixture.
+func (m *MockServer) SendRequest(ctx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+	params, err := paramsMap(request.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.calls = append(m.calls, MockCall{Method: request.Method, Params: params})
+	fixtures := m.fixtures
+	m.mu.Unlock()
+
+	for _, fixture := range fixtures {
+		if fixture.method == request.Method && (fixture.matcher == nil || fixture.matcher(params)) {
+			return fixture.response(request.ID), nil
+		}
+	}
+
+	switch request.Method {
+	case string(mcp.MethodInitialize):
+		return RespondWith(mcp.InitializeResult{
+			ProtocolVersion: mcp.LATEST_PROTOCOL_VERSION,
+			ServerInfo:      mcp.Implementation{Name: "mock-server", Version: "1.0.0"},
+		})(request.ID), nil
+	case string(mcp.MethodPing):
+		return RespondWith(mcp.EmptyResult{})(request.ID), nil
+	}
+
+	return transport.NewJSONRPCErrorResponse(request.ID, mcp.METHOD_NOT_FOUND,
+		fmt.Sprintf("no fixture for method %s", request.Method), nil), nil
+}
+
+// SendNotification implements transport.Interface. It records the notification.
+func (m *MockServer) SendNotification(ctx context.Context, notification mcp.JSONRPCNotification) error {
+	params, err := paramsMap(notification.Params)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: notification.Method, Params: params})
+	return nil
+}
+
+// SetNotificationHandler implements transport.Interface.
+func (m *MockServer) SetNotificationHandler(handler func(notification mcp.JSONRPCNotification)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onNotification = handler
+}
+
+// Close implements transport.Interface.
+func (m *MockServer) Close() error {
+	return nil
+}
+
+// GetSessionId implements transport.Interface.
+func (m *MockServer) GetSessionId() string {
+	return ""
+}
+
+// paramsMap decodes request params into a map, as the server would see them.
+func paramsMap(params any) (map[string]any, error) {
+	if params == nil {
+		return nil, nil
+	}
+	normalized, err := normalizeJSON(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+	m, _ := normalized.(map[string]any)
+	return m, nil
+}
+
+// normalizeJSON converts v to its generic JSON representation.
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
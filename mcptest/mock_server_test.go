@@ -0,0 +1,140 @@
+package mcptest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/mcptest"
+)
+
+func newMockClient(t *testing.T, mock *mcptest.MockServer) *client.Client {
+	t.Helper()
+
+	c := client.NewClient(mock)
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	var initRequest mcp.InitializeRequest
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	if _, err := c.Initialize(context.Background(), initRequest); err != nil {
+		t.Fatal("Initialize:", err)
+	}
+	return c
+}
+
+func callTool(c *client.Client, name string, args map[string]any) (*mcp.CallToolResult, error) {
+	var request mcp.CallToolRequest
+	request.Params.Name = name
+	request.Params.Arguments = args
+	return c.CallTool(context.Background(), request)
+}
+
+func TestMockServer(t *testing.T) {
+	mock := mcptest.NewMockServer().
+		On("tools/call", mcptest.MatchToolName("add"), mcptest.RespondWithTool(mcp.NewToolResultText("3"))).
+		On("tools/call", mcptest.MatchToolName("fail"), mcptest.RespondWithError(mcp.INVALID_PARAMS, "bad input"))
+	c := newMockClient(t, mock)
+
+	result, err := callTool(c, "add", map[string]any{"a": 1, "b": 2})
+	if err != nil {
+		t.Fatal("CallTool:", err)
+	}
+	if got := result.Content[0].(mcp.TextContent).Text; got != "3" {
+		t.Errorf("Got %q, want %q", got, "3")
+	}
+	mock.AssertToolCalled(t, "add", map[string]any{"a": 1, "b": 2})
+	mock.AssertToolCalled(t, "add", nil)
+
+	_, err = callTool(c, "fail", nil)
+	if !errors.Is(err, mcp.ErrInvalidParams) {
+		t.Errorf("Got error %v, want %v", err, mcp.ErrInvalidParams)
+	}
+
+	_, err = callTool(c, "unknown", nil)
+	if !errors.Is(err, mcp.ErrMethodNotFound) {
+		t.Errorf("Got error %v, want %v", err, mcp.ErrMethodNotFound)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Error("Ping:", err)
+	}
+
+	var methods []string
+	for _, call := range mock.Calls() {
+		methods = append(methods, call.Method)
+	}
+	want := []string{"initialize", "notifications/initialized", "tools/call", "tools/call", "tools/call", "ping"}
+	if len(methods) != len(want) {
+		t.Fatalf("Got calls %v, want %v", methods, want)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Errorf("Got calls %v, want %v", methods, want)
+			break
+		}
+	}
+}
+
+// failureRecorder records assertion failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failed = true
+}
+
+func (r *failureRecorder) Fatalf(format string, args ...any) {
+	r.failed = true
+}
+
+func TestMockServerAssertToolCalledFailures(t *testing.T) {
+	mock := mcptest.NewMockServer().
+		On("tools/call", nil, mcptest.RespondWithTool(mcp.NewToolResultText("ok")))
+	c := newMockClient(t, mock)
+
+	if _, err := callTool(c, "add", map[string]any{"a": 1}); err != nil {
+		t.Fatal("CallTool:", err)
+	}
+
+	wrongArgs := &failureRecorder{TB: t}
+	mock.AssertToolCalled(wrongArgs, "add", map[string]any{"a": 2})
+	if !wrongArgs.failed {
+		t.Error("AssertToolCalled should fail for different arguments")
+	}
+
+	notCalled := &failureRecorder{TB: t}
+	mock.AssertToolCalled(notCalled, "subtract", nil)
+	if !notCalled.failed {
+		t.Error("AssertToolCalled should fail for a tool that was not called")
+	}
+}
+
+func TestMockServerNotify(t *testing.T) {
+	mock := mcptest.NewMockServer()
+	c := newMockClient(t, mock)
+
+	received := make(chan mcp.JSONRPCNotification, 1)
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		received <- notification
+	})
+
+	mock.Notify(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": "test://resource"})
+
+	notification := <-received
+	if notification.Method != mcp.MethodNotificationResourceUpdated {
+		t.Errorf("Got method %q, want %q", notification.Method, mcp.MethodNotificationResourceUpdated)
+	}
+	if uri := notification.Params.AdditionalFields["uri"]; uri != "test://resource" {
+		t.Errorf("Got uri %v, want %q", uri, "test://resource")
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// isBatch reports whether message is a JSON-RPC batch, an array of messages.
+func isBatch(message json.RawMessage) bool {
+	trimmed := bytes.TrimLeft(message, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// handleBatch handles the messages of a JSON-RPC batch in order. It returns
+// the array of their responses, or nil if the batch only holds notifications.
+func (s *MCPServer) handleBatch(ctx context.Context, message json.RawMessage) mcp.JSONRPCMessage {
+	if s.maxRequestBodySize > 0 && int64(len(message)) > s.maxRequestBodySize {
+		return createErrorResponse(nil, mcp.PARSE_ERROR, s.requestTooLargeMessage())
+	}
+
+	var messages []json.RawMessage
+	if err := json.Unmarshal(message, &messages); err != nil {
+		return createErrorResponse(nil, mcp.PARSE_ERROR, "Failed to parse message")
+	}
+	if len(messages) == 0 {
+		return createErrorResponse(nil, mcp.INVALID_REQUEST, "Empty batch")
+	}
+
+	var responses []mcp.JSONRPCMessage
+	for _, message := range messages {
+		var response mcp.JSONRPCMessage
+		if isBatch(message) {
+			response = createErrorResponse(nil, mcp.INVALID_REQUEST, "Nested batch")
+		} else {
+			response = s.HandleMessage(ctx, message)
+		}
+		if response != nil {
+			responses = append(responses, response)
+		}
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	return responses
+}
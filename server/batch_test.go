@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_HandleBatch(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0")
+
+	response := server.HandleMessage(context.Background(), []byte(`[
+		{"jsonrpc": "2.0", "id": 1, "method": "ping"},
+		{"jsonrpc": "2.0", "method": "notifications/initialized"},
+		{"jsonrpc": "2.0", "id": 2, "method": "unknown/method"},
+		[{"jsonrpc": "2.0", "id": 3, "method": "ping"}]
+	]`))
+	responses, ok := response.([]mcp.JSONRPCMessage)
+	require.True(t, ok, "expected a batch response, got %T", response)
+	require.Len(t, responses, 3)
+
+	pong, ok := responses[0].(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	assert.Equal(t, mcp.NewRequestId(float64(1)), pong.ID)
+
+	unknown, ok := responses[1].(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.METHOD_NOT_FOUND, unknown.Error.Code)
+
+	nested, ok := responses[2].(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, nested.Error.Code)
+
+	// The responses are encoded as a JSON array
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded, 3)
+}
+
+func TestMCPServer_HandleBatchWithoutResponses(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0")
+
+	assert.Nil(t, server.HandleMessage(context.Background(), []byte(
+		`[{"jsonrpc": "2.0", "method": "notifications/initialized"}]`,
+	)))
+
+	response, ok := server.HandleMessage(context.Background(), []byte(`[]`)).(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, response.Error.Code)
+
+	response, ok = server.HandleMessage(context.Background(), []byte(`[{]`)).(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.PARSE_ERROR, response.Error.Code)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerBuilder collects the options, tools, resources and prompts of an
+// MCPServer and constructs it in a single step with Build.
+type ServerBuilder struct {
+	name      string
+	version   string
+	options   []ServerOption
+	tools     []ServerTool
+	resources []ServerResource
+	prompts   []ServerPrompt
+}
+
+// NewServerBuilder creates a ServerBuilder for a server with the given name
+// and version.
+//
+//	s, err := server.NewServerBuilder("demo", "1.0.0").
+//		Option(server.WithRecovery()).
+//		Tool(mcp.NewTool("echo"), echoHandler).
+//		Build()
+func NewServerBuilder(name, version string) *ServerBuilder {
+	return &ServerBuilder{
+		name:    name,
+		version: version,
+	}
+}
+
+// Option adds a ServerOption applied when the server is built.
+func (b *ServerBuilder) Option(opt ServerOption) *ServerBuilder {
+	b.options = append(b.options, opt)
+	return b
+}
+
+// Tool registers a tool and its handler.
+func (b *ServerBuilder) Tool(tool mcp.Tool, handler ToolHandlerFunc) *ServerBuilder {
+	b.tools = append(b.tools, ServerTool{Tool: tool, Handler: handler})
+	return b
+}
+
+// Resource registers a resource and its handler.
+func (b *ServerBuilder) Resource(resource mcp.Resource, handler ResourceHandlerFunc) *ServerBuilder {
+	b.resources = append(b.resources, ServerResource{Resource: resource, Handler: handler})
+	return b
+}
+
+// Prompt registers a prompt and its handler.
+func (b *ServerBuilder) Prompt(prompt mcp.Prompt, handler PromptHandlerFunc) *ServerBuilder {
+	b.prompts = append(b.prompts, ServerPrompt{Prompt: prompt, Handler: handler})
+	return b
+}
+
+// Build creates the server, registers everything added to the builder and
+// validates the result with ValidateAllRegistrations. The server is only
+// returned if validation succeeds.
+func (b *ServerBuilder) Build() (*MCPServer, error) {
+	s := NewMCPServer(b.name, b.version, b.options...)
+
+	if len(b.tools) > 0 {
+		s.AddTools(b.tools...)
+	}
+	if len(b.resources) > 0 {
+		s.AddResources(b.resources...)
+	}
+	if len(b.prompts) > 0 {
+		s.AddPrompts(b.prompts...)
+	}
+
+	if err := s.ValidateAllRegistrations(); err != nil {
+		return nil, fmt.Errorf("building server %s: %w", b.name, err)
+	}
+	return s, nil
+}
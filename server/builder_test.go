@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerBuilder_Build(t *testing.T) {
+	server, err := NewServerBuilder("test-server", "1.0.0").
+		Option(WithRecovery()).
+		Tool(mcp.NewTool("echo"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("echo"), nil
+		}).
+		Resource(mcp.NewResource("test://resource", "Resource"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		}).
+		Prompt(mcp.NewPrompt("greeting"), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("greeting", nil), nil
+		}).
+		Build()
+	require.NoError(t, err)
+	require.NotNil(t, server)
+
+	assert.Len(t, server.ListTools(), 1)
+	assert.Len(t, server.toolHandlerMiddlewares, 1)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "initialize"
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tools"`)
+	assert.Contains(t, string(data), `"resources"`)
+	assert.Contains(t, string(data), `"prompts"`)
+}
+
+func TestServerBuilder_BuildInvalidTool(t *testing.T) {
+	server, err := NewServerBuilder("test-server", "1.0.0").
+		Tool(mcp.Tool{Name: "no-schema"}, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, nil
+		}).
+		Build()
+
+	require.Error(t, err)
+	assert.Nil(t, server)
+	assert.ErrorIs(t, err, ErrInvalidRegistration)
+	assert.Contains(t, err.Error(), "no-schema")
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NewBulkheadMiddleware returns a middleware limiting the number of requests
+// handled at the same time to maxConcurrent, separately for each tool and for
+// each method other than tools/call. A tool that is slow or stuck can then
+// only hold up calls to itself, not to other tools. Requests over the limit
+// wait for a slot, and are answered with REQUEST_INTERRUPTED if their context
+// is done first. The semaphore of a tool only exists while requests hold or
+// wait for its slots, so calls to unknown tool names leave nothing behind.
+func NewBulkheadMiddleware(maxConcurrent int) MiddlewareFunc {
+	bulkhead := &bulkhead{
+		maxConcurrent: max(maxConcurrent, 1),
+		slots:         make(map[string]*bulkheadSlots),
+	}
+	return bulkhead.middleware
+}
+
+type bulkhead struct {
+	maxConcurrent int
+
+	mu    sync.Mutex
+	slots map[string]*bulkheadSlots // Semaphores in use, by request target
+}
+
+// bulkheadSlots is the semaphore of a request target.
+type bulkheadSlots struct {
+	semaphore chan struct{}
+	users     int // Requests holding or waiting for a slot
+}
+
+func (b *bulkhead) middleware(next RequestHandlerFunc) RequestHandlerFunc {
+	return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+		target := requestTarget(request)
+		slots := b.acquire(target)
+		defer b.release(target, slots)
+
+		select {
+		case slots.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return mcp.NewJSONRPCError(
+				request.ID,
+				mcp.REQUEST_INTERRUPTED,
+				fmt.Sprintf("request for %s interrupted while waiting for a slot: %v", target, ctx.Err()),
+				nil,
+			)
+		}
+		defer func() { <-slots.semaphore }()
+
+		return next(ctx, request)
+	}
+}
+
+// acquire returns the semaphore of the given request target, creating it if
+// no other request uses it. It must be released with release.
+func (b *bulkhead) acquire(target string) *bulkheadSlots {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slots, ok := b.slots[target]
+	if !ok {
+		slots = &bulkheadSlots{semaphore: make(chan struct{}, b.maxConcurrent)}
+		b.slots[target] = slots
+	}
+	slots.users++
+	return slots
+}
+
+// release drops the semaphore of the given request target once no request
+// uses it anymore.
+func (b *bulkhead) release(target string, slots *bulkheadSlots) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slots.users--
+	if slots.users == 0 {
+		delete(b.slots, target)
+	}
+}
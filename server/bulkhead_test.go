@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBulkheadMiddleware(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddMiddlewareByName("bulkhead", NewBulkheadMiddleware(2))
+
+	release := make(chan struct{})
+	var running atomic.Int32
+	server.AddTool(mcp.NewTool("a"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		running.Add(1)
+		defer running.Add(-1)
+		<-release
+		return mcp.NewToolResultText("a"), nil
+	})
+	server.AddTool(mcp.NewTool("b"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("b"), nil
+	})
+
+	callTool := func(ctx context.Context, id int, name string) mcp.JSONRPCMessage {
+		return server.HandleMessage(ctx, []byte(fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": %d,
+			"method": "tools/call",
+			"params": {"name": "%s"}
+		}`, id, name)))
+	}
+
+	// Saturate tool a, with one more call waiting for a slot
+	var wg sync.WaitGroup
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := callTool(context.Background(), i+1, "a")
+			_, ok := response.(mcp.JSONRPCResponse)
+			assert.True(t, ok, "unexpected response %#v", response)
+		}()
+	}
+	require.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Tool b is not held up by tool a
+	for i := range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		response := callTool(ctx, 10+i, "b")
+		cancel()
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+	}
+
+	// Calls to tool a over the limit wait until their context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	response := callTool(ctx, 20, "a")
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, mcp.REQUEST_INTERRUPTED, errResp.Error.Code)
+	assert.Equal(t, int32(2), running.Load())
+
+	close(release)
+	wg.Wait()
+}
+
+func TestBulkhead_DropsIdleSemaphores(t *testing.T) {
+	b := &bulkhead{maxConcurrent: 1, slots: make(map[string]*bulkheadSlots)}
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := b.middleware(func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+		if request.Params.(map[string]any)["name"] == "slow" {
+			close(entered)
+			<-release
+		}
+		return mcp.NewJSONRPCResponse(request.ID, mcp.Result{})
+	})
+	call := func(name string) mcp.JSONRPCMessage {
+		return handler(context.Background(), &mcp.JSONRPCRequest{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			ID:      mcp.NewRequestId(1),
+			Params:  map[string]any{"name": name},
+			Request: mcp.Request{Method: string(mcp.MethodToolsCall)},
+		})
+	}
+
+	// Calls to arbitrary tool names leave no semaphore behind
+	for i := range 100 {
+		call(fmt.Sprintf("unknown-%d", i))
+	}
+	assert.Empty(t, b.slots)
+
+	// The semaphore of a tool lives as long as a call uses it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		call("slow")
+	}()
+	<-entered
+	b.mu.Lock()
+	assert.Len(t, b.slots, 1)
+	b.mu.Unlock()
+	close(release)
+	<-done
+	assert.Empty(t, b.slots)
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithCapabilitiesChangedNotifications makes SetCapabilities send a
+// notifications/capabilities_changed notification to the initialized clients,
+// so they can negotiate the new capabilities. This notification is an
+// extension to the MCP specification, which clients may not understand.
+func WithCapabilitiesChangedNotifications() ServerOption {
+	return func(s *MCPServer) {
+		s.capabilitiesNotifications = true
+	}
+}
+
+// SetCapabilities replaces the capabilities the server advertises, for
+// servers discovering their capabilities after startup. Clients initializing
+// afterwards receive the new capabilities; the clients already initialized
+// are notified when the server has WithCapabilitiesChangedNotifications.
+func (s *MCPServer) SetCapabilities(capabilities mcp.ServerCapabilities) {
+	updated := serverCapabilities{
+		experimental: maps.Clone(capabilities.Experimental),
+	}
+	if capabilities.Resources != nil {
+		updated.resources = &resourceCapabilities{
+			subscribe:   capabilities.Resources.Subscribe,
+			listChanged: capabilities.Resources.ListChanged,
+		}
+	}
+	if capabilities.Prompts != nil {
+		updated.prompts = &promptCapabilities{listChanged: capabilities.Prompts.ListChanged}
+	}
+	if capabilities.Tools != nil {
+		updated.tools = &toolCapabilities{listChanged: capabilities.Tools.ListChanged}
+	}
+	updated.logging = mcp.ToBoolPtr(capabilities.Logging != nil)
+	updated.sampling = mcp.ToBoolPtr(capabilities.Sampling != nil)
+	updated.elicitation = mcp.ToBoolPtr(capabilities.Elicitation != nil)
+	updated.roots = mcp.ToBoolPtr(capabilities.Roots != nil)
+	updated.completions = mcp.ToBoolPtr(capabilities.Completions != nil)
+
+	s.capabilitiesMu.Lock()
+	s.capabilities = updated
+	s.capabilitiesMu.Unlock()
+
+	if s.capabilitiesNotifications {
+		s.SendNotificationToAllClients(mcp.MethodNotificationCapabilitiesChanged, map[string]any{
+			"capabilities": s.advertisedCapabilities(),
+		})
+	}
+}
+
+// capabilitiesSnapshot returns a copy of the capabilities of the server. It
+// is the way to read them outside of capabilitiesMu, as SetCapabilities may
+// replace them at any time.
+func (s *MCPServer) capabilitiesSnapshot() serverCapabilities {
+	s.capabilitiesMu.RLock()
+	defer s.capabilitiesMu.RUnlock()
+	return s.capabilities
+}
+
+// toolsListChanged reports whether the tools capability declares listChanged.
+func (c serverCapabilities) toolsListChanged() bool {
+	return c.tools != nil && c.tools.listChanged
+}
+
+// resourcesListChanged reports whether the resources capability declares
+// listChanged.
+func (c serverCapabilities) resourcesListChanged() bool {
+	return c.resources != nil && c.resources.listChanged
+}
+
+// promptsListChanged reports whether the prompts capability declares
+// listChanged.
+func (c serverCapabilities) promptsListChanged() bool {
+	return c.prompts != nil && c.prompts.listChanged
+}
+
+// advertisedCapabilities returns the capabilities the server advertises to
+// clients on initialization.
+func (s *MCPServer) advertisedCapabilities() mcp.ServerCapabilities {
+	s.capabilitiesMu.RLock()
+	defer s.capabilitiesMu.RUnlock()
+
+	capabilities := mcp.ServerCapabilities{
+		Experimental: maps.Clone(s.capabilities.experimental),
+	}
+
+	// Only add resource capabilities if they're configured
+	if s.capabilities.resources != nil {
+		capabilities.Resources = &struct {
+			Subscribe   bool `json:"subscribe,omitempty"`
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{
+			Subscribe:   s.capabilities.resources.subscribe,
+			ListChanged: s.capabilities.resources.listChanged,
+		}
+	}
+
+	// Only add prompt capabilities if they're configured
+	if s.capabilities.prompts != nil {
+		capabilities.Prompts = &struct {
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{
+			ListChanged: s.capabilities.prompts.listChanged,
+		}
+	}
+
+	// Only add tool capabilities if they're configured
+	if s.capabilities.tools != nil {
+		capabilities.Tools = &struct {
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{
+			ListChanged: s.capabilities.tools.listChanged,
+		}
+	}
+
+	if s.capabilities.logging != nil && *s.capabilities.logging {
+		capabilities.Logging = &struct{}{}
+	}
+
+	if s.capabilities.sampling != nil && *s.capabilities.sampling {
+		capabilities.Sampling = &struct{}{}
+	}
+
+	if s.capabilities.elicitation != nil && *s.capabilities.elicitation {
+		capabilities.Elicitation = &struct{}{}
+	}
+
+	if s.capabilities.roots != nil && *s.capabilities.roots {
+		capabilities.Roots = &struct{}{}
+	}
+
+	if s.capabilities.completions != nil && *s.capabilities.completions {
+		capabilities.Completions = &struct{}{}
+	}
+
+	return capabilities
+}
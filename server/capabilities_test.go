@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_SetCapabilities(t *testing.T) {
+	initialize := func(server *MCPServer) mcp.ServerCapabilities {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "initialize",
+			"params": {"protocolVersion": "2025-03-26", "clientInfo": {"name": "test", "version": "1.0.0"}}
+		}`))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		return resp.Result.(mcp.InitializeResult).Capabilities
+	}
+
+	server := NewMCPServer("test", "1.0.0", WithToolCapabilities(true))
+	capabilities := initialize(server)
+	require.NotNil(t, capabilities.Tools)
+	assert.Nil(t, capabilities.Sampling)
+
+	updated := mcp.ServerCapabilities{
+		Experimental: map[string]any{"plugins": true},
+		Resources: &struct {
+			Subscribe   bool `json:"subscribe,omitempty"`
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{Subscribe: true},
+		Sampling: &struct{}{},
+	}
+	server.SetCapabilities(updated)
+	assert.Equal(t, updated, initialize(server))
+}
+
+func TestMCPServer_SetCapabilitiesNotifiesClients(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []ServerOption
+		notify  bool
+	}{
+		{name: "without notifications"},
+		{name: "with notifications", options: []ServerOption{WithCapabilitiesChangedNotifications()}, notify: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewMCPServer("test", "1.0.0", tt.options...)
+			initialized := fakeSession{
+				sessionID:           "initialized",
+				notificationChannel: make(chan mcp.JSONRPCNotification, 1),
+				initialized:         true,
+			}
+			uninitialized := fakeSession{
+				sessionID:           "uninitialized",
+				notificationChannel: make(chan mcp.JSONRPCNotification, 1),
+			}
+			require.NoError(t, server.RegisterSession(context.Background(), initialized))
+			require.NoError(t, server.RegisterSession(context.Background(), uninitialized))
+
+			server.SetCapabilities(mcp.ServerCapabilities{Logging: &struct{}{}})
+
+			assert.Empty(t, uninitialized.notificationChannel)
+			if !tt.notify {
+				assert.Empty(t, initialized.notificationChannel)
+				return
+			}
+			require.Len(t, initialized.notificationChannel, 1)
+			notification := <-initialized.notificationChannel
+			assert.Equal(t, mcp.MethodNotificationCapabilitiesChanged, notification.Method)
+			data, err := json.Marshal(notification.Params.AdditionalFields["capabilities"])
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"logging": {}}`, string(data))
+		})
+	}
+}
+
+func TestMCPServer_SetCapabilitiesConcurrentReads(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0")
+	withLists := mcp.ServerCapabilities{
+		Tools: &struct {
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{ListChanged: true},
+		Resources: &struct {
+			Subscribe   bool `json:"subscribe,omitempty"`
+			ListChanged bool `json:"listChanged,omitempty"`
+		}{ListChanged: true},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if i%2 == 0 {
+				server.SetCapabilities(withLists)
+			} else {
+				server.SetCapabilities(mcp.ServerCapabilities{})
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`))
+			server.AddTool(mcp.NewTool("echo"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("echo"), nil
+			})
+			server.DeleteTools("echo")
+			server.AddResource(mcp.NewResource("test://resource", "resource"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				return nil, nil
+			})
+			require.NoError(t, server.Reload())
+		}
+	}()
+	wg.Wait()
+}
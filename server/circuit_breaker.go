@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// circuitState is the state of a circuit breaker.
+type circuitState int
+
+const (
+	// circuitClosed lets requests through, counting consecutive failures.
+	circuitClosed circuitState = iota
+	// circuitOpen rejects requests until the reset timeout has passed.
+	circuitOpen
+	// circuitHalfOpen lets a single trial request through, whose outcome
+	// closes or opens the circuit again.
+	circuitHalfOpen
+)
+
+// NewCircuitBreakerMiddleware returns a middleware that stops handling
+// requests that keep failing, so that a broken tool or backend is not called
+// over and over. Each tool has its own circuit, as has each method other than
+// tools/call. A circuit opens after threshold consecutive failures, which are
+// requests answered with an INTERNAL_ERROR, such as tool handlers returning an
+// error. While open, requests are rejected with SERVICE_UNAVAILABLE. Once
+// resetTimeout has passed, the circuit is half-open: a single request is let
+// through, closing the circuit if it succeeds and opening it again otherwise.
+func NewCircuitBreakerMiddleware(threshold int, resetTimeout time.Duration) MiddlewareFunc {
+	breaker := &circuitBreaker{
+		threshold:    max(threshold, 1),
+		resetTimeout: resetTimeout,
+		circuits:     make(map[string]*circuit),
+	}
+	return breaker.middleware
+}
+
+type circuitBreaker struct {
+	threshold    int
+	resetTimeout time.Duration
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+func (b *circuitBreaker) middleware(next RequestHandlerFunc) RequestHandlerFunc {
+	return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+		key := requestTarget(request)
+		if !b.allow(key) {
+			return mcp.JSONRPCError{
+				JSONRPC: mcp.JSONRPC_VERSION,
+				ID:      request.ID,
+				Error: mcp.NewJSONRPCErrorDetails(
+					mcp.SERVICE_UNAVAILABLE,
+					fmt.Sprintf("circuit breaker is open for %s", key),
+					nil,
+				),
+			}
+		}
+
+		response := next(ctx, request)
+		errResp, failed := response.(mcp.JSONRPCError)
+		b.record(key, failed && errResp.Error.Code == mcp.INTERNAL_ERROR)
+		return response
+	}
+}
+
+// allow reports whether a request may go through the circuit with the given
+// key, moving it from open to half-open once the reset timeout has passed.
+func (b *circuitBreaker) allow(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok {
+		return true
+	}
+	switch c.state {
+	case circuitOpen:
+		if time.Since(c.openedAt) < b.resetTimeout {
+			return false
+		}
+		c.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// The trial request is still in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// record updates the circuit with the given key with the outcome of a
+// request that went through it.
+func (b *circuitBreaker) record(key string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !failed {
+		if ok {
+			delete(b.circuits, key)
+		}
+		return
+	}
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	c.failures++
+	if c.state == circuitHalfOpen || c.failures >= b.threshold {
+		c.state = circuitOpen
+		c.openedAt = time.Now()
+	}
+}
+
+// requestTarget describes what request is for, to tell apart the failure
+// domains of middleware: the tool for tool calls, and the method otherwise.
+func requestTarget(request *mcp.JSONRPCRequest) string {
+	if request.Method == string(mcp.MethodToolsCall) {
+		if params, ok := request.Params.(map[string]any); ok {
+			if name, ok := params["name"].(string); ok {
+				return fmt.Sprintf("tool '%s'", name)
+			}
+		}
+	}
+	return request.Method
+}
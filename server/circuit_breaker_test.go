@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCircuitBreakerMiddleware(t *testing.T) {
+	const resetTimeout = 50 * time.Millisecond
+
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddMiddlewareByName("circuit-breaker", NewCircuitBreakerMiddleware(2, resetTimeout))
+
+	failing := true
+	var calls int
+	server.AddTool(mcp.NewTool("flaky"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		if failing {
+			return nil, errors.New("backend unavailable")
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	server.AddTool(mcp.NewTool("healthy"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	callTool := func(name string) int {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "`+name+`"}
+		}`))
+		switch resp := response.(type) {
+		case mcp.JSONRPCResponse:
+			return 0
+		case mcp.JSONRPCError:
+			return resp.Error.Code
+		}
+		t.Fatalf("unexpected response %#v", response)
+		return 0
+	}
+
+	// Closed: failures go through until the threshold is reached
+	assert.Equal(t, mcp.INTERNAL_ERROR, callTool("flaky"))
+	assert.Equal(t, mcp.INTERNAL_ERROR, callTool("flaky"))
+	assert.Equal(t, 2, calls)
+
+	// Open: requests are rejected without calling the handler
+	assert.Equal(t, mcp.SERVICE_UNAVAILABLE, callTool("flaky"))
+	assert.Equal(t, 2, calls)
+	// Other tools have their own circuit
+	assert.Equal(t, 0, callTool("healthy"))
+
+	// Half-open: a failing trial request opens the circuit again
+	time.Sleep(resetTimeout)
+	assert.Equal(t, mcp.INTERNAL_ERROR, callTool("flaky"))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, mcp.SERVICE_UNAVAILABLE, callTool("flaky"))
+	assert.Equal(t, 3, calls)
+
+	// Half-open: a successful trial request closes the circuit
+	failing = false
+	time.Sleep(resetTimeout)
+	assert.Equal(t, 0, callTool("flaky"))
+	assert.Equal(t, 0, callTool("flaky"))
+	assert.Equal(t, 5, calls)
+
+	// Closed again: the failure count starts over
+	failing = true
+	assert.Equal(t, mcp.INTERNAL_ERROR, callTool("flaky"))
+	assert.Equal(t, mcp.INTERNAL_ERROR, callTool("flaky"))
+	assert.Equal(t, mcp.SERVICE_UNAVAILABLE, callTool("flaky"))
+	require.Equal(t, 7, calls)
+}
+
+func TestCircuitBreaker_HalfOpenAllowsSingleTrial(t *testing.T) {
+	breaker := &circuitBreaker{threshold: 1, resetTimeout: 0, circuits: make(map[string]*circuit)}
+	breaker.record("key", true)
+
+	assert.True(t, breaker.allow("key"))
+	// The trial request has not finished yet
+	assert.False(t, breaker.allow("key"))
+	breaker.record("key", false)
+	assert.True(t, breaker.allow("key"))
+	assert.True(t, breaker.allow("key"))
+}
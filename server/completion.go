@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCompletionValues is the maximum number of values of a completion result.
+const maxCompletionValues = 100
+
+// WithCompletions enables the completions capability, so that clients can
+// request completions for the arguments of tools and prompts that have
+// completion providers, set with their WithArgumentCompleter methods.
+func WithCompletions() ServerOption {
+	return func(s *MCPServer) {
+		s.capabilities.completions = mcp.ToBoolPtr(true)
+	}
+}
+
+func (s *MCPServer) handleComplete(
+	ctx context.Context,
+	id any,
+	request mcp.CompleteRequest,
+) (*mcp.CompleteResult, *requestError) {
+	ref, _ := request.Params.Ref.(map[string]any)
+	refType, _ := ref["type"].(string)
+	name, _ := ref["name"].(string)
+
+	var provider mcp.CompletionProvider
+	switch refType {
+	case mcp.RefTypePrompt:
+		s.promptsMu.RLock()
+		prompt, ok := s.prompts[name]
+		s.promptsMu.RUnlock()
+		if !ok {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.INVALID_PARAMS,
+				err:  fmt.Errorf("prompt '%s' not found: %w", name, ErrPromptNotFound),
+			}
+		}
+		provider = prompt.ArgumentCompleter(request.Params.Argument.Name)
+	case mcp.RefTypeTool:
+		tool, ok := s.lookupTool(ctx, name)
+		if !ok {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.INVALID_PARAMS,
+				err:  fmt.Errorf("tool '%s' not found: %w", name, ErrToolNotFound),
+			}
+		}
+		provider = tool.Tool.ArgumentCompleter(request.Params.Argument.Name)
+	case mcp.RefTypeResource:
+		// Resource template arguments have no completion providers
+	default:
+		return nil, &requestError{
+			id:   id,
+			code: mcp.INVALID_PARAMS,
+			err:  fmt.Errorf("unsupported completion reference type '%s'", refType),
+		}
+	}
+
+	values := []string{}
+	if provider != nil {
+		completed, err := provider.Complete(ctx, request.Params.Argument.Value)
+		if err != nil {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.INTERNAL_ERROR,
+				err:  err,
+			}
+		}
+		if completed != nil {
+			values = completed
+		}
+	}
+
+	result := &mcp.CompleteResult{}
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	result.Completion.Values = values
+	return result, nil
+}
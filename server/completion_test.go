@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_Completions(t *testing.T) {
+	languages := mcp.CompletionProviderFunc(func(ctx context.Context, prefix string) ([]string, error) {
+		var values []string
+		for _, language := range []string{"go", "python", "rust", "ruby"} {
+			if strings.HasPrefix(language, prefix) {
+				values = append(values, language)
+			}
+		}
+		return values, nil
+	})
+	many := mcp.CompletionProviderFunc(func(ctx context.Context, prefix string) ([]string, error) {
+		values := make([]string, 150)
+		for i := range values {
+			values[i] = fmt.Sprintf("%s%d", prefix, i)
+		}
+		return values, nil
+	})
+	failing := mcp.CompletionProviderFunc(func(ctx context.Context, prefix string) ([]string, error) {
+		return nil, errors.New("backend unavailable")
+	})
+
+	server := NewMCPServer("test-server", "1.0.0", WithCompletions())
+	server.AddTool(
+		mcp.NewTool("format", mcp.WithString("language")).
+			WithArgumentCompleter("language", languages).
+			WithArgumentCompleter("failing", failing),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	server.AddPrompt(
+		mcp.NewPrompt("review", mcp.WithArgument("file")).WithArgumentCompleter("file", many),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return &mcp.GetPromptResult{}, nil
+		},
+	)
+
+	complete := func(ref, argument string) mcp.JSONRPCMessage {
+		return server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "completion/complete",
+			"params": {"ref": `+ref+`, "argument": `+argument+`}
+		}`))
+	}
+
+	t.Run("tool argument", func(t *testing.T) {
+		response := complete(`{"type": "ref/tool", "name": "format"}`, `{"name": "language", "value": "ru"}`)
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		result, ok := resp.Result.(mcp.CompleteResult)
+		require.True(t, ok)
+		assert.Equal(t, []string{"rust", "ruby"}, result.Completion.Values)
+		assert.Equal(t, 2, result.Completion.Total)
+		assert.False(t, result.Completion.HasMore)
+	})
+
+	t.Run("prompt argument is truncated", func(t *testing.T) {
+		response := complete(`{"type": "ref/prompt", "name": "review"}`, `{"name": "file", "value": "f"}`)
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		result, ok := resp.Result.(mcp.CompleteResult)
+		require.True(t, ok)
+		assert.Len(t, result.Completion.Values, maxCompletionValues)
+		assert.Equal(t, 150, result.Completion.Total)
+		assert.True(t, result.Completion.HasMore)
+	})
+
+	t.Run("argument without provider", func(t *testing.T) {
+		response := complete(`{"type": "ref/tool", "name": "format"}`, `{"name": "other", "value": ""}`)
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		result, ok := resp.Result.(mcp.CompleteResult)
+		require.True(t, ok)
+		assert.Empty(t, result.Completion.Values)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		response := complete(`{"type": "ref/tool", "name": "format"}`, `{"name": "failing", "value": ""}`)
+		errResp, ok := response.(mcp.JSONRPCError)
+		require.True(t, ok, "unexpected response %#v", response)
+		assert.Equal(t, mcp.INTERNAL_ERROR, errResp.Error.Code)
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		response := complete(`{"type": "ref/tool", "name": "missing"}`, `{"name": "language", "value": ""}`)
+		errResp, ok := response.(mcp.JSONRPCError)
+		require.True(t, ok, "unexpected response %#v", response)
+		assert.Equal(t, mcp.INVALID_PARAMS, errResp.Error.Code)
+	})
+}
+
+func TestMCPServer_CompletionsCapability(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "completion/complete",
+		"params": {"ref": {"type": "ref/prompt", "name": "review"}, "argument": {"name": "file", "value": ""}}
+	}`))
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, mcp.METHOD_NOT_FOUND, errResp.Error.Code)
+
+	server = NewMCPServer("test-server", "1.0.0", WithCompletions())
+	response = server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "initialize",
+		"params": {"protocolVersion": "2025-03-26", "clientInfo": {"name": "client", "version": "1.0.0"}}
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	result, ok := resp.Result.(mcp.InitializeResult)
+	require.True(t, ok)
+	assert.NotNil(t, result.Capabilities.Completions)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// WithCompression sets whether the HTTP transports of the server, SSEServer
+// and StreamableHTTPServer, gzip-compress their responses to the clients
+// accepting it with an Accept-Encoding: gzip header. Streamed responses are
+// compressed too, each event being flushed to the client as it is written.
+func WithCompression(enabled bool) ServerOption {
+	return func(s *MCPServer) {
+		s.compression = enabled
+	}
+}
+
+// compressResponse returns the writer of the response to r, which
+// gzip-compresses what is written to w if server enables compression and the
+// client accepts it. The returned function completes the compressed stream
+// and must be called once the response is written.
+func compressResponse(server *MCPServer, w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	if server == nil || !server.compression || !acceptsGzip(r) {
+		return w, func() {}
+	}
+	gw := &gzipResponseWriter{ResponseWriter: w}
+	return gw, gw.close
+}
+
+// compressHandler wraps handler to compress its responses as
+// compressResponse does.
+func compressHandler(server *MCPServer, handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w, done := compressResponse(server, w, r)
+		defer done()
+		handler(w, r)
+	})
+}
+
+// acceptsGzip reports whether the client of r accepts gzip-encoded responses.
+func acceptsGzip(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(value, ",") {
+			coding, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+			if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+				continue
+			}
+			// A quality of zero refuses the coding
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter is an http.ResponseWriter gzip-compressing the body of
+// the response. Responses without body, such as 204 No Content, are left
+// uncompressed.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if statusCode != http.StatusNoContent && statusCode != http.StatusNotModified {
+		header := w.Header()
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", "gzip")
+		header.Add("Vary", "Accept-Encoding")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		// Sniff the content type from the uncompressed body
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.gz.Write(b)
+}
+
+// Flush sends the data written so far to the client.
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close completes the compressed stream.
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCompression(t *testing.T) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 1<<20/45)
+	newServer := func(opts ...ServerOption) *httptest.Server {
+		mcpServer := NewMCPServer("test", "1.0.0", opts...)
+		mcpServer.AddTool(mcp.NewTool("large"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		})
+		return httptest.NewServer(NewStreamableHTTPServer(mcpServer, WithStateLess(true)))
+	}
+	callLarge := func(t *testing.T, url string) *http.Response {
+		request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(
+			`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "large"}}`,
+		))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		// Set explicitly, so the client does not decompress the response itself
+		request.Header.Set("Accept-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("compressed", func(t *testing.T) {
+		server := newServer(WithCompression(true))
+		defer server.Close()
+
+		resp := callLarge(t, server.URL)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		compressed, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Less(t, len(compressed), len(text)/20)
+
+		reader, err := gzip.NewReader(strings.NewReader(string(compressed)))
+		require.NoError(t, err)
+		var response struct {
+			Result mcp.CallToolResult `json:"result"`
+		}
+		require.NoError(t, json.NewDecoder(reader).Decode(&response))
+		require.Len(t, response.Result.Content, 1)
+		assert.Equal(t, text, response.Result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		resp := callLarge(t, server.URL)
+		defer resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Greater(t, len(body), len(text))
+	})
+
+	t.Run("decompressed by the client", func(t *testing.T) {
+		server := newServer(WithCompression(true))
+		defer server.Close()
+
+		resp, err := http.Post(server.URL, "application/json", strings.NewReader(
+			`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`,
+		))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		// The client decompresses the responses to its own Accept-Encoding
+		assert.True(t, resp.Uncompressed)
+		var response map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Equal(t, float64(1), response["id"])
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		server := newServer(WithCompression(true))
+		defer server.Close()
+
+		request, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(
+			`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`,
+		))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept-Encoding", "identity")
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	})
+}
+
+func TestWithCompression_SSE(t *testing.T) {
+	mcpServer := NewMCPServer("test", "1.0.0", WithCompression(true))
+	server := NewTestServer(mcpServer)
+	defer server.Close()
+
+	// The events of the stream are flushed as they are written
+	resp, err := http.Get(server.URL + "/sse")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.True(t, resp.Uncompressed)
+	buf := make([]byte, 1024)
+	n, err := resp.Body.Read(buf)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf[:n]), "event: endpoint")
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := map[string]bool{
+		"":                    false,
+		"gzip":                true,
+		"deflate, gzip;q=1.0": true,
+		"br, GZIP":            true,
+		"gzip;q=0":            false,
+		"identity":            false,
+	}
+	for value, expected := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if value != "" {
+			r.Header.Set("Accept-Encoding", value)
+		}
+		assert.Equal(t, expected, acceptsGzip(r), value)
+	}
+}
@@ -1,8 +1,21 @@
 package server
 
+import (
+	"context"
+	"net/http"
+)
+
 type contextKey int
 
 const (
 	// This const is used as key for context value lookup
 	requestHeader contextKey = iota
 )
+
+// RequestHeaderFromContext returns the headers of the HTTP request that
+// carried the message being handled, or nil for messages received over other
+// transports, such as stdio.
+func RequestHeaderFromContext(ctx context.Context) http.Header {
+	header, _ := ctx.Value(requestHeader).(http.Header)
+	return header
+}
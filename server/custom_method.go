@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// JSONRPCHandler handles requests of a custom JSON-RPC method. The result is
+// sent as the result of the response. An error is sent as an INTERNAL_ERROR
+// response, unless it is an *mcp.MCPError determining the code and data.
+type JSONRPCHandler func(ctx context.Context, req *mcp.JSONRPCRequest) (any, error)
+
+// reservedMethods are the methods of the MCP specification, which cannot be
+// handled by custom handlers.
+var reservedMethods = map[mcp.MCPMethod]bool{
+	mcp.MethodInitialize:             true,
+	mcp.MethodPing:                   true,
+	mcp.MethodResourcesList:          true,
+	mcp.MethodResourcesTemplatesList: true,
+	mcp.MethodResourcesRead:          true,
+	"resources/subscribe":            true,
+	"resources/unsubscribe":          true,
+	mcp.MethodPromptsList:            true,
+	mcp.MethodPromptsGet:             true,
+	mcp.MethodToolsList:              true,
+	mcp.MethodToolsCall:              true,
+	mcp.MethodCompletionComplete:     true,
+	mcp.MethodSetLogLevel:            true,
+	mcp.MethodElicitationCreate:      true,
+	mcp.MethodListRoots:              true,
+	mcp.MethodSamplingCreateMessage:  true,
+}
+
+// Handle registers handler for requests of a custom JSON-RPC method, for
+// extensions beyond the MCP specification. Requests of methods the server
+// does not implement are dispatched to the handler of their method.
+// Registering a method of the MCP specification, a notification method or a
+// method starting with "rpc." returns an error wrapping
+// ErrInvalidRegistration. Registering a method again replaces its handler.
+func (s *MCPServer) Handle(method string, handler JSONRPCHandler) error {
+	if handler == nil {
+		return fmt.Errorf("method %q has no handler: %w", method, ErrInvalidRegistration)
+	}
+	if method == "" || reservedMethods[mcp.MCPMethod(method)] ||
+		strings.HasPrefix(method, "notifications/") || strings.HasPrefix(method, "rpc.") {
+		return fmt.Errorf("method %q is reserved: %w", method, ErrInvalidRegistration)
+	}
+
+	s.customMethodsMu.Lock()
+	defer s.customMethodsMu.Unlock()
+	if s.customMethods == nil {
+		s.customMethods = make(map[string]JSONRPCHandler)
+	}
+	s.customMethods[method] = handler
+	return nil
+}
+
+// handleCustomMethod dispatches a request of a method the server does not
+// implement to its custom handler.
+func (s *MCPServer) handleCustomMethod(
+	ctx context.Context,
+	baseMessage jsonrpcEnvelope,
+	message json.RawMessage,
+) mcp.JSONRPCMessage {
+	s.customMethodsMu.RLock()
+	handler, ok := s.customMethods[string(baseMessage.Method)]
+	s.customMethodsMu.RUnlock()
+	if !ok {
+		return createErrorResponse(
+			baseMessage.ID,
+			mcp.METHOD_NOT_FOUND,
+			fmt.Sprintf("Method %s not found", baseMessage.Method),
+		)
+	}
+
+	var request mcp.JSONRPCRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return (&requestError{
+			id:   baseMessage.ID,
+			code: mcp.INVALID_REQUEST,
+			err:  &UnparsableMessageError{message: message, err: err, method: baseMessage.Method},
+		}).ToJSONRPCError()
+	}
+
+	result, err := handler(ctx, &request)
+	if err != nil {
+		return (&requestError{
+			id:   baseMessage.ID,
+			code: mcp.INTERNAL_ERROR,
+			err:  err,
+		}).ToJSONRPCError()
+	}
+	return createResponse(baseMessage.ID, result)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_Handle(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	require.NoError(t, server.Handle("acme/echo", func(ctx context.Context, req *mcp.JSONRPCRequest) (any, error) {
+		return map[string]any{"method": req.Method, "params": req.Params}, nil
+	}))
+	require.NoError(t, server.Handle("acme/fail", func(ctx context.Context, req *mcp.JSONRPCRequest) (any, error) {
+		return nil, mcp.NewMCPError(-32001, "quota exceeded", map[string]any{"limit": 10})
+	}))
+	require.NoError(t, server.Handle("acme/broken", func(ctx context.Context, req *mcp.JSONRPCRequest) (any, error) {
+		return nil, errors.New("boom")
+	}))
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "acme/echo",
+		"params": {"value": 42}
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, map[string]any{
+		"method": "acme/echo",
+		"params": map[string]any{"value": float64(42)},
+	}, resp.Result)
+
+	response = server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 2, "method": "acme/fail"}`))
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, -32001, errResp.Error.Code)
+	assert.Equal(t, "quota exceeded", errResp.Error.Message)
+	assert.Equal(t, map[string]any{"limit": 10}, errResp.Error.Data)
+
+	response = server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 3, "method": "acme/broken"}`))
+	errResp, ok = response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INTERNAL_ERROR, errResp.Error.Code)
+	assert.Equal(t, "boom", errResp.Error.Message)
+
+	// Methods without a handler are still not found
+	response = server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 4, "method": "acme/other"}`))
+	errResp, ok = response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.METHOD_NOT_FOUND, errResp.Error.Code)
+}
+
+func TestMCPServer_HandleReservedMethod(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	handler := func(ctx context.Context, req *mcp.JSONRPCRequest) (any, error) { return nil, nil }
+
+	for _, method := range []string{"tools/call", "initialize", "resources/subscribe", "notifications/custom", "rpc.discover", ""} {
+		err := server.Handle(method, handler)
+		assert.ErrorIs(t, err, ErrInvalidRegistration, method)
+	}
+	assert.ErrorIs(t, server.Handle("acme/nil", nil), ErrInvalidRegistration)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/sync/singleflight"
+)
+
+// NewDeduplicatingMiddleware returns a middleware that collapses identical
+// requests handled at the same time into one: the first one is handled, and
+// the others wait for its response, which they get with their own ID.
+// Requests are identical if they come from the same session with the same
+// method and parameters, ignoring _meta. Since the shared request runs with
+// the context of the first one, canceling that request cancels all of them.
+func NewDeduplicatingMiddleware() MiddlewareFunc {
+	var group singleflight.Group
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+			key, ok := requestKey(ctx, request)
+			if !ok {
+				return next(ctx, request)
+			}
+
+			response, _, _ := group.Do(key, func() (any, error) {
+				return next(ctx, request), nil
+			})
+			return withResponseID(response, request.ID)
+		}
+	}
+}
+
+// requestKey returns the key identifying requests identical to request. It
+// returns false if the parameters of the request cannot be encoded, in which
+// case the request is not deduplicated.
+func requestKey(ctx context.Context, request *mcp.JSONRPCRequest) (string, bool) {
+	params := request.Params
+	if m, ok := params.(map[string]any); ok {
+		if _, hasMeta := m["_meta"]; hasMeta {
+			withoutMeta := make(map[string]any, len(m))
+			for k, v := range m {
+				if k != "_meta" {
+					withoutMeta[k] = v
+				}
+			}
+			params = withoutMeta
+		}
+	}
+
+	// Maps are encoded with sorted keys, so equal parameters get equal keys
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+
+	var sessionID string
+	if session := ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+	key, err := json.Marshal([]string{sessionID, request.Method, string(encoded)})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// withResponseID returns response addressed to the request with the given ID.
+func withResponseID(response mcp.JSONRPCMessage, id mcp.RequestId) mcp.JSONRPCMessage {
+	switch r := response.(type) {
+	case mcp.JSONRPCResponse:
+		r.ID = id
+		return r
+	case mcp.JSONRPCError:
+		r.ID = id
+		return r
+	}
+	return response
+}
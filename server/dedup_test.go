@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeduplicatingMiddleware(t *testing.T) {
+	// Count the requests reaching the deduplicating middleware
+	var arrived atomic.Int32
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddMiddlewareByName("count", func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+			arrived.Add(1)
+			return next(ctx, request)
+		}
+	})
+	server.AddMiddlewareByName("dedup", NewDeduplicatingMiddleware())
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server.AddTool(mcp.NewTool("slow", mcp.WithString("input")), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls.Add(1)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	const requests = 5
+	responses := make([]mcp.JSONRPCMessage, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = server.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+				"jsonrpc": "2.0",
+				"id": %d,
+				"method": "tools/call",
+				"params": {"name": "slow", "arguments": {"input": "same"}}
+			}`, i+1)))
+		}()
+	}
+
+	// Wait for the first request to be in flight and the others to join it
+	require.Eventually(t, func() bool {
+		return calls.Load() == 1 && arrived.Load() == requests
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for i, response := range responses {
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		assert.Equal(t, mcp.NewRequestId(int64(i+1)).String(), resp.ID.String())
+		result, ok := resp.Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		assert.Equal(t, "done", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	// Requests made after the first one finished are handled again
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 6,
+		"method": "tools/call",
+		"params": {"name": "slow", "arguments": {"input": "same"}}
+	}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRequestKey(t *testing.T) {
+	request := func(method string, params any) *mcp.JSONRPCRequest {
+		r := &mcp.JSONRPCRequest{Params: params}
+		r.Method = method
+		return r
+	}
+	key := func(r *mcp.JSONRPCRequest) string {
+		k, ok := requestKey(context.Background(), r)
+		require.True(t, ok)
+		return k
+	}
+
+	base := key(request("tools/call", map[string]any{"name": "a", "arguments": map[string]any{"x": 1.0}}))
+	assert.Equal(t, base, key(request("tools/call", map[string]any{
+		"name":      "a",
+		"arguments": map[string]any{"x": 1.0},
+		"_meta":     map[string]any{"progressToken": "token"},
+	})))
+	assert.NotEqual(t, base, key(request("tools/call", map[string]any{"name": "a", "arguments": map[string]any{"x": 2.0}})))
+	assert.NotEqual(t, base, key(request("prompts/get", map[string]any{"name": "a", "arguments": map[string]any{"x": 1.0}})))
+}
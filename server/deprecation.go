@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithDeprecatedToolWarnings adds a middleware that logs a warning through
+// slog.Default whenever a client calls a tool marked as deprecated.
+func WithDeprecatedToolWarnings() ServerOption {
+	return func(s *MCPServer) {
+		WithToolHandlerMiddleware(s.DeprecatedToolWarning())(s)
+	}
+}
+
+// DeprecatedToolWarning returns a tool handler middleware that logs a warning
+// through slog.Default whenever a client calls a tool marked as deprecated
+// with mcp.Tool.Deprecate. The call itself is not affected.
+func (s *MCPServer) DeprecatedToolWarning() ToolHandlerMiddleware {
+	return func(next ToolHandlerFunc) ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if tool, ok := s.lookupTool(ctx, request.Params.Name); ok && tool.Tool.Deprecated {
+				attrs := []any{slog.String("tool", request.Params.Name)}
+				if tool.Tool.DeprecationMessage != "" {
+					attrs = append(attrs, slog.String("reason", tool.Tool.DeprecationMessage))
+				}
+				if session := ClientSessionFromContext(ctx); session != nil {
+					attrs = append(attrs, slog.String("session", session.SessionID()))
+				}
+				slog.WarnContext(ctx, "deprecated tool called", attrs...)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// notifyDeprecatedToolCall sends the client calling a deprecated tool a
+// warning log message with the deprecation message, before the tool runs.
+// Clients that did not enable logging, or set a level above warning, are not
+// notified.
+func (s *MCPServer) notifyDeprecatedToolCall(ctx context.Context, tool mcp.Tool) {
+	message := fmt.Sprintf("tool '%s' is deprecated", tool.Name)
+	if tool.DeprecationMessage != "" {
+		message += ": " + tool.DeprecationMessage
+	}
+	// Errors only mean that the session cannot receive log messages
+	_ = s.SendLogMessageToClient(ctx, mcp.NewLoggingMessageNotification(mcp.LoggingLevelWarning, s.name, message))
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_DeprecatedToolWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	server := NewMCPServer("test-server", "1.0.0", WithDeprecatedToolWarnings())
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	server.AddTool(mcp.NewTool("old-tool").Deprecate("use new-tool instead"), handler)
+	server.AddTool(mcp.NewTool("new-tool"), handler)
+
+	callTool := func(name string) {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "`+name+`"}
+		}`))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok)
+	}
+
+	callTool("new-tool")
+	assert.Empty(t, buf.String())
+
+	callTool("old-tool")
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "WARN", entries[0]["level"])
+	assert.Equal(t, "deprecated tool called", entries[0]["msg"])
+	assert.Equal(t, "old-tool", entries[0]["tool"])
+	assert.Equal(t, "use new-tool instead", entries[0]["reason"])
+}
+
+func TestMCPServer_DeprecatedToolNotification(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithLogging())
+	ctx := context.Background()
+
+	sessionChan := make(chan mcp.JSONRPCNotification, 10)
+	var notified bool
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// The notification is sent before the handler runs
+		notified = len(sessionChan) > 0
+		return mcp.NewToolResultText("ok"), nil
+	}
+	server.AddTool(mcp.NewTool("old-tool").Deprecate("use new-tool instead"), handler)
+	server.AddTool(mcp.NewTool("new-tool"), handler)
+
+	// The session is registered after the tools, so it gets no list_changed notifications
+	session := &sessionTestClientWithLogging{
+		sessionID:           "session-1",
+		notificationChannel: sessionChan,
+	}
+	session.Initialize()
+	session.SetLogLevel(mcp.LoggingLevelInfo)
+	require.NoError(t, server.RegisterSession(ctx, session))
+	sessionCtx := server.WithContext(ctx, session)
+
+	callTool := func(name string) {
+		response := server.HandleMessage(sessionCtx, []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "`+name+`"}
+		}`))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok)
+	}
+
+	callTool("new-tool")
+	assert.False(t, notified)
+	assert.Empty(t, sessionChan)
+
+	callTool("old-tool")
+	assert.True(t, notified)
+	require.Len(t, sessionChan, 1)
+	notification := <-sessionChan
+	assert.Equal(t, "notifications/message", notification.Method)
+	assert.Equal(t, mcp.LoggingLevelWarning, notification.Params.AdditionalFields["level"])
+	assert.Equal(t, "tool 'old-tool' is deprecated: use new-tool instead", notification.Params.AdditionalFields["data"])
+}
+
+func TestMCPServer_ListToolsExcludeDeprecated(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	server.AddTool(mcp.NewTool("old-tool").Deprecate("use new-tool instead"), handler)
+	server.AddTool(mcp.NewTool("new-tool"), handler)
+
+	listTools := func(params string) []mcp.Tool {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/list",
+			"params": `+params+`
+		}`))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		result, ok := resp.Result.(mcp.ListToolsResult)
+		require.True(t, ok)
+		return result.Tools
+	}
+
+	tools := listTools(`{}`)
+	require.Len(t, tools, 2)
+	assert.Equal(t, "new-tool", tools[0].Name)
+	assert.Equal(t, "old-tool", tools[1].Name)
+	assert.True(t, tools[1].Deprecated)
+
+	tools = listTools(`{"excludeDeprecated": true}`)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "new-tool", tools[0].Name)
+}
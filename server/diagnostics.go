@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DiagnosticsMethod is the non-standard method answered by servers created
+// with WithDiagnosticsEndpoint.
+const DiagnosticsMethod = "mcp/diagnostics"
+
+// Diagnostics is the result of a DiagnosticsMethod request.
+type Diagnostics struct {
+	// UptimeSeconds is the time since the server was created.
+	UptimeSeconds float64 `json:"uptimeSeconds"`
+	// Tools, Resources, ResourceTemplates and Prompts are the numbers of
+	// items registered with the server, not including session-specific ones.
+	Tools             int `json:"tools"`
+	Resources         int `json:"resources"`
+	ResourceTemplates int `json:"resourceTemplates"`
+	Prompts           int `json:"prompts"`
+	// ActiveConnections is the number of registered client sessions.
+	ActiveConnections int `json:"activeConnections"`
+	// Calls are the statistics of the requests handled since the server was
+	// created, keyed by tool (as "tool 'name'") or method.
+	Calls map[string]CallDiagnostics `json:"calls"`
+}
+
+// CallDiagnostics are the statistics of the requests to a tool or method.
+type CallDiagnostics struct {
+	Count            int64   `json:"count"`
+	AverageLatencyMs float64 `json:"averageLatencyMs"`
+}
+
+// WithDiagnosticsEndpoint makes the server answer DiagnosticsMethod requests
+// with its Diagnostics, to help troubleshooting. As it exposes details about
+// the server to every client, it is best left out of production servers.
+func WithDiagnosticsEndpoint() ServerOption {
+	return func(s *MCPServer) {
+		d := &diagnosticsRecorder{
+			startedAt: time.Now(),
+			calls:     make(map[string]*callTotals),
+		}
+		s.AddMiddlewareByName("diagnostics", func(next RequestHandlerFunc) RequestHandlerFunc {
+			return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+				if request.Method == DiagnosticsMethod {
+					return mcp.NewJSONRPCResultResponse(request.ID, s.diagnostics(d))
+				}
+				start := time.Now()
+				response := next(ctx, request)
+				d.observe(requestTarget(request), time.Since(start))
+				return response
+			}
+		})
+	}
+}
+
+// diagnosticsRecorder records the requests handled by the server.
+type diagnosticsRecorder struct {
+	startedAt time.Time
+
+	mu    sync.Mutex
+	calls map[string]*callTotals
+}
+
+type callTotals struct {
+	count int64
+	total time.Duration
+}
+
+func (d *diagnosticsRecorder) observe(target string, duration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, ok := d.calls[target]
+	if !ok {
+		c = &callTotals{}
+		d.calls[target] = c
+	}
+	c.count++
+	c.total += duration
+}
+
+func (s *MCPServer) diagnostics(d *diagnosticsRecorder) Diagnostics {
+	diagnostics := Diagnostics{
+		UptimeSeconds: time.Since(d.startedAt).Seconds(),
+		Tools:         len(s.tools.snapshot()),
+	}
+
+	s.resourcesMu.RLock()
+	diagnostics.Resources = len(s.resources)
+	diagnostics.ResourceTemplates = len(s.resourceTemplates)
+	s.resourcesMu.RUnlock()
+
+	s.promptsMu.RLock()
+	diagnostics.Prompts = len(s.prompts)
+	s.promptsMu.RUnlock()
+
+	s.sessions.Range(func(_, _ any) bool {
+		diagnostics.ActiveConnections++
+		return true
+	})
+
+	d.mu.Lock()
+	diagnostics.Calls = make(map[string]CallDiagnostics, len(d.calls))
+	for target, c := range d.calls {
+		diagnostics.Calls[target] = CallDiagnostics{
+			Count:            c.count,
+			AverageLatencyMs: float64(c.total.Microseconds()) / 1000 / float64(c.count),
+		}
+	}
+	d.mu.Unlock()
+
+	return diagnostics
+}
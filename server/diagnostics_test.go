@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDiagnosticsEndpoint(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithDiagnosticsEndpoint())
+	for i := range 5 {
+		server.AddTool(mcp.NewTool(fmt.Sprintf("tool-%d", i)), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+	}
+	server.AddPrompt(mcp.NewPrompt("prompt"), nil)
+	server.AddResource(mcp.NewResource("file:///a", "a"), nil)
+
+	for i := range 2 {
+		response := server.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": %d,
+			"method": "tools/call",
+			"params": {"name": "tool-0"}
+		}`, i)))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+	}
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 10,
+		"method": "mcp/diagnostics"
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+
+	// Check the result as clients see it
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	var diagnostics Diagnostics
+	require.NoError(t, json.Unmarshal(data, &diagnostics))
+
+	assert.Equal(t, 5, diagnostics.Tools)
+	assert.Equal(t, 1, diagnostics.Prompts)
+	assert.Equal(t, 1, diagnostics.Resources)
+	assert.Equal(t, 0, diagnostics.ResourceTemplates)
+	assert.Equal(t, 0, diagnostics.ActiveConnections)
+	assert.Greater(t, diagnostics.UptimeSeconds, 0.0)
+	require.Contains(t, diagnostics.Calls, "tool 'tool-0'")
+	assert.Equal(t, int64(2), diagnostics.Calls["tool 'tool-0'"].Count)
+	assert.NotContains(t, diagnostics.Calls, DiagnosticsMethod)
+}
+
+func TestDiagnosticsMethod_DisabledByDefault(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "mcp/diagnostics"
+	}`))
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, mcp.METHOD_NOT_FOUND, errResp.Error.Code)
+}
@@ -25,7 +25,10 @@ func (s *MCPServer) RequestElicitation(ctx context.Context, request mcp.Elicitat
 
 	// Check if the session supports elicitation requests
 	if elicitationSession, ok := session.(SessionWithElicitation); ok {
-		return elicitationSession.RequestElicitation(ctx, request)
+		logResult := s.protocolLogger.logRequest(ctx, mcp.MethodElicitationCreate, request.Params)
+		result, err := elicitationSession.RequestElicitation(ctx, request)
+		logResult(result, err)
+		return result, err
 	}
 
 	return nil, ErrElicitationNotSupported
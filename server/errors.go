@@ -11,6 +11,9 @@ var (
 	ErrResourceNotFound = errors.New("resource not found")
 	ErrPromptNotFound   = errors.New("prompt not found")
 	ErrToolNotFound     = errors.New("tool not found")
+	ErrToolTimeout      = errors.New("tool call timed out") // Cause of the context of a call past the tool timeout
+	ErrServerShutdown   = errors.New("server is shutting down")
+	ErrRequestTooLarge  = errors.New("request body too large")
 
 	// Session-related errors
 	ErrSessionNotFound                        = errors.New("session not found")
@@ -21,6 +24,9 @@ var (
 	ErrSessionDoesNotSupportResourceTemplates = errors.New("session does not support resource templates")
 	ErrSessionDoesNotSupportLogging           = errors.New("session does not support setting logging level")
 
+	// Registration-related errors
+	ErrInvalidRegistration = errors.New("invalid registration")
+
 	// Notification-related errors
 	ErrNotificationNotInitialized = errors.New("notification channel not initialized")
 	ErrNotificationChannelBlocked = errors.New("notification channel queue is full - client may not be processing notifications fast enough")
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FSOption configures a resource provider created by
+// NewFilesystemResourceProvider.
+type FSOption func(*filesystemResourceProvider)
+
+// WithGlob restricts the provider to files matching pattern, using the syntax
+// of path.Match. Patterns without a slash are matched against the file name,
+// others against the slash-separated path relative to the root.
+func WithGlob(pattern string) FSOption {
+	return func(p *filesystemResourceProvider) {
+		p.glob = pattern
+	}
+}
+
+// WithMIMETypeDetection controls whether resources are given a MIME type based
+// on their file extension or, when reading, their content. It is enabled by
+// default.
+func WithMIMETypeDetection(enabled bool) FSOption {
+	return func(p *filesystemResourceProvider) {
+		p.detectMIMEType = enabled
+	}
+}
+
+// WithRecursive controls whether files in subdirectories of the root are
+// exposed. It is enabled by default.
+func WithRecursive(recursive bool) FSOption {
+	return func(p *filesystemResourceProvider) {
+		p.recursive = recursive
+	}
+}
+
+// filesystemResourceProvider exposes the regular files below a directory as
+// file:// resources.
+type filesystemResourceProvider struct {
+	root           string
+	glob           string
+	detectMIMEType bool
+	recursive      bool
+}
+
+// NewFilesystemResourceProvider creates a ResourceProvider exposing the files
+// below root as resources with file:// URIs. Files are read as text resources
+// when they hold valid UTF-8 and as blob resources otherwise. Symbolic links
+// pointing outside of root are never followed.
+func NewFilesystemResourceProvider(root string, opts ...FSOption) ResourceProvider {
+	p := &filesystemResourceProvider{
+		root:           root,
+		detectMIMEType: true,
+		recursive:      true,
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		p.root = abs
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// List implements ResourceProvider.
+func (p *filesystemResourceProvider) List(ctx context.Context) ([]mcp.Resource, error) {
+	var resources []mcp.Resource
+	err := filepath.WalkDir(p.root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(p.root, name)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && !p.recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !p.matches(rel) {
+			return nil
+		}
+
+		var opts []mcp.ResourceOption
+		if mimeType := p.mimeTypeByExtension(name); mimeType != "" {
+			opts = append(opts, mcp.WithMIMEType(mimeType))
+		}
+		resources = append(resources, mcp.NewResource(fileURI(name), rel, opts...))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", p.root, err)
+	}
+	return resources, nil
+}
+
+// Read implements ResourceProvider.
+func (p *filesystemResourceProvider) Read(ctx context.Context, uri string) (mcp.ResourceContents, error) {
+	name, err := p.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+
+	mimeType := p.mimeTypeByExtension(name)
+	if mimeType == "" && p.detectMIMEType {
+		mimeType = http.DetectContentType(data)
+	}
+
+	if utf8.Valid(data) {
+		return mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: mimeType,
+			Text:     string(data),
+		}, nil
+	}
+	return mcp.BlobResourceContents{
+		URI:      uri,
+		MIMEType: mimeType,
+		Blob:     base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+// resolve maps uri to the path of a file exposed by the provider. It returns
+// an error wrapping ErrResourceNotFound for URIs outside of the provider.
+func (p *filesystemResourceProvider) resolve(uri string) (string, error) {
+	notFound := fmt.Errorf("resource %s is not provided by %s: %w", uri, p.root, ErrResourceNotFound)
+
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || (u.Host != "" && u.Host != "localhost") {
+		return "", notFound
+	}
+
+	uriPath := u.Path
+	if filepath.VolumeName(strings.TrimPrefix(uriPath, "/")) != "" {
+		// Windows paths such as /C:/dir lose their leading slash
+		uriPath = strings.TrimPrefix(uriPath, "/")
+	}
+	name := filepath.Clean(filepath.FromSlash(uriPath))
+	rel, ok := p.relative(name)
+	if !ok || !p.matches(rel) {
+		return "", notFound
+	}
+
+	// Reject symbolic links escaping the root directory
+	resolved, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		return "", notFound
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(p.root)
+	if err != nil {
+		return "", notFound
+	}
+	if inside, err := filepath.Rel(resolvedRoot, resolved); err != nil || !filepath.IsLocal(inside) {
+		return "", notFound
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", notFound
+	}
+	return name, nil
+}
+
+// relative returns the slash-separated path of name relative to the root, if
+// name is below the root and allowed by the recursion setting.
+func (p *filesystemResourceProvider) relative(name string) (string, bool) {
+	rel, err := filepath.Rel(p.root, name)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	if !p.recursive && strings.Contains(rel, "/") {
+		return "", false
+	}
+	return rel, true
+}
+
+// matches reports whether the file at the slash-separated relative path rel
+// matches the glob pattern of the provider.
+func (p *filesystemResourceProvider) matches(rel string) bool {
+	if p.glob == "" {
+		return true
+	}
+	target := rel
+	if !strings.Contains(p.glob, "/") {
+		target = path.Base(rel)
+	}
+	matched, err := path.Match(p.glob, target)
+	return err == nil && matched
+}
+
+func (p *filesystemResourceProvider) mimeTypeByExtension(name string) string {
+	if !p.detectMIMEType {
+		return ""
+	}
+	return mime.TypeByExtension(filepath.Ext(name))
+}
+
+// fileURI returns the file:// URI of the absolute path name.
+func fileURI(name string) string {
+	slashed := filepath.ToSlash(name)
+	if !strings.HasPrefix(slashed, "/") {
+		// Windows paths such as C:/dir need a leading slash
+		slashed = "/" + slashed
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T, root string, files map[string][]byte) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+	}
+}
+
+func resourceNames(resources []mcp.Resource) []string {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+func TestFilesystemResourceProvider_List(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string][]byte{
+		"readme.md":       []byte("# Readme"),
+		"notes.txt":       []byte("notes"),
+		"docs/guide.md":   []byte("# Guide"),
+		"docs/img/a.png":  {0x89, 'P', 'N', 'G'},
+		"src/main.go":     []byte("package main"),
+		"src/lib/util.go": []byte("package lib"),
+	})
+
+	tests := []struct {
+		name     string
+		opts     []FSOption
+		expected []string
+	}{
+		{
+			name:     "all files",
+			expected: []string{"docs/guide.md", "docs/img/a.png", "notes.txt", "readme.md", "src/lib/util.go", "src/main.go"},
+		},
+		{
+			name:     "not recursive",
+			opts:     []FSOption{WithRecursive(false)},
+			expected: []string{"notes.txt", "readme.md"},
+		},
+		{
+			name:     "glob on file name",
+			opts:     []FSOption{WithGlob("*.md")},
+			expected: []string{"docs/guide.md", "readme.md"},
+		},
+		{
+			name:     "glob on relative path",
+			opts:     []FSOption{WithGlob("src/*.go")},
+			expected: []string{"src/main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewFilesystemResourceProvider(root, tt.opts...)
+			resources, err := provider.List(context.Background())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, resourceNames(resources))
+		})
+	}
+
+	resources, err := NewFilesystemResourceProvider(root, WithGlob("notes.txt")).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, fileURI(filepath.Join(root, "notes.txt")), resources[0].URI)
+	assert.Contains(t, resources[0].MIMEType, "text/plain")
+
+	resources, err = NewFilesystemResourceProvider(root, WithGlob("notes.txt"), WithMIMETypeDetection(false)).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Empty(t, resources[0].MIMEType)
+}
+
+func TestFilesystemResourceProvider_Read(t *testing.T) {
+	root := t.TempDir()
+	binary := []byte{0x89, 'P', 'N', 'G', 0xff, 0xfe}
+	writeTestFiles(t, root, map[string][]byte{
+		"hello.txt":    []byte("hello"),
+		"image.png":    binary,
+		"sub/deep.txt": []byte("deep"),
+	})
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("secret"), 0o644))
+
+	provider := NewFilesystemResourceProvider(root)
+	ctx := context.Background()
+
+	contents, err := provider.Read(ctx, fileURI(filepath.Join(root, "hello.txt")))
+	require.NoError(t, err)
+	text, ok := contents.(mcp.TextResourceContents)
+	require.True(t, ok, "expected TextResourceContents, got %T", contents)
+	assert.Equal(t, "hello", text.Text)
+	assert.Contains(t, text.MIMEType, "text/plain")
+
+	contents, err = provider.Read(ctx, fileURI(filepath.Join(root, "image.png")))
+	require.NoError(t, err)
+	blob, ok := contents.(mcp.BlobResourceContents)
+	require.True(t, ok, "expected BlobResourceContents, got %T", contents)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(binary), blob.Blob)
+	assert.Equal(t, "image/png", blob.MIMEType)
+
+	notFound := []string{
+		fileURI(outside),
+		fileURI(filepath.Join(root, "..", filepath.Base(filepath.Dir(outside)), "secret.txt")),
+		fileURI(filepath.Join(root, "missing.txt")),
+		fileURI(filepath.Join(root, "sub")),
+		"https://example.com/hello.txt",
+	}
+	for _, uri := range notFound {
+		_, err := provider.Read(ctx, uri)
+		assert.ErrorIs(t, err, ErrResourceNotFound, uri)
+	}
+
+	_, err = NewFilesystemResourceProvider(root, WithRecursive(false)).Read(ctx, fileURI(filepath.Join(root, "sub", "deep.txt")))
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+
+	_, err = NewFilesystemResourceProvider(root, WithGlob("*.png")).Read(ctx, fileURI(filepath.Join(root, "hello.txt")))
+	assert.ErrorIs(t, err, ErrResourceNotFound)
+
+	link := filepath.Join(root, "link.txt")
+	if err := os.Symlink(outside, link); err == nil {
+		_, err = provider.Read(ctx, fileURI(link))
+		assert.ErrorIs(t, err, ErrResourceNotFound)
+	}
+}
+
+func TestMCPServer_ResourceProviderPrecedence(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string][]byte{
+		"a.txt": []byte("from provider"),
+		"b.txt": []byte("shadowed"),
+	})
+
+	server := NewMCPServer("test-server", "1.0.0")
+	require.NoError(t, server.RegisterResourceProvider(NewFilesystemResourceProvider(root)))
+	server.AddResource(
+		mcp.NewResource(fileURI(filepath.Join(root, "b.txt")), "registered"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "registered"}}, nil
+		},
+	)
+
+	response := server.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	listResult, ok := resp.Result.(mcp.ListResourcesResult)
+	require.True(t, ok)
+	assert.Equal(t, []string{"a.txt", "registered"}, resourceNames(listResult.Resources))
+
+	readResource := func(uri string) mcp.JSONRPCMessage {
+		request := mcp.ReadResourceRequest{}
+		request.Params.URI = uri
+		message := mcp.JSONRPCRequest{
+			JSONRPC: mcp.JSONRPC_VERSION,
+			ID:      mcp.NewRequestId(int64(2)),
+			Request: mcp.Request{Method: string(mcp.MethodResourcesRead)},
+			Params:  request.Params,
+		}
+		data, err := json.Marshal(message)
+		require.NoError(t, err)
+		return server.HandleMessage(context.Background(), data)
+	}
+
+	response = readResource(fileURI(filepath.Join(root, "a.txt")))
+	resp, ok = response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	readResult, ok := resp.Result.(mcp.ReadResourceResult)
+	require.True(t, ok)
+	require.Len(t, readResult.Contents, 1)
+	assert.Equal(t, "from provider", readResult.Contents[0].(mcp.TextResourceContents).Text)
+
+	response = readResource(fileURI(filepath.Join(root, "b.txt")))
+	resp, ok = response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	readResult, ok = resp.Result.(mcp.ReadResourceResult)
+	require.True(t, ok)
+	assert.Equal(t, "registered", readResult.Contents[0].(mcp.TextResourceContents).Text)
+
+	response = readResource(fileURI(filepath.Join(root, "missing.txt")))
+	errorResponse, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "expected JSONRPCError, got %T", response)
+	assert.Equal(t, mcp.RESOURCE_NOT_FOUND, errorResponse.Error.Code)
+}
+
+// failingResourceProvider is a ResourceProvider whose List always fails.
+type failingResourceProvider struct {
+	err error
+}
+
+func (p failingResourceProvider) List(ctx context.Context) ([]mcp.Resource, error) {
+	return nil, p.err
+}
+
+func (p failingResourceProvider) Read(ctx context.Context, uri string) (mcp.ResourceContents, error) {
+	return nil, p.err
+}
+
+func TestMCPServer_RegisterResourceProvider(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string][]byte{"a.txt": []byte("from provider")})
+
+	server := NewMCPServer("test-server", "1.0.0", WithResourceCapabilities(false, true))
+	require.NoError(t, server.RegisterResourceProvider(NewFilesystemResourceProvider(root)))
+
+	response := server.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	listResult, ok := resp.Result.(mcp.ListResourcesResult)
+	require.True(t, ok)
+	assert.Equal(t, []string{"a.txt"}, resourceNames(listResult.Resources))
+
+	request := fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":%q}}`,
+		fileURI(filepath.Join(root, "a.txt")))
+	response = server.HandleMessage(context.Background(), []byte(request))
+	resp, ok = response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	readResult, ok := resp.Result.(mcp.ReadResourceResult)
+	require.True(t, ok)
+	require.Len(t, readResult.Contents, 1)
+	assert.Equal(t, "from provider", readResult.Contents[0].(mcp.TextResourceContents).Text)
+
+	// The registered resources are the baseline of Reload
+	notifications := make(chan mcp.JSONRPCNotification, 10)
+	require.NoError(t, server.RegisterSession(context.Background(), &fakeSession{
+		sessionID:           "test",
+		notificationChannel: notifications,
+		initialized:         true,
+	}))
+	writeTestFiles(t, root, map[string][]byte{"b.txt": []byte("new")})
+	require.NoError(t, server.Reload())
+	assert.Equal(t, []string{mcp.MethodNotificationResourcesListChanged}, drainNotifications(notifications))
+}
+
+func TestMCPServer_RegisterResourceProviderError(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	err := server.RegisterResourceProvider(failingResourceProvider{err: errors.New("directory unavailable")})
+	assert.ErrorContains(t, err, "directory unavailable")
+	assert.Empty(t, server.resourceProviders)
+	assert.Nil(t, server.capabilities.resources)
+}
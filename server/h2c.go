@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"net"
+)
+
+// ServeWithH2C serves the server on addr with a MultiplexedTransport, the
+// Streamable HTTP transport at its default endpoint path over cleartext
+// HTTP/2 (h2c) as well as HTTP/1.1, for internal networks where TLS is
+// terminated elsewhere, such as by a sidecar. It runs until ctx is cancelled, then shuts down the transport
+// within the shutdown timeout of the server and returns nil.
+func (s *MCPServer) ServeWithH2C(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.serveH2C(ctx, listener)
+}
+
+// serveH2C serves the server over h2c on listener until ctx is cancelled.
+func (s *MCPServer) serveH2C(ctx context.Context, listener net.Listener) error {
+	transport := NewMultiplexedTransport(s)
+	return s.serveUntilDone(ctx, transport.StreamableHTTPServer, func() error {
+		return transport.httpServer.Serve(listener)
+	})
+}
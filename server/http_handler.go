@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPHandler serves an MCP server over both HTTP transports at a single
+// path, so that it can be mounted in an existing HTTP server:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/mcp", server.NewHTTPHandler(mcpServer))
+//
+// Each request is routed to the streamable HTTP transport or to the legacy
+// HTTP+SSE transport based on its method and headers: a GET accepting
+// text/event-stream without an Mcp-Session-Id header opens an SSE stream, and
+// a POST with a sessionId query parameter is a message for such a stream.
+// Everything else is handled by the streamable HTTP transport. SSE clients
+// are told to post their messages to the path the handler is mounted at,
+// without any trailing slash, so mount it at an exact path.
+//
+// Shutting down the MCP server shuts down both transports.
+type HTTPHandler struct {
+	sse        *SSEServer
+	streamable *StreamableHTTPServer
+}
+
+// HTTPHandlerOption configures an HTTPHandler.
+type HTTPHandlerOption func(*httpHandlerConfig)
+
+type httpHandlerConfig struct {
+	sseOpts        []SSEOption
+	streamableOpts []StreamableHTTPOption
+}
+
+// WithSSEOptions sets options for the HTTP+SSE transport of the handler, such
+// as WithKeepAlive or WithSSEContextFunc. Options setting endpoints or base
+// paths have no effect.
+func WithSSEOptions(opts ...SSEOption) HTTPHandlerOption {
+	return func(c *httpHandlerConfig) {
+		c.sseOpts = append(c.sseOpts, opts...)
+	}
+}
+
+// WithStreamableHTTPOptions sets options for the streamable HTTP transport of
+// the handler, such as WithStateLess or WithHTTPContextFunc.
+func WithStreamableHTTPOptions(opts ...StreamableHTTPOption) HTTPHandlerOption {
+	return func(c *httpHandlerConfig) {
+		c.streamableOpts = append(c.streamableOpts, opts...)
+	}
+}
+
+// NewHTTPHandler creates an http.Handler serving server over the streamable
+// HTTP and HTTP+SSE transports.
+func NewHTTPHandler(server *MCPServer, opts ...HTTPHandlerOption) *HTTPHandler {
+	config := &httpHandlerConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	sseOpts := append(config.sseOpts,
+		// Messages are posted to the path the handler is mounted at
+		WithDynamicBasePath(func(r *http.Request, sessionID string) string {
+			return mountPath(r)
+		}),
+		WithMessageEndpoint(""),
+		WithUseFullURLForMessageEndpoint(false),
+	)
+
+	return &HTTPHandler{
+		sse:        NewSSEServer(server, sseOpts...),
+		streamable: NewStreamableHTTPServer(server, config.streamableOpts...),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	hasSession := r.Header.Get(HeaderKeySessionID) != ""
+	switch {
+	case r.Method == http.MethodGet && !hasSession && acceptsEventStream(r):
+		h.sse.handleSSE(w, r)
+	case r.Method == http.MethodPost && !hasSession && r.URL.Query().Has("sessionId"):
+		h.sse.handleMessage(w, r)
+	default:
+		h.streamable.ServeHTTP(w, r)
+	}
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		if strings.Contains(accept, "text/event-stream") {
+			return true
+		}
+	}
+	return false
+}
+
+// mountPath returns the path the client requested, before any prefix was
+// stripped by http.StripPrefix.
+func mountPath(r *http.Request) string {
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil && u.Path != "" {
+		return u.Path
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInitializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"test","version":"1.0.0"},"capabilities":{}}}`
+
+func newMountedTestServer(t *testing.T, pattern string, handler http.Handler) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.Handle(pattern, handler)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestHTTPHandler_StreamableHTTP(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0")
+	ts := newMountedTestServer(t, "/mcp", NewHTTPHandler(mcpServer))
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/mcp", strings.NewReader(testInitializeRequest))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(HeaderKeySessionID))
+	var response mcp.JSONRPCResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, mcp.NewRequestId(int64(1)), response.ID)
+
+	// The rest of the mux is unaffected
+	resp, err = http.Get(ts.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestHTTPHandler_SSE(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0")
+	ts := newMountedTestServer(t, "/api/mcp", http.StripPrefix("/api", NewHTTPHandler(mcpServer)))
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	sseResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer sseResp.Body.Close()
+	assert.Equal(t, "text/event-stream", sseResp.Header.Get("Content-Type"))
+
+	events := make(chan string, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(sseResp.Body)
+		for scanner.Scan() {
+			if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+				events <- data
+			}
+		}
+	}()
+	nextEvent := func() string {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("no SSE event received")
+			return ""
+		}
+	}
+
+	// The message endpoint is the path the handler is mounted at, including
+	// the stripped prefix
+	endpoint := nextEvent()
+	assert.True(t, strings.HasPrefix(endpoint, "/api/mcp?sessionId="), endpoint)
+
+	resp, err := http.Post(ts.URL+endpoint, "application/json",
+		bytes.NewReader([]byte(testInitializeRequest)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var response mcp.JSONRPCResponse
+	require.NoError(t, json.Unmarshal([]byte(nextEvent()), &response))
+	assert.Equal(t, mcp.NewRequestId(int64(1)), response.ID)
+}
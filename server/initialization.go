@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithStrictInitialization makes the server reject with INVALID_REQUEST the
+// requests other than initialize and ping that a client sends before
+// notifications/initialized, as the specification requires clients to wait
+// for the initialization to complete. It applies to the sessions of all the
+// transports of the package, except those of stateless streamable HTTP
+// servers, which cannot be tracked, and to custom sessions implementing
+// SessionWithInitialization.
+func WithStrictInitialization() ServerOption {
+	return func(s *MCPServer) {
+		s.strictInitialization = true
+	}
+}
+
+// SetInitializeTimeout sets how long the server waits for a client to send
+// notifications/initialized after the initialize request. Sessions that have
+// not sent it by then are unregistered and closed: stdio and SSE connections
+// are closed, and streamable HTTP sessions, multiplexed ones included, are
+// terminated as by a DELETE request. It applies to the same sessions as
+// WithStrictInitialization, and to initialize requests handled after the
+// call. A zero duration, the default, waits forever.
+func (s *MCPServer) SetInitializeTimeout(d time.Duration) {
+	s.initializeTimeout.Store(int64(d))
+}
+
+// checkInitialized returns an error response if the request must be rejected
+// because the client has not sent notifications/initialized yet.
+func (s *MCPServer) checkInitialized(ctx context.Context, baseMessage jsonrpcEnvelope) mcp.JSONRPCMessage {
+	if !s.strictInitialization {
+		return nil
+	}
+	switch mcp.MCPMethod(baseMessage.Method) {
+	case mcp.MethodInitialize, mcp.MethodPing:
+		return nil
+	}
+	session, ok := ClientSessionFromContext(ctx).(SessionWithInitialization)
+	if !ok || session.ClientReady() {
+		return nil
+	}
+	return createErrorResponse(
+		baseMessage.ID,
+		mcp.INVALID_REQUEST,
+		"session is not initialized: the client has not sent notifications/initialized",
+	)
+}
+
+// markClientReady records that the client of the session in ctx sent
+// notifications/initialized.
+func (s *MCPServer) markClientReady(ctx context.Context) {
+	if session, ok := ClientSessionFromContext(ctx).(SessionWithInitialization); ok {
+		session.MarkClientReady()
+	}
+}
+
+// startInitializeTimer closes and unregisters the session if its client does
+// not send notifications/initialized before the initialize timeout.
+func (s *MCPServer) startInitializeTimer(session ClientSession) {
+	timeout := time.Duration(s.initializeTimeout.Load())
+	initSession, ok := session.(SessionWithInitialization)
+	if timeout <= 0 || !ok {
+		return
+	}
+	time.AfterFunc(timeout, func() {
+		if initSession.ClientReady() {
+			return
+		}
+		s.closeSession(session)
+	})
+}
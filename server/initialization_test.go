@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const initializeRequest = `{
+	"jsonrpc": "2.0",
+	"id": 1,
+	"method": "initialize",
+	"params": {
+		"protocolVersion": "2025-03-26",
+		"clientInfo": {"name": "test-client", "version": "1.0.0"}
+	}
+}`
+
+const initializedNotification = `{"jsonrpc": "2.0", "method": "notifications/initialized"}`
+
+func TestWithStrictInitialization(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithStrictInitialization(), WithToolCapabilities(false))
+	session := NewInProcessSession("session-1", nil)
+	require.NoError(t, server.RegisterSession(context.Background(), session))
+	ctx := server.WithContext(context.Background(), session)
+
+	listTools := func() mcp.JSONRPCMessage {
+		return server.HandleMessage(ctx, []byte(`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`))
+	}
+
+	// Requests other than initialize and ping are rejected until initialized
+	errResp, ok := listTools().(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, errResp.Error.Code)
+
+	response := server.HandleMessage(ctx, []byte(`{"jsonrpc": "2.0", "id": 3, "method": "ping"}`))
+	assert.IsType(t, mcp.JSONRPCResponse{}, response)
+	response = server.HandleMessage(ctx, []byte(initializeRequest))
+	assert.IsType(t, mcp.JSONRPCResponse{}, response)
+
+	errResp, ok = listTools().(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, errResp.Error.Code)
+	assert.False(t, session.ClientReady())
+
+	assert.Nil(t, server.HandleMessage(ctx, []byte(initializedNotification)))
+	assert.True(t, session.ClientReady())
+	assert.IsType(t, mcp.JSONRPCResponse{}, listTools())
+}
+
+func TestInitialization_NotEnforcedByDefault(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(false))
+	session := NewInProcessSession("session-1", nil)
+	require.NoError(t, server.RegisterSession(context.Background(), session))
+	ctx := server.WithContext(context.Background(), session)
+
+	response := server.HandleMessage(ctx, []byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`))
+	assert.IsType(t, mcp.JSONRPCResponse{}, response)
+}
+
+func TestMCPServer_SetInitializeTimeout(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.SetInitializeTimeout(20 * time.Millisecond)
+
+	initialize := func(sessionID string, sendInitialized bool) {
+		session := NewInProcessSession(sessionID, nil)
+		require.NoError(t, server.RegisterSession(context.Background(), session))
+		ctx := server.WithContext(context.Background(), session)
+		server.HandleMessage(ctx, []byte(initializeRequest))
+		if sendInitialized {
+			server.HandleMessage(ctx, []byte(initializedNotification))
+		}
+	}
+	initialize("acknowledged", true)
+	initialize("silent", false)
+
+	isRegistered := func(sessionID string) bool {
+		_, ok := server.sessions.Load(sessionID)
+		return ok
+	}
+	require.Eventually(t, func() bool { return !isRegistered("silent") }, time.Second, 5*time.Millisecond)
+	assert.True(t, isRegistered("acknowledged"))
+}
+
+func TestStreamableHTTP_StrictInitialization(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0", WithStrictInitialization(), WithToolCapabilities(false))
+	server := NewTestStreamableHTTPServer(mcpServer)
+	defer server.Close()
+
+	resp, err := postJSON(server.URL, json.RawMessage(initializeRequest))
+	require.NoError(t, err)
+	resp.Body.Close()
+	sessionID := resp.Header.Get(HeaderKeySessionID)
+	require.NotEmpty(t, sessionID)
+
+	listTools := func() mcp.JSONRPCMessage {
+		t.Helper()
+		resp, err := postSessionJSON(server.URL, sessionID, json.RawMessage(`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var message map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&message))
+		return message
+	}
+
+	// The initialization is tracked across the requests of the session
+	assert.Equal(t, float64(mcp.INVALID_REQUEST), listTools().(map[string]any)["error"].(map[string]any)["code"])
+
+	resp, err = postSessionJSON(server.URL, sessionID, json.RawMessage(initializedNotification))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	assert.Contains(t, listTools(), "result")
+}
+
+func TestStreamableHTTP_InitializeTimeout(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0")
+	mcpServer.SetInitializeTimeout(20 * time.Millisecond)
+	server := NewTestStreamableHTTPServer(mcpServer)
+	defer server.Close()
+
+	initialize := func(sendInitialized bool) string {
+		t.Helper()
+		resp, err := postJSON(server.URL, json.RawMessage(initializeRequest))
+		require.NoError(t, err)
+		resp.Body.Close()
+		sessionID := resp.Header.Get(HeaderKeySessionID)
+		if sendInitialized {
+			resp, err = postSessionJSON(server.URL, sessionID, json.RawMessage(initializedNotification))
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+		return sessionID
+	}
+	pingStatus := func(sessionID string) int {
+		t.Helper()
+		resp, err := postSessionJSON(server.URL, sessionID, json.RawMessage(`{"jsonrpc": "2.0", "id": 3, "method": "ping"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode
+	}
+
+	acknowledged := initialize(true)
+	silent := initialize(false)
+
+	// The silent session is terminated, as if the client had deleted it
+	require.Eventually(t, func() bool { return pingStatus(silent) == http.StatusNotFound }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, http.StatusOK, pingStatus(acknowledged))
+}
+
+func TestStdioServer_InitializeTimeoutClosesConnection(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0")
+	mcpServer.SetInitializeTimeout(20 * time.Millisecond)
+	stdioSessionInstance.initializedNotice.Store(false)
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+	stdoutReader, stdoutWriter := io.Pipe()
+	go func() { _, _ = io.Copy(io.Discard, stdoutReader) }()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewStdioServer(mcpServer).Listen(context.Background(), stdinReader, stdoutWriter)
+		stdoutWriter.Close()
+	}()
+
+	var compact bytes.Buffer
+	require.NoError(t, json.Compact(&compact, []byte(initializeRequest)))
+	_, err := stdinWriter.Write(append(compact.Bytes(), '\n'))
+	require.NoError(t, err)
+
+	// Listen returns although stdin is still open
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the connection of the session was not closed")
+	}
+}
+
+func TestSSESession_CloseIsIdempotent(t *testing.T) {
+	session := &sseSession{done: make(chan struct{})}
+	session.close()
+	session.close()
+	select {
+	case <-session.done:
+	default:
+		t.Fatal("session is not closed")
+	}
+}
@@ -29,6 +29,7 @@ type InProcessSession struct {
 	sessionID          string
 	notifications      chan mcp.JSONRPCNotification
 	initialized        atomic.Bool
+	initializedNotice  atomic.Bool
 	loggingLevel       atomic.Value
 	clientInfo         atomic.Value
 	clientCapabilities atomic.Value
@@ -73,6 +74,14 @@ func (s *InProcessSession) Initialized() bool {
 	return s.initialized.Load()
 }
 
+func (s *InProcessSession) MarkClientReady() {
+	s.initializedNotice.Store(true)
+}
+
+func (s *InProcessSession) ClientReady() bool {
+	return s.initializedNotice.Load()
+}
+
 func (s *InProcessSession) GetClientInfo() mcp.Implementation {
 	if value := s.clientInfo.Load(); value != nil {
 		if clientInfo, ok := value.(mcp.Implementation); ok {
@@ -156,10 +165,11 @@ func GenerateInProcessSessionID() string {
 
 // Ensure interface compliance
 var (
-	_ ClientSession          = (*InProcessSession)(nil)
-	_ SessionWithLogging     = (*InProcessSession)(nil)
-	_ SessionWithClientInfo  = (*InProcessSession)(nil)
-	_ SessionWithSampling    = (*InProcessSession)(nil)
-	_ SessionWithElicitation = (*InProcessSession)(nil)
-	_ SessionWithRoots       = (*InProcessSession)(nil)
+	_ ClientSession             = (*InProcessSession)(nil)
+	_ SessionWithLogging        = (*InProcessSession)(nil)
+	_ SessionWithClientInfo     = (*InProcessSession)(nil)
+	_ SessionWithSampling       = (*InProcessSession)(nil)
+	_ SessionWithElicitation    = (*InProcessSession)(nil)
+	_ SessionWithRoots          = (*InProcessSession)(nil)
+	_ SessionWithInitialization = (*InProcessSession)(nil)
 )
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithToolInputValidation makes the server check the arguments of tool calls
+// against the input schema of the tool before calling its handler. Calls with
+// invalid arguments are answered with an INVALID_PARAMS error whose data, made
+// with mcp.NewValidationErrorData, lists the fields that failed validation.
+func WithToolInputValidation() ServerOption {
+	return func(s *MCPServer) {
+		s.toolInputValidation = true
+	}
+}
+
+// validateToolInput checks the arguments of a call to tool against its input
+// schema.
+func validateToolInput(id any, tool mcp.Tool, request mcp.CallToolRequest) *requestError {
+	arguments := request.GetArguments()
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+
+	err := tool.ValidateArguments(arguments)
+	if err == nil {
+		return nil
+	}
+	var validationErrors []mcp.ValidationError
+	var schemaErrs mcp.SchemaValidationErrors
+	var schemaErr *mcp.SchemaValidationError
+	switch {
+	case errors.As(err, &schemaErrs):
+		validationErrors = schemaErrs.ValidationErrors()
+	case errors.As(err, &schemaErr):
+		validationErrors = []mcp.ValidationError{schemaErr.ValidationError()}
+	default:
+		return &requestError{
+			id:   id,
+			code: mcp.INTERNAL_ERROR,
+			err:  fmt.Errorf("tool '%s' has an invalid input schema: %w", tool.Name, err),
+		}
+	}
+	return &requestError{
+		id:   id,
+		code: mcp.INVALID_PARAMS,
+		err: mcp.NewMCPError(
+			mcp.INVALID_PARAMS,
+			fmt.Sprintf("invalid arguments for tool '%s': %s", tool.Name, err),
+			mcp.NewValidationErrorData(validationErrors),
+		),
+	}
+}
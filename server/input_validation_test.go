@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithToolInputValidation(t *testing.T) {
+	newServer := func(opts ...ServerOption) *MCPServer {
+		server := NewMCPServer("test", "1.0.0", opts...)
+		server.AddTool(mcp.NewTool("search",
+			mcp.WithString("query", mcp.Required()),
+			mcp.WithNumber("limit", mcp.Min(1)),
+		), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("found"), nil
+		})
+		server.AddTool(mcp.NewTool("ping"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("pong"), nil
+		})
+		return server
+	}
+	call := func(server *MCPServer, name, arguments string) mcp.JSONRPCMessage {
+		return server.HandleMessage(context.Background(), []byte(
+			`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "`+name+`", "arguments": `+arguments+`}}`,
+		))
+	}
+
+	server := newServer(WithToolInputValidation())
+
+	tests := []struct {
+		name      string
+		arguments string
+		expected  []mcp.ValidationError
+	}{
+		{
+			name:      "missing required argument",
+			arguments: `{"limit": 5}`,
+			expected:  []mcp.ValidationError{{Field: "/query", Code: "required", Message: "is required"}},
+		},
+		{
+			name:      "wrong type",
+			arguments: `{"query": 42}`,
+			expected:  []mcp.ValidationError{{Field: "/query", Code: "type", Message: "expected string, got number"}},
+		},
+		{
+			name:      "out of range",
+			arguments: `{"query": "go", "limit": 0}`,
+			expected:  []mcp.ValidationError{{Field: "/limit", Code: "minimum", Message: "must be at least 1"}},
+		},
+		{
+			name:      "several errors",
+			arguments: `{"limit": 0}`,
+			expected: []mcp.ValidationError{
+				{Field: "/query", Code: "required", Message: "is required"},
+				{Field: "/limit", Code: "minimum", Message: "must be at least 1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, ok := call(server, "search", tt.arguments).(mcp.JSONRPCError)
+			require.True(t, ok)
+			assert.Equal(t, mcp.INVALID_PARAMS, response.Error.Code)
+			assert.Contains(t, response.Error.Message, "invalid arguments for tool 'search'")
+
+			// The data reaches the client as a JSON object
+			data, err := json.Marshal(response.Error.Data)
+			require.NoError(t, err)
+			var decoded mcp.ValidationErrorData
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, "Invalid params", decoded.Title)
+			assert.Equal(t, tt.expected, decoded.Errors)
+		})
+	}
+
+	t.Run("valid arguments", func(t *testing.T) {
+		response, ok := call(server, "search", `{"query": "go", "limit": 5}`).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		assert.Equal(t, "found", response.Result.(mcp.CallToolResult).Content[0].(mcp.TextContent).Text)
+
+		response, ok = call(server, "ping", `null`).(mcp.JSONRPCResponse)
+		require.True(t, ok)
+		assert.Equal(t, "pong", response.Result.(mcp.CallToolResult).Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, ok := call(newServer(), "search", `{"limit": 5}`).(mcp.JSONRPCResponse)
+		assert.True(t, ok)
+	})
+}
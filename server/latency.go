@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// latencyReservoirSize is the number of samples kept per request target to
+// estimate latency percentiles.
+const latencyReservoirSize = 1024
+
+// LatencyStats summarizes the latency of the requests to a tool or method.
+// Percentiles are estimated from a uniform sample of the requests.
+type LatencyStats struct {
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Count int64
+}
+
+// latencyReservoir keeps a uniform random sample of latencies, using
+// reservoir sampling so that memory use does not grow with the request count.
+type latencyReservoir struct {
+	samples []time.Duration
+	count   int64
+}
+
+func (r *latencyReservoir) observe(d time.Duration) {
+	r.count++
+	if len(r.samples) < latencyReservoirSize {
+		r.samples = append(r.samples, d)
+		return
+	}
+	if i := rand.Int64N(r.count); i < latencyReservoirSize {
+		r.samples[i] = d
+	}
+}
+
+func (r *latencyReservoir) stats() LatencyStats {
+	sorted := slices.Clone(r.samples)
+	slices.Sort(sorted)
+	percentile := func(p float64) time.Duration {
+		if len(sorted) == 0 {
+			return 0
+		}
+		return sorted[int(p*float64(len(sorted)-1)+0.5)]
+	}
+	return LatencyStats{
+		P50:   percentile(0.50),
+		P95:   percentile(0.95),
+		P99:   percentile(0.99),
+		Count: r.count,
+	}
+}
+
+// latencyRecorder records request latencies by request target.
+type latencyRecorder struct {
+	mu         sync.Mutex
+	reservoirs map[string]*latencyReservoir
+}
+
+func (l *latencyRecorder) observe(target string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.reservoirs == nil {
+		l.reservoirs = make(map[string]*latencyReservoir)
+	}
+	r, ok := l.reservoirs[target]
+	if !ok {
+		r = &latencyReservoir{}
+		l.reservoirs[target] = r
+	}
+	r.observe(d)
+}
+
+// NewLatencyMiddleware returns a middleware that measures how long requests
+// take to handle, for each tool and for each method other than tools/call.
+// The measurements are reported by LatencySnapshot of the server handling the
+// requests.
+func NewLatencyMiddleware() MiddlewareFunc {
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+			start := time.Now()
+			response := next(ctx, request)
+			if s := ServerFromContext(ctx); s != nil {
+				s.latency.observe(requestTarget(request), time.Since(start))
+			}
+			return response
+		}
+	}
+}
+
+// LatencySnapshot returns the latency statistics recorded by
+// NewLatencyMiddleware, keyed by tool (as "tool 'name'") or method. It is
+// empty if the middleware was not added to the server.
+func (s *MCPServer) LatencySnapshot() map[string]LatencyStats {
+	s.latency.mu.Lock()
+	defer s.latency.mu.Unlock()
+	snapshot := make(map[string]LatencyStats, len(s.latency.reservoirs))
+	for target, r := range s.latency.reservoirs {
+		snapshot[target] = r.stats()
+	}
+	return snapshot
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLatencyMiddleware(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddMiddlewareByName("latency", NewLatencyMiddleware())
+	server.AddTool(mcp.NewTool("slow"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		time.Sleep(2 * time.Millisecond)
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	const calls = 100
+	durations := make([]time.Duration, 0, calls)
+	for i := range calls {
+		start := time.Now()
+		response := server.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": %d,
+			"method": "tools/call",
+			"params": {"name": "slow"}
+		}`, i)))
+		durations = append(durations, time.Since(start))
+		_, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+	}
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1000, "method": "ping"}`))
+
+	snapshot := server.LatencySnapshot()
+	require.Contains(t, snapshot, "tool 'slow'")
+	require.Contains(t, snapshot, "ping")
+	assert.Equal(t, int64(1), snapshot["ping"].Count)
+
+	stats := snapshot["tool 'slow'"]
+	assert.Equal(t, int64(calls), stats.Count)
+	slices.Sort(durations)
+	median := durations[calls/2]
+	assert.InEpsilon(t, float64(median), float64(stats.P50), 0.2,
+		"P50 %v is not within 20%% of the median %v", stats.P50, median)
+	assert.LessOrEqual(t, stats.P50, stats.P95)
+	assert.LessOrEqual(t, stats.P95, stats.P99)
+}
+
+func TestLatencySnapshot_WithoutMiddleware(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+	assert.Empty(t, server.LatencySnapshot())
+}
+
+func TestLatencyReservoir_BoundedSamples(t *testing.T) {
+	var r latencyReservoir
+	for i := range 10 * latencyReservoirSize {
+		r.observe(time.Duration(i))
+	}
+	assert.Len(t, r.samples, latencyReservoirSize)
+	stats := r.stats()
+	assert.Equal(t, int64(10*latencyReservoirSize), stats.Count)
+	// The sample is uniform, so its median is close to the overall one
+	assert.InEpsilon(t, float64(5*latencyReservoirSize), float64(stats.P50), 0.2)
+}
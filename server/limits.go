@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithMaxRequestBodySize limits the size in bytes of a single JSON-RPC message
+// read from a client. Larger messages are answered with a parse error and the
+// connection is closed: HTTP transports stop reading the body and close the
+// connection after responding, and the stdio transport stops listening. A
+// value of zero or less disables the limit.
+func WithMaxRequestBodySize(bytes int64) ServerOption {
+	return func(s *MCPServer) {
+		s.maxRequestBodySize = bytes
+	}
+}
+
+// WithMaxResponseBodySize limits the size in bytes of a single JSON-RPC
+// response produced by HandleMessage. Larger responses are replaced with an
+// internal error response. A value of zero or less disables the limit.
+func WithMaxResponseBodySize(bytes int64) ServerOption {
+	return func(s *MCPServer) {
+		s.maxResponseBodySize = bytes
+	}
+}
+
+// limitRequestBody caps how much of the request body can be read according to
+// WithMaxRequestBodySize.
+func (s *MCPServer) limitRequestBody(w http.ResponseWriter, r *http.Request) {
+	if s.maxRequestBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxRequestBodySize)
+	}
+}
+
+// requestTooLargeMessage is the error message sent for oversized requests.
+func (s *MCPServer) requestTooLargeMessage() string {
+	return fmt.Sprintf("request body exceeds the maximum size of %d bytes", s.maxRequestBodySize)
+}
+
+// isRequestTooLarge reports whether err was caused by a request exceeding
+// WithMaxRequestBodySize.
+func isRequestTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr) || errors.Is(err, ErrRequestTooLarge)
+}
+
+// readLimitedLine reads a newline terminated line, failing with
+// ErrRequestTooLarge once more than limit bytes have been read. A limit of
+// zero or less reads lines of any length.
+func readLimitedLine(reader *bufio.Reader, limit int64) (string, error) {
+	if limit <= 0 {
+		return reader.ReadString('\n')
+	}
+
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if int64(len(line)+len(chunk)) > limit {
+			return "", ErrRequestTooLarge
+		}
+		line = append(line, chunk...)
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return string(line), err
+		}
+	}
+}
+
+// limitResponseSize replaces a response larger than WithMaxResponseBodySize
+// with an error response for the same request.
+func (s *MCPServer) limitResponseSize(response mcp.JSONRPCMessage) mcp.JSONRPCMessage {
+	if s.maxResponseBodySize <= 0 || response == nil {
+		return response
+	}
+
+	var id mcp.RequestId
+	switch r := response.(type) {
+	case mcp.JSONRPCResponse:
+		id = r.ID
+	case mcp.JSONRPCError:
+		id = r.ID
+	default:
+		return response
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil || int64(len(data)) <= s.maxResponseBodySize {
+		return response
+	}
+
+	return mcp.JSONRPCError{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Error: mcp.NewJSONRPCErrorDetails(
+			mcp.INTERNAL_ERROR,
+			fmt.Sprintf("response exceeds the maximum size of %d bytes", s.maxResponseBodySize),
+			nil,
+		),
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_MaxRequestBodySize(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithMaxRequestBodySize(64))
+
+	response := server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	large := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 2, "method": "ping", "params": {"padding": %q}}`, strings.Repeat("x", 100))
+	response = server.HandleMessage(context.Background(), []byte(large))
+	errorResponse, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.PARSE_ERROR, errorResponse.Error.Code)
+	assert.Contains(t, errorResponse.Error.Message, "64 bytes")
+}
+
+func TestMCPServer_MaxResponseBodySize(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithMaxResponseBodySize(256))
+	server.AddTool(mcp.NewTool("large"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(strings.Repeat("x", 1024)), nil
+	})
+	server.AddTool(mcp.NewTool("small"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/call",
+		"params": {"name": "small"}
+	}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	response = server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 2,
+		"method": "tools/call",
+		"params": {"name": "large"}
+	}`))
+	errorResponse, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.NewRequestId(float64(2)), errorResponse.ID)
+	assert.Equal(t, mcp.INTERNAL_ERROR, errorResponse.Error.Code)
+	assert.Contains(t, errorResponse.Error.Message, "256 bytes")
+}
+
+func TestStreamableHTTP_MaxRequestBodySize(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0", WithMaxRequestBodySize(128))
+	server := NewTestStreamableHTTPServer(mcpServer)
+	defer server.Close()
+
+	body := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"padding": %q}}`, strings.Repeat("x", 1024))
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.True(t, resp.Close, "connection should be closed")
+
+	var errorResponse mcp.JSONRPCError
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errorResponse))
+	assert.Equal(t, mcp.PARSE_ERROR, errorResponse.Error.Code)
+}
+
+func TestStdioServer_MaxRequestBodySize(t *testing.T) {
+	mcpServer := NewMCPServer("test-server", "1.0.0", WithMaxRequestBodySize(128))
+	stdioServer := NewStdioServer(mcpServer)
+
+	input := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "ping", "params": {"padding": %q}}`+"\n", strings.Repeat("x", 8192))
+	var output bytes.Buffer
+
+	err := stdioServer.Listen(context.Background(), strings.NewReader(input), &output)
+	assert.ErrorIs(t, err, ErrRequestTooLarge)
+
+	var errorResponse mcp.JSONRPCError
+	require.NoError(t, json.Unmarshal(output.Bytes(), &errorResponse))
+	assert.Equal(t, mcp.PARSE_ERROR, errorResponse.Error.Code)
+}
+
+func TestReadLimitedLine(t *testing.T) {
+	// A small buffer forces the line to be read in several chunks
+	reader := bufio.NewReaderSize(strings.NewReader(strings.Repeat("a", 40)+"\n"+strings.Repeat("b", 100)+"\n"), 16)
+
+	line, err := readLimitedLine(reader, 64)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("a", 40)+"\n", line)
+
+	_, err = readLimitedLine(reader, 64)
+	assert.ErrorIs(t, err, ErrRequestTooLarge)
+}
@@ -0,0 +1,164 @@
+// Package metrics exports Prometheus metrics of MCP server operations: tool
+// call counts, errors and latencies, and the number of active sessions.
+//
+// The metrics are registered on the default Prometheus registry, served by
+// Handler, unless another registerer is given with WithRegisterer:
+//
+//	hooks := &server.Hooks{}
+//	metrics.TrackSessions(hooks)
+//	s := server.NewMCPServer("example", "1.0.0",
+//		server.WithHooks(hooks),
+//		server.WithToolHandlerMiddleware(metrics.NewPrometheusMiddleware()),
+//	)
+//	http.Handle("/metrics", metrics.Handler())
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Values of the status label.
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Option configures NewPrometheusMiddleware and TrackSessions.
+type Option func(*options)
+
+type options struct {
+	registerer prometheus.Registerer
+}
+
+// WithRegisterer registers the metrics on registerer instead of the default
+// Prometheus registry.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		o.registerer = registerer
+	}
+}
+
+// collectors are the metrics registered on a registerer.
+type collectors struct {
+	toolCalls        *prometheus.CounterVec
+	toolErrors       *prometheus.CounterVec
+	toolCallDuration *prometheus.HistogramVec
+	activeSessions   prometheus.Gauge
+}
+
+// newCollectors registers the metrics on the registerer of opts. Metrics
+// already registered there, by an earlier call, are reused, so that the
+// middleware and the session hooks share them. It panics if the registerer
+// has conflicting metrics of the same names.
+func newCollectors(opts []Option) *collectors {
+	o := options{registerer: prometheus.DefaultRegisterer}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &collectors{
+		toolCalls: register(o.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_tool_calls_total",
+			Help: "Total number of tool calls by tool and status.",
+		}, []string{"tool_name", "status"})),
+		toolErrors: register(o.registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_tool_errors_total",
+			Help: "Total number of failed tool calls by tool.",
+		}, []string{"tool_name"})),
+		toolCallDuration: register(o.registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_tool_call_duration_seconds",
+			Help:    "Duration of tool calls in seconds by tool and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"tool_name", "status"})),
+		activeSessions: register(o.registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "mcp_active_sessions",
+			Help: "Number of active client sessions.",
+		})),
+	}
+}
+
+// register registers collector on registerer, returning the collector
+// already registered in its place if any.
+func register[C prometheus.Collector](registerer prometheus.Registerer, collector C) C {
+	if err := registerer.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+		panic(fmt.Sprintf("metrics: failed to register collector: %v", err))
+	}
+	return collector
+}
+
+// NewPrometheusMiddleware returns a tool middleware recording every tool call
+// in mcp_tool_calls_total and mcp_tool_call_duration_seconds, labelled with
+// tool_name and status. Calls that return an error or a result with IsError
+// set have the status "error" and are also counted in mcp_tool_errors_total.
+func NewPrometheusMiddleware(opts ...Option) server.ToolHandlerMiddleware {
+	c := newCollectors(opts)
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			tool := request.Params.Name
+			status := StatusSuccess
+			if err != nil || (result != nil && result.IsError) {
+				status = StatusError
+				c.toolErrors.WithLabelValues(tool).Inc()
+			}
+			c.toolCalls.WithLabelValues(tool, status).Inc()
+			c.toolCallDuration.WithLabelValues(tool, status).Observe(time.Since(start).Seconds())
+
+			return result, err
+		}
+	}
+}
+
+// TrackSessions adds hooks keeping mcp_active_sessions up to date with the
+// sessions registered with the servers using hooks.
+func TrackSessions(hooks *server.Hooks, opts ...Option) {
+	c := newCollectors(opts)
+	hooks.AddOnRegisterSession(func(ctx context.Context, session server.ClientSession) {
+		c.activeSessions.Inc()
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		c.activeSessions.Dec()
+	})
+}
+
+// Handler returns an HTTP handler serving the metrics of the default
+// Prometheus registry, to be mounted on a /metrics endpoint. Metrics
+// registered elsewhere with WithRegisterer are served by
+// promhttp.HandlerFor.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Export writes the metrics gathered by gatherer, such as
+// prometheus.DefaultGatherer, to w in the Prometheus text exposition format.
+func Export(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(w, family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
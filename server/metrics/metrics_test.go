@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer creates a server recording metrics on registry, with tools
+// succeeding and failing.
+func newTestServer(registry *prometheus.Registry) *server.MCPServer {
+	hooks := &server.Hooks{}
+	TrackSessions(hooks, WithRegisterer(registry))
+	s := server.NewMCPServer("test-server", "1.0.0",
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(NewPrometheusMiddleware(WithRegisterer(registry))),
+	)
+	s.AddTool(mcp.NewTool("echo"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("echo"), nil
+	})
+	s.AddTool(mcp.NewTool("fail"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("failed")
+	})
+	s.AddTool(mcp.NewTool("soft-fail"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("failed"), nil
+	})
+	return s
+}
+
+func callTools(s *server.MCPServer, names ...string) {
+	for i, name := range names {
+		s.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+			"jsonrpc": "2.0",
+			"id": %d,
+			"method": "tools/call",
+			"params": {"name": %q}
+		}`, i, name)))
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	s := newTestServer(registry)
+
+	sessions := []server.ClientSession{
+		server.NewInProcessSession("metrics-session-1", nil),
+		server.NewInProcessSession("metrics-session-2", nil),
+	}
+	for _, session := range sessions {
+		require.NoError(t, s.RegisterSession(context.Background(), session))
+	}
+	s.UnregisterSession(context.Background(), sessions[1].SessionID())
+
+	callTools(s, "echo", "echo", "echo", "fail", "soft-fail")
+
+	httpServer := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer httpServer.Close()
+	resp, err := http.Get(httpServer.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	output := string(body)
+
+	assert.Contains(t, output, "# TYPE mcp_tool_calls_total counter\n")
+	assert.Contains(t, output, `mcp_tool_calls_total{status="success",tool_name="echo"} 3`+"\n")
+	assert.Contains(t, output, `mcp_tool_calls_total{status="error",tool_name="fail"} 1`+"\n")
+	assert.Contains(t, output, `mcp_tool_calls_total{status="error",tool_name="soft-fail"} 1`+"\n")
+
+	assert.Contains(t, output, "# TYPE mcp_tool_errors_total counter\n")
+	assert.Contains(t, output, `mcp_tool_errors_total{tool_name="fail"} 1`+"\n")
+	assert.Contains(t, output, `mcp_tool_errors_total{tool_name="soft-fail"} 1`+"\n")
+	assert.NotContains(t, output, `mcp_tool_errors_total{tool_name="echo"}`)
+
+	assert.Contains(t, output, "# TYPE mcp_tool_call_duration_seconds histogram\n")
+	assert.Contains(t, output, `mcp_tool_call_duration_seconds_bucket{status="success",tool_name="echo",le="+Inf"} 3`+"\n")
+	assert.Contains(t, output, `mcp_tool_call_duration_seconds_count{status="error",tool_name="fail"} 1`+"\n")
+
+	assert.Contains(t, output, "# TYPE mcp_active_sessions gauge\nmcp_active_sessions 1\n")
+}
+
+func TestPrometheusMetrics_SharedRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first := newTestServer(registry)
+	second := newTestServer(registry)
+
+	callTools(first, "echo")
+	callTools(second, "echo")
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, registry))
+	assert.Contains(t, buf.String(), `mcp_tool_calls_total{status="success",tool_name="echo"} 2`+"\n")
+}
+
+func TestPrometheusMetrics_ConflictingRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "mcp_tool_calls_total", Help: "Conflicting."}))
+
+	assert.Panics(t, func() {
+		NewPrometheusMiddleware(WithRegisterer(registry))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RequestHandlerFunc handles a JSON-RPC request and returns the response to
+// send back, which is either a mcp.JSONRPCResponse or a mcp.JSONRPCError.
+type RequestHandlerFunc func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage
+
+// MiddlewareFunc wraps the handling of every request the server receives,
+// whatever its method. The request passed to the handlers is decoded for
+// inspection only: changing it does not change the request the server
+// handles. Notifications are not passed to middleware.
+type MiddlewareFunc func(next RequestHandlerFunc) RequestHandlerFunc
+
+// namedMiddleware is a middleware along with the name it was registered with.
+type namedMiddleware struct {
+	name string
+	mw   MiddlewareFunc
+}
+
+// AddMiddlewareByName adds a middleware wrapped around the handling of every
+// request. The name identifies the middleware in ListMiddleware and need not
+// be unique. Middleware added first is the outermost one.
+func (s *MCPServer) AddMiddlewareByName(name string, mw MiddlewareFunc) {
+	s.middlewareMu.Lock()
+	defer s.middlewareMu.Unlock()
+	s.middlewares = append(s.middlewares, namedMiddleware{name: name, mw: mw})
+}
+
+// ListMiddleware returns the names of the middleware of the server, in the
+// order they were added.
+func (s *MCPServer) ListMiddleware() []string {
+	s.middlewareMu.RLock()
+	defer s.middlewareMu.RUnlock()
+	names := make([]string, len(s.middlewares))
+	for i, m := range s.middlewares {
+		names[i] = m.name
+	}
+	return names
+}
+
+// handleRequestWithMiddleware handles the request through the middleware of
+// the server, if any.
+func (s *MCPServer) handleRequestWithMiddleware(
+	ctx context.Context,
+	baseMessage jsonrpcEnvelope,
+	message json.RawMessage,
+) mcp.JSONRPCMessage {
+	s.middlewareMu.RLock()
+	middlewares := s.middlewares
+	s.middlewareMu.RUnlock()
+
+	if len(middlewares) == 0 {
+		return s.handleRequest(ctx, baseMessage, message)
+	}
+
+	var request mcp.JSONRPCRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		return createErrorResponse(baseMessage.ID, mcp.INVALID_REQUEST, "Failed to parse request")
+	}
+
+	handler := RequestHandlerFunc(func(ctx context.Context, _ *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+		return s.handleRequest(ctx, baseMessage, message)
+	})
+	// Apply middlewares in reverse order
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i].mw(handler)
+	}
+	return handler(ctx, &request)
+}
+
+// NewContextMiddleware returns a middleware that replaces the context of every
+// request with the one returned by extractor, so that values the extractor
+// adds, for example from the HTTP headers returned by
+// RequestHeaderFromContext, are available to hooks and handlers.
+func NewContextMiddleware(extractor func(ctx context.Context, req *mcp.JSONRPCRequest) context.Context) MiddlewareFunc {
+	return func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+			if extracted := extractor(ctx, request); extracted != nil {
+				ctx = extracted
+			}
+			return next(ctx, request)
+		}
+	}
+}
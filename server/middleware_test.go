@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_AddMiddlewareByName(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	assert.Empty(t, server.ListMiddleware())
+
+	var calls []string
+	recording := func(name string) MiddlewareFunc {
+		return func(next RequestHandlerFunc) RequestHandlerFunc {
+			return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+				assert.Equal(t, string(mcp.MethodPing), request.Method)
+				calls = append(calls, name)
+				return next(ctx, request)
+			}
+		}
+	}
+	server.AddMiddlewareByName("auth", recording("auth"))
+	server.AddMiddlewareByName("logging", recording("logging"))
+	server.AddMiddlewareByName("metrics", recording("metrics"))
+
+	assert.Equal(t, []string{"auth", "logging", "metrics"}, server.ListMiddleware())
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "ping"
+	}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, []string{"auth", "logging", "metrics"}, calls)
+}
+
+func TestMCPServer_MiddlewareShortCircuit(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddMiddlewareByName("deny", func(next RequestHandlerFunc) RequestHandlerFunc {
+		return func(ctx context.Context, request *mcp.JSONRPCRequest) mcp.JSONRPCMessage {
+			return createErrorResponse(request.ID.Value(), mcp.INVALID_REQUEST, "denied")
+		}
+	})
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "ping"
+	}`))
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "unexpected response %#v", response)
+	assert.Equal(t, "denied", errResp.Error.Message)
+}
+
+func TestNewContextMiddleware(t *testing.T) {
+	type tenantKey struct{}
+
+	mcpServer := NewMCPServer("test-server", "1.0.0")
+	mcpServer.AddMiddlewareByName("tenant", NewContextMiddleware(func(ctx context.Context, req *mcp.JSONRPCRequest) context.Context {
+		return context.WithValue(ctx, tenantKey{}, RequestHeaderFromContext(ctx).Get("X-Tenant-ID"))
+	}))
+	mcpServer.AddTool(mcp.NewTool("whoami"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return mcp.NewToolResultText(tenant), nil
+	})
+
+	httpServer := NewTestStreamableHTTPServer(mcpServer, WithStateLess(true))
+	defer httpServer.Close()
+
+	req, err := http.NewRequest(http.MethodPost, httpServer.URL, strings.NewReader(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/call",
+		"params": {"name": "whoami"}
+	}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", "acme")
+
+	resp, err := httpServer.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response struct {
+		Result mcp.CallToolResult `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Len(t, response.Result.Content, 1)
+	text, ok := response.Result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Equal(t, "acme", text.Text)
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// MultiplexedTransport serves several client sessions over a single HTTP/2
+// connection. It is the streamable HTTP transport served over HTTP/2: each
+// message a client posts is a stream of the connection, the Mcp-Session-Id
+// header of the stream identifies the session it belongs to, and responses
+// and notifications go back on the stream of the request or on the GET stream
+// of the session. The sessions and their handling are those of the embedded
+// StreamableHTTPServer; MultiplexedTransport accepts cleartext HTTP/2 (h2c)
+// connections in addition to HTTP/2 over TLS and HTTP/1.1, so that clients
+// behind a TLS-terminating proxy can keep a single connection open too.
+//
+// Clients multiplex their sessions by sharing an HTTP/2 capable http.Client
+// between the streamable HTTP transports of the client package, set with
+// transport.WithHTTPBasicClient.
+type MultiplexedTransport struct {
+	*StreamableHTTPServer
+	handler http.Handler
+}
+
+// NewMultiplexedTransport creates a MultiplexedTransport serving server with
+// the given streamable HTTP options. Start serves it at its endpoint path.
+func NewMultiplexedTransport(server *MCPServer, opts ...StreamableHTTPOption) *MultiplexedTransport {
+	mux := http.NewServeMux()
+	httpServer := &http.Server{Handler: h2c.NewHandler(mux, &http2.Server{})}
+	opts = append([]StreamableHTTPOption{WithStreamableHTTPServer(httpServer)}, opts...)
+
+	t := &MultiplexedTransport{StreamableHTTPServer: NewStreamableHTTPServer(server, opts...)}
+	t.handler = h2c.NewHandler(t.StreamableHTTPServer, &http2.Server{})
+	mux.Handle(t.endpointPath, t.StreamableHTTPServer)
+	return t
+}
+
+// ServeHTTP implements the http.Handler interface, upgrading h2c connections
+// to HTTP/2, for mounting the transport on an existing server.
+func (t *MultiplexedTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.handler.ServeHTTP(w, r)
+}
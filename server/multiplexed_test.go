@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+func TestMultiplexedTransport(t *testing.T) {
+	mcpServer := NewMCPServer("test", "1.0.0")
+	mcpServer.AddTool(mcp.NewTool("whoami"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(ClientSessionFromContext(ctx).SessionID()), nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	counting := &countingListener{Listener: listener}
+	transport := NewMultiplexedTransport(mcpServer)
+	go func() { _ = transport.httpServer.Serve(counting) }()
+	defer transport.Shutdown(context.Background())
+	url := "http://" + listener.Addr().String() + "/mcp"
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}}
+	post := func(sessionID, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if sessionID != "" {
+			req.Header.Set(HeaderKeySessionID, sessionID)
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 2, resp.ProtoMajor)
+		return resp
+	}
+
+	// Each session is initialized independently
+	var sessionIDs []string
+	for _, name := range []string{"a", "b"} {
+		resp := post("", fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": %q, "clientInfo": {"name": %q, "version": "1.0.0"}}}`,
+			mcp.LATEST_PROTOCOL_VERSION, name))
+		sessionID := resp.Header.Get(HeaderKeySessionID)
+		require.NotEmpty(t, sessionID)
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	assert.NotEqual(t, sessionIDs[0], sessionIDs[1])
+
+	// Requests are handled in the session of their header
+	for _, sessionID := range sessionIDs {
+		resp := post(sessionID, `{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "whoami"}}`)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		var response struct {
+			Result mcp.CallToolResult `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(body, &response))
+		require.Len(t, response.Result.Content, 1)
+		assert.Equal(t, sessionID, response.Result.Content[0].(mcp.TextContent).Text)
+	}
+
+	// All the sessions shared a single connection
+	assert.Equal(t, int32(1), counting.accepted.Load())
+}
+
+func TestMultiplexedTransport_ServeHTTP(t *testing.T) {
+	transport := NewMultiplexedTransport(NewMCPServer("test", "1.0.0"))
+	mux := http.NewServeMux()
+	mux.Handle("/custom", transport)
+	httpServer := &http.Server{Handler: mux}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = httpServer.Serve(listener) }()
+	defer httpServer.Close()
+
+	// HTTP/1.1 clients are served at the path the transport is mounted on
+	resp, err := http.Post("http://"+listener.Addr().String()+"/custom", "application/json",
+		strings.NewReader(`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "clientInfo": {"name": "test", "version": "1.0.0"}}}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(HeaderKeySessionID))
+}
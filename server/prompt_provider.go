@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PromptProvider supplies prompts and their handlers, for example from a
+// plugin or a directory of prompt templates.
+type PromptProvider interface {
+	// Prompts returns the prompts currently offered by the provider.
+	Prompts() ([]mcp.Prompt, error)
+	// Handler returns the handler of the named prompt.
+	Handler(name string) (PromptHandlerFunc, error)
+}
+
+// promptProviderEntry is a registered provider with the names of the prompts
+// it registered most recently.
+type promptProviderEntry struct {
+	provider PromptProvider
+	names    []string
+}
+
+// RegisterPromptProvider registers the prompts of provider with the server.
+// The provider is queried again by Reload.
+func (s *MCPServer) RegisterPromptProvider(provider PromptProvider) error {
+	prompts, err := loadProviderPrompts(provider)
+	if err != nil {
+		return err
+	}
+
+	s.providersMu.Lock()
+	s.promptProviders = append(s.promptProviders, &promptProviderEntry{
+		provider: provider,
+		names:    serverPromptNames(prompts),
+	})
+	s.providersMu.Unlock()
+
+	s.AddPrompts(prompts...)
+	return nil
+}
+
+// loadProviderPrompts queries provider for its prompts and their handlers.
+func loadProviderPrompts(provider PromptProvider) ([]ServerPrompt, error) {
+	prompts, err := provider.Prompts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider prompts: %w", err)
+	}
+
+	serverPrompts := make([]ServerPrompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		handler, err := provider.Handler(prompt.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get handler for prompt %q: %w", prompt.Name, err)
+		}
+		if handler == nil {
+			return nil, fmt.Errorf("prompt %q has no handler: %w", prompt.Name, ErrInvalidRegistration)
+		}
+		serverPrompts = append(serverPrompts, ServerPrompt{Prompt: prompt, Handler: handler})
+	}
+	return serverPrompts, nil
+}
+
+func serverPromptNames(prompts []ServerPrompt) []string {
+	names := make([]string, len(prompts))
+	for i, prompt := range prompts {
+		names[i] = prompt.Prompt.Name
+	}
+	return names
+}
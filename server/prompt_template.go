@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// PromptTemplate is a prompt rendered from a text/template. Its Handle method
+// can be registered as the prompt handler:
+//
+//	tmpl, err := server.NewPromptTemplate("greet", "Greets someone", "Say hello to {{.name}}.",
+//		[]mcp.PromptArgument{{Name: "name", Required: true}})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	s.AddPrompt(tmpl.Prompt(), tmpl.Handle)
+//
+// The template is executed with the prompt arguments as a map[string]string.
+// Declared arguments that are not provided render as empty strings, while
+// referencing an undeclared argument is an error.
+type PromptTemplate struct {
+	prompt   mcp.Prompt
+	template *template.Template
+}
+
+// NewPromptTemplate creates a prompt template with the given name,
+// description and arguments. It returns an error if templateStr cannot be
+// parsed.
+func NewPromptTemplate(name, description, templateStr string, args []mcp.PromptArgument) (*PromptTemplate, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template of prompt %q: %w", name, err)
+	}
+
+	return &PromptTemplate{
+		prompt: mcp.Prompt{
+			Name:        name,
+			Description: description,
+			Arguments:   args,
+		},
+		template: tmpl,
+	}, nil
+}
+
+// promptTemplateFile is the format of files read by LoadPromptTemplateFile.
+type promptTemplateFile struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Arguments   []mcp.PromptArgument `json:"arguments"`
+	Template    string               `json:"template"`
+}
+
+// LoadPromptTemplateFile reads a prompt template from a JSON or YAML file,
+// chosen by the .json, .yaml or .yml extension, with the fields name,
+// description, arguments and template:
+//
+//	name: greet
+//	description: Greets someone
+//	arguments:
+//	  - name: name
+//	    required: true
+//	template: Say hello to {{.name}}.
+func LoadPromptTemplateFile(path string) (*PromptTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt template: %w", err)
+	}
+
+	var file promptTemplateFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	case ".yaml", ".yml":
+		err = unmarshalYAMLAsJSON(data, &file)
+	default:
+		return nil, fmt.Errorf("unsupported prompt template file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template in %s: %w", path, err)
+	}
+
+	if file.Name == "" {
+		return nil, fmt.Errorf("prompt template in %s has no name", path)
+	}
+	return NewPromptTemplate(file.Name, file.Description, file.Template, file.Arguments)
+}
+
+// unmarshalYAMLAsJSON decodes YAML into v through its JSON representation, so
+// that the json struct tags apply.
+func unmarshalYAMLAsJSON(data []byte, v any) error {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	jsonData, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// Prompt returns the prompt definition to register with the server.
+func (p *PromptTemplate) Prompt() mcp.Prompt {
+	return p.prompt
+}
+
+// Render executes the template with args.
+func (p *PromptTemplate) Render(args map[string]string) (string, error) {
+	data := make(map[string]string, len(p.prompt.Arguments)+len(args))
+	for _, arg := range p.prompt.Arguments {
+		data[arg.Name] = ""
+	}
+	for name, value := range args {
+		data[name] = value
+	}
+
+	var sb strings.Builder
+	if err := p.template.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %q: %w", p.prompt.Name, err)
+	}
+	return sb.String(), nil
+}
+
+// Handle is a PromptHandlerFunc returning the rendered template as a single
+// user message. Rendering errors are reported to the client as invalid params.
+func (p *PromptTemplate) Handle(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	text, err := p.Render(request.Params.Arguments)
+	if err != nil {
+		return nil, mcp.NewMCPError(mcp.INVALID_PARAMS, err.Error(), nil)
+	}
+
+	return mcp.NewGetPromptResult(p.prompt.Description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	}), nil
+}
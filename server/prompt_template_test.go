@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptTemplate_Render(t *testing.T) {
+	tmpl, err := NewPromptTemplate("greet", "Greets someone",
+		"Say hello to {{.name}}{{if .title}}, {{.title}}{{end}}.",
+		[]mcp.PromptArgument{
+			{Name: "name", Required: true},
+			{Name: "title"},
+		})
+	require.NoError(t, err)
+
+	text, err := tmpl.Render(map[string]string{"name": "Ada", "title": "Countess"})
+	require.NoError(t, err)
+	assert.Equal(t, "Say hello to Ada, Countess.", text)
+
+	// Optional arguments that are not given render as empty strings
+	text, err = tmpl.Render(map[string]string{"name": "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "Say hello to Ada.", text)
+
+	prompt := tmpl.Prompt()
+	assert.Equal(t, "greet", prompt.Name)
+	assert.Equal(t, "Greets someone", prompt.Description)
+	assert.Len(t, prompt.Arguments, 2)
+
+	_, err = NewPromptTemplate("broken", "", "{{.name", nil)
+	assert.Error(t, err)
+}
+
+func TestPromptTemplate_Handler(t *testing.T) {
+	tmpl, err := NewPromptTemplate("greet", "Greets someone", "Say hello to {{.name}} from {{.sender}}.",
+		[]mcp.PromptArgument{{Name: "name", Required: true}})
+	require.NoError(t, err)
+
+	s := NewMCPServer("test-server", "1.0.0")
+	s.AddPrompt(tmpl.Prompt(), tmpl.Handle)
+
+	getPrompt := func(arguments map[string]string) mcp.JSONRPCMessage {
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "prompts/get",
+			"params":  map[string]any{"name": "greet", "arguments": arguments},
+		})
+		require.NoError(t, err)
+		return s.HandleMessage(context.Background(), data)
+	}
+
+	// sender is not a declared argument, so rendering fails unless it is given
+	response := getPrompt(map[string]string{"name": "Ada"})
+	errorResponse, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok, "expected JSONRPCError, got %T", response)
+	assert.Equal(t, mcp.INVALID_PARAMS, errorResponse.Error.Code)
+	assert.Contains(t, errorResponse.Error.Message, "sender")
+
+	response = getPrompt(map[string]string{"name": "Ada", "sender": "Charles"})
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	result, ok := resp.Result.(mcp.GetPromptResult)
+	require.True(t, ok, "expected GetPromptResult, got %T", resp.Result)
+	assert.Equal(t, "Greets someone", result.Description)
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, mcp.RoleUser, result.Messages[0].Role)
+	assert.Equal(t, "Say hello to Ada from Charles.", result.Messages[0].Content.(mcp.TextContent).Text)
+}
+
+func TestLoadPromptTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"greet.yaml": `
+name: greet
+description: Greets someone
+arguments:
+  - name: name
+    description: Who to greet
+    required: true
+template: Say hello to {{.name}}.
+`,
+		"greet.json": `{
+			"name": "greet",
+			"description": "Greets someone",
+			"arguments": [{"name": "name", "description": "Who to greet", "required": true}],
+			"template": "Say hello to {{.name}}."
+		}`,
+	}
+
+	for file, content := range files {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(dir, file)
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+			tmpl, err := LoadPromptTemplateFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, mcp.Prompt{
+				Name:        "greet",
+				Description: "Greets someone",
+				Arguments:   []mcp.PromptArgument{{Name: "name", Description: "Who to greet", Required: true}},
+			}, tmpl.Prompt())
+
+			text, err := tmpl.Render(map[string]string{"name": "Ada"})
+			require.NoError(t, err)
+			assert.Equal(t, "Say hello to Ada.", text)
+		})
+	}
+
+	noName := filepath.Join(dir, "unnamed.json")
+	require.NoError(t, os.WriteFile(noName, []byte(`{"template": "hi"}`), 0o644))
+	_, err := LoadPromptTemplateFile(noName)
+	assert.ErrorContains(t, err, "has no name")
+
+	_, err = LoadPromptTemplateFile(filepath.Join(dir, "prompt.txt"))
+	assert.Error(t, err)
+}
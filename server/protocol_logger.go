@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultProtocolLogMaxBytes is the size above which logged protocol messages
+// are truncated unless overridden with WithProtocolLogMaxBytes.
+const defaultProtocolLogMaxBytes = 4096
+
+// Directions attached to protocol log entries.
+const (
+	protocolDirectionRecv = "recv"
+	protocolDirectionSend = "send"
+)
+
+// protocolLogger logs the raw JSON-RPC traffic handled by the server.
+type protocolLogger struct {
+	logger   *slog.Logger
+	level    slog.Level
+	maxBytes int
+}
+
+// WithProtocolLogging logs the JSON-RPC traffic of the server at the given
+// level: every message received by HandleMessage and every response it
+// produces, the notifications sent to clients, such as list_changed and
+// progress notifications, and the requests the server sends to clients, such
+// as sampling and elicitation requests, with their results. Each entry carries
+// a "direction" attribute set to "recv" or "send". This is intended for
+// debugging and is usually paired with a low level such as slog.LevelDebug.
+func WithProtocolLogging(logger *slog.Logger, level slog.Level) ServerOption {
+	return func(s *MCPServer) {
+		if logger == nil {
+			s.protocolLogger = nil
+			return
+		}
+		s.protocolLogger = &protocolLogger{
+			logger: logger,
+			level:  level,
+		}
+	}
+}
+
+// WithProtocolLogMaxBytes sets the size above which messages logged by
+// WithProtocolLogging are truncated. A value of zero or less disables truncation.
+func WithProtocolLogMaxBytes(maxBytes int) ServerOption {
+	return func(s *MCPServer) {
+		s.protocolLogMaxBytes = maxBytes
+	}
+}
+
+// logRaw logs an already encoded message.
+func (l *protocolLogger) logRaw(ctx context.Context, direction string, message json.RawMessage) {
+	if l == nil || !l.logger.Enabled(ctx, l.level) {
+		return
+	}
+
+	text := string(message)
+	if l.maxBytes > 0 && len(text) > l.maxBytes {
+		// Cut at a rune boundary so the logged text stays valid UTF-8
+		end := l.maxBytes
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		text = fmt.Sprintf("%s [truncated at %d bytes]", text[:end], l.maxBytes)
+	}
+
+	l.logger.LogAttrs(ctx, l.level, "mcp protocol message",
+		slog.String("direction", direction),
+		slog.String("message", text),
+	)
+}
+
+// logMessage encodes and logs an outgoing message. Nil messages, such as the
+// result of handling a notification, are not logged.
+func (l *protocolLogger) logMessage(ctx context.Context, direction string, message mcp.JSONRPCMessage) {
+	if l == nil || message == nil || !l.logger.Enabled(ctx, l.level) {
+		return
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		l.logger.LogAttrs(ctx, l.level, "mcp protocol message",
+			slog.String("direction", direction),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	l.logRaw(ctx, direction, data)
+}
+
+// logRequest logs a request the server sends to a client, and returns a
+// function logging its result or error once the client answered. The session
+// assigns the JSON-RPC ID when it writes the request, so the entries carry
+// none.
+func (l *protocolLogger) logRequest(ctx context.Context, method mcp.MCPMethod, params any) func(result any, err error) {
+	if l == nil {
+		return func(any, error) {}
+	}
+
+	request := map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"method":  method,
+	}
+	if params != nil {
+		request["params"] = params
+	}
+	l.logMessage(ctx, protocolDirectionSend, request)
+
+	return func(result any, err error) {
+		if err != nil {
+			l.logMessage(ctx, protocolDirectionRecv, map[string]any{
+				"jsonrpc": mcp.JSONRPC_VERSION,
+				"error":   map[string]any{"message": err.Error()},
+			})
+			return
+		}
+		l.logMessage(ctx, protocolDirectionRecv, map[string]any{
+			"jsonrpc": mcp.JSONRPC_VERSION,
+			"result":  result,
+		})
+	}
+}
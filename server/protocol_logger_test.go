@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeProtocolLogEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestMCPServer_ProtocolLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogging(logger, slog.LevelDebug),
+	)
+	server.AddTool(mcp.NewTool("echo"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("hello"), nil
+	})
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/call",
+		"params": {"name": "echo"}
+	}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "recv", entries[0]["direction"])
+	assert.Equal(t, "DEBUG", entries[0]["level"])
+	assert.Contains(t, entries[0]["message"], `"tools/call"`)
+
+	assert.Equal(t, "send", entries[1]["direction"])
+	assert.Contains(t, entries[1]["message"], "hello")
+}
+
+func TestMCPServer_ProtocolLoggingNotification(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogging(logger, slog.LevelInfo),
+	)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"method": "notifications/initialized"
+	}`))
+	assert.Nil(t, response)
+
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "recv", entries[0]["direction"])
+}
+
+func TestMCPServer_ProtocolLoggingTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogging(logger, slog.LevelInfo),
+		WithProtocolLogMaxBytes(16),
+	)
+
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, `{"jsonrpc": "2.0 [truncated at 16 bytes]`, entries[0]["message"])
+}
+
+func TestMCPServer_ProtocolLoggingTruncationOptionOrder(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogMaxBytes(27),
+		WithProtocolLogging(logger, slog.LevelInfo),
+	)
+
+	// The limit falls inside the two bytes of "é", which is not split
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": "é", "method": "ping"}`))
+
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 2)
+	assert.Equal(t, `{"jsonrpc": "2.0", "id": " [truncated at 27 bytes]`, entries[0]["message"])
+}
+
+func TestMCPServer_ProtocolLoggingBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogging(logger, slog.LevelDebug),
+	)
+
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+	assert.Empty(t, buf.String())
+}
+
+func TestMCPServer_ProtocolLoggingServerInitiated(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	server := NewMCPServer("test-server", "1.0.0",
+		WithProtocolLogging(logger, slog.LevelInfo),
+	)
+	server.EnableSampling()
+	session := &mockSamplingSession{
+		mockSession: mockSession{sessionID: "test-session"},
+		result: &mcp.CreateMessageResult{
+			SamplingMessage: mcp.SamplingMessage{
+				Role:    mcp.RoleAssistant,
+				Content: mcp.NewTextContent("sampled"),
+			},
+			Model: "test-model",
+		},
+	}
+	ctx := server.WithContext(context.Background(), session)
+
+	require.NoError(t, server.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": "token",
+		"progress":      1,
+	}))
+	_, err := server.RequestSampling(ctx, mcp.CreateMessageRequest{
+		CreateMessageParams: mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("question")}},
+		},
+	})
+	require.NoError(t, err)
+
+	session.err = errors.New("sampling declined")
+	_, err = server.RequestSampling(ctx, mcp.CreateMessageRequest{})
+	require.Error(t, err)
+
+	entries := decodeProtocolLogEntries(t, &buf)
+	require.Len(t, entries, 5)
+
+	assert.Equal(t, "send", entries[0]["direction"])
+	assert.Contains(t, entries[0]["message"], `"notifications/progress"`)
+
+	assert.Equal(t, "send", entries[1]["direction"])
+	assert.Contains(t, entries[1]["message"], `"sampling/createMessage"`)
+	assert.Contains(t, entries[1]["message"], "question")
+	assert.Equal(t, "recv", entries[2]["direction"])
+	assert.Contains(t, entries[2]["message"], "sampled")
+
+	assert.Equal(t, "send", entries[3]["direction"])
+	assert.Equal(t, "recv", entries[4]["direction"])
+	assert.Contains(t, entries[4]["message"], "sampling declined")
+}
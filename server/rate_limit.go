@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter decides whether a client session may make another tool call,
+// resource read or prompt get.
+type RateLimiter interface {
+	// Allow reports whether the session may make a request now, consuming
+	// its share of the limit if so. The session ID is "" for requests
+	// without a session.
+	Allow(sessionID string) bool
+}
+
+// Limiter is a rate limiter shared by all requests it is used for. It is
+// implemented by *rate.Limiter from golang.org/x/time/rate.
+type Limiter interface {
+	Allow() bool
+}
+
+// WithRateLimiter makes the server check limiter before dispatching each tool
+// call, resource read and prompt get. Requests over the limit fail with a
+// TOO_MANY_REQUESTS error.
+func WithRateLimiter(limiter RateLimiter) ServerOption {
+	return func(s *MCPServer) {
+		s.rateLimiter = limiter
+	}
+}
+
+// NewTokenBucketRateLimiter returns a RateLimiter allowing each session rps
+// requests per second on average, with bursts of up to burst requests. Each
+// session gets its own rate.Limiter from golang.org/x/time/rate.
+func NewTokenBucketRateLimiter(rps float64, burst int) RateLimiter {
+	return NewPerSessionRateLimiter(func() Limiter {
+		return rate.NewLimiter(rate.Limit(rps), burst)
+	})
+}
+
+// NewPerSessionRateLimiter returns a RateLimiter giving each session its own
+// Limiter, created by newLimiter when the session makes its first request.
+// For example, to use golang.org/x/time/rate:
+//
+//	server.NewPerSessionRateLimiter(func() server.Limiter {
+//		return rate.NewLimiter(10, 20)
+//	})
+func NewPerSessionRateLimiter(newLimiter func() Limiter) RateLimiter {
+	return &perSessionRateLimiter{
+		newLimiter: newLimiter,
+		limiters:   make(map[string]Limiter),
+	}
+}
+
+type perSessionRateLimiter struct {
+	newLimiter func() Limiter
+
+	mu       sync.Mutex
+	limiters map[string]Limiter
+}
+
+func (l *perSessionRateLimiter) Allow(sessionID string) bool {
+	l.mu.Lock()
+	limiter, ok := l.limiters[sessionID]
+	if !ok {
+		limiter = l.newLimiter()
+		l.limiters[sessionID] = limiter
+	}
+	l.mu.Unlock()
+	return limiter.Allow()
+}
+
+// RemoveSession drops the limiter of a session that has ended.
+func (l *perSessionRateLimiter) RemoveSession(sessionID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.limiters, sessionID)
+}
+
+// checkRateLimit returns a TOO_MANY_REQUESTS error if the session of ctx has
+// exceeded the rate limit of the server.
+func (s *MCPServer) checkRateLimit(ctx context.Context, id any, method mcp.MCPMethod) *requestError {
+	if s.rateLimiter == nil {
+		return nil
+	}
+
+	var sessionID string
+	if session := ClientSessionFromContext(ctx); session != nil {
+		sessionID = session.SessionID()
+	}
+	if s.rateLimiter.Allow(sessionID) {
+		return nil
+	}
+	return &requestError{
+		id:   id,
+		code: mcp.TOO_MANY_REQUESTS,
+		err:  fmt.Errorf("rate limit exceeded for %s: %w", method, mcp.ErrTooManyRequests),
+	}
+}
+
+// forgetRateLimitSession releases the rate limiter state of an ended session.
+func (s *MCPServer) forgetRateLimitSession(sessionID string) {
+	if limiter, ok := s.rateLimiter.(interface{ RemoveSession(string) }); ok {
+		limiter.RemoveSession(sessionID)
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLimiter allows a fixed number of requests.
+type countingLimiter struct {
+	remaining int
+}
+
+func (l *countingLimiter) Allow() bool {
+	if l.remaining == 0 {
+		return false
+	}
+	l.remaining--
+	return true
+}
+
+func TestMCPServer_WithRateLimiter(t *testing.T) {
+	limiter := NewPerSessionRateLimiter(func() Limiter { return &countingLimiter{remaining: 3} })
+	s := NewMCPServer("test-server", "1.0.0", WithRateLimiter(limiter))
+	s.AddTool(mcp.NewTool("echo"), textToolHandler("echo"))
+	s.AddPrompt(mcp.NewPrompt("greeting"), func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult("greeting", nil), nil
+	})
+	s.AddResource(mcp.NewResource("test://resource", "resource"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "content"}}, nil
+	})
+
+	sessionCtx := func(id string) context.Context {
+		session := &fakeSession{sessionID: id, notificationChannel: make(chan mcp.JSONRPCNotification, 1), initialized: true}
+		require.NoError(t, s.RegisterSession(context.Background(), session))
+		return s.WithContext(context.Background(), session)
+	}
+	first := sessionCtx("first")
+	second := sessionCtx("second")
+
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"prompts/get","params":{"name":"greeting"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"test://resource"}}`,
+	}
+	for _, request := range requests {
+		response := s.HandleMessage(first, []byte(request))
+		_, ok := response.(mcp.JSONRPCResponse)
+		assert.True(t, ok, "expected %s to succeed, got %#v", request, response)
+	}
+
+	// Every limited method is refused once the limit is reached
+	for _, request := range requests {
+		response := s.HandleMessage(first, []byte(request))
+		errorResponse, ok := response.(mcp.JSONRPCError)
+		require.True(t, ok, "expected %s to be rate limited, got %#v", request, response)
+		assert.Equal(t, mcp.TOO_MANY_REQUESTS, errorResponse.Error.Code)
+		assert.Contains(t, errorResponse.Error.Message, "rate limit exceeded")
+	}
+
+	// Other methods are not limited
+	response := s.HandleMessage(first, []byte(`{"jsonrpc":"2.0","id":4,"method":"tools/list"}`))
+	_, ok := response.(mcp.JSONRPCResponse)
+	assert.True(t, ok)
+
+	// Each session has its own limit
+	response = s.HandleMessage(second, []byte(requests[0]))
+	_, ok = response.(mcp.JSONRPCResponse)
+	assert.True(t, ok)
+
+	// The limiter of an ended session is released
+	s.UnregisterSession(context.Background(), "first")
+	assert.NotContains(t, limiter.(*perSessionRateLimiter).limiters, "first")
+	assert.Contains(t, limiter.(*perSessionRateLimiter).limiters, "second")
+}
+
+func TestNewTokenBucketRateLimiter(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(0.001, 2)
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("b"))
+
+	// Tokens are refilled at rps per second
+	limiter = NewTokenBucketRateLimiter(100, 1)
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+	time.Sleep(20 * time.Millisecond)
+	assert.True(t, limiter.Allow("a"))
+}
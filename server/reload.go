@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithAutoReload makes the server call Reload every interval, so that changes
+// in its providers reach clients without an explicit reload. A failing reload
+// leaves the server untouched and is retried at the next interval. The
+// reloading stops when the server is shut down.
+func WithAutoReload(interval time.Duration) ServerOption {
+	return func(s *MCPServer) {
+		s.autoReloadInterval = interval
+	}
+}
+
+// startAutoReload starts the goroutine calling Reload every interval until
+// Shutdown is called. Shutdown waits for a reload in progress to finish.
+func (s *MCPServer) startAutoReload(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.onShutdown(func(context.Context) error {
+		cancel()
+		<-done
+		return nil
+	})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = s.Reload()
+			}
+		}
+	}()
+}
+
+// Reload queries all registered tool, prompt and resource providers again.
+// Tools and prompts a provider no longer offers are removed, and new or
+// changed ones are registered. Clients are sent the list_changed
+// notification of each kind of capability whose list changed, if the server
+// declared the listChanged capability for it.
+//
+// All providers are queried before anything is changed, so if any of them
+// fails, the server is left untouched.
+func (s *MCPServer) Reload() error {
+	s.providersMu.Lock()
+	defer s.providersMu.Unlock()
+
+	tools := make([][]ServerTool, len(s.toolProviders))
+	for i, entry := range s.toolProviders {
+		loaded, err := loadProviderTools(entry.provider)
+		if err != nil {
+			return err
+		}
+		tools[i] = loaded
+	}
+
+	prompts := make([][]ServerPrompt, len(s.promptProviders))
+	for i, entry := range s.promptProviders {
+		loaded, err := loadProviderPrompts(entry.provider)
+		if err != nil {
+			return err
+		}
+		prompts[i] = loaded
+	}
+
+	s.resourcesMu.RLock()
+	resourceProviders := s.resourceProviders
+	s.resourcesMu.RUnlock()
+	resourceURIs := make([][]string, len(resourceProviders))
+	for i, provider := range resourceProviders {
+		uris, err := listProviderURIs(context.Background(), provider)
+		if err != nil {
+			return err
+		}
+		resourceURIs[i] = uris
+	}
+
+	toolsChanged := s.reloadProviderTools(tools)
+	promptsChanged := s.reloadProviderPrompts(prompts)
+
+	// Resources of providers are listed on demand, so there is nothing to
+	// update but the snapshot used to tell whether the list changed.
+	resourcesChanged := false
+	for i, uris := range resourceURIs {
+		if !slices.Equal(s.resourceProviderURIs[i], uris) {
+			resourcesChanged = true
+		}
+		s.resourceProviderURIs[i] = uris
+	}
+
+	if toolsChanged && s.capabilitiesSnapshot().toolsListChanged() {
+		s.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
+	}
+	if promptsChanged && s.capabilitiesSnapshot().promptsListChanged() {
+		s.SendNotificationToAllClients(mcp.MethodNotificationPromptsListChanged, nil)
+	}
+	if resourcesChanged && s.capabilitiesSnapshot().resourcesListChanged() {
+		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	}
+	return nil
+}
+
+// reloadProviderTools replaces the tools of each tool provider with the newly
+// loaded ones and reports whether the list of tools changed.
+func (s *MCPServer) reloadProviderTools(loaded [][]ServerTool) bool {
+	changed := false
+	s.tools.update(func(tools map[string]ServerTool) {
+		for i, entry := range s.toolProviders {
+			names := serverToolNames(loaded[i])
+			for _, name := range entry.names {
+				if !slices.Contains(names, name) {
+					delete(tools, name)
+					changed = true
+				}
+			}
+			for _, tool := range loaded[i] {
+				if existing, ok := tools[tool.Tool.Name]; !ok || !sameWireForm(existing.Tool, tool.Tool) {
+					changed = true
+				}
+				tools[tool.Tool.Name] = tool
+			}
+			entry.names = names
+		}
+	})
+	return changed
+}
+
+// reloadProviderPrompts replaces the prompts of each prompt provider with the
+// newly loaded ones and reports whether the list of prompts changed.
+func (s *MCPServer) reloadProviderPrompts(loaded [][]ServerPrompt) bool {
+	s.promptsMu.Lock()
+	defer s.promptsMu.Unlock()
+
+	changed := false
+	for i, entry := range s.promptProviders {
+		names := serverPromptNames(loaded[i])
+		for _, name := range entry.names {
+			if !slices.Contains(names, name) {
+				delete(s.prompts, name)
+				delete(s.promptHandlers, name)
+				changed = true
+			}
+		}
+		for _, prompt := range loaded[i] {
+			if existing, ok := s.prompts[prompt.Prompt.Name]; !ok || !sameWireForm(existing, prompt.Prompt) {
+				changed = true
+			}
+			s.prompts[prompt.Prompt.Name] = prompt.Prompt
+			s.promptHandlers[prompt.Prompt.Name] = prompt.Handler
+		}
+		entry.names = names
+	}
+	return changed
+}
+
+// sameWireForm reports whether a and b, tools or prompts, are sent the same
+// to clients. Unlike reflect.DeepEqual, it ignores what clients never see,
+// such as argument completers, whose funcs are never deeply equal.
+func sameWireForm(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
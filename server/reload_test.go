@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPromptProvider serves a replaceable set of prompts.
+type testPromptProvider struct {
+	mu      sync.Mutex
+	prompts []mcp.Prompt
+}
+
+func (p *testPromptProvider) set(prompts ...mcp.Prompt) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts = prompts
+}
+
+func (p *testPromptProvider) Prompts() ([]mcp.Prompt, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.prompts, nil
+}
+
+func (p *testPromptProvider) Handler(name string) (PromptHandlerFunc, error) {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return mcp.NewGetPromptResult(name, nil), nil
+	}, nil
+}
+
+func listPromptNames(t *testing.T, s *MCPServer) []string {
+	t.Helper()
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	result, ok := resp.Result.(mcp.ListPromptsResult)
+	require.True(t, ok)
+	names := make([]string, len(result.Prompts))
+	for i, prompt := range result.Prompts {
+		names[i] = prompt.Name
+	}
+	return names
+}
+
+func drainNotifications(ch chan mcp.JSONRPCNotification) []string {
+	var methods []string
+	for {
+		select {
+		case notification := <-ch:
+			methods = append(methods, notification.Method)
+		case <-time.After(50 * time.Millisecond):
+			return methods
+		}
+	}
+}
+
+func TestMCPServer_ReloadPromptProvider(t *testing.T) {
+	s := NewMCPServer("test-server", "1.0.0", WithPromptCapabilities(true))
+
+	provider := &testPromptProvider{}
+	provider.set(mcp.NewPrompt("alpha"), mcp.NewPrompt("beta"))
+	require.NoError(t, s.RegisterPromptProvider(provider))
+	assert.ElementsMatch(t, []string{"alpha", "beta"}, listPromptNames(t, s))
+
+	provider.set(mcp.NewPrompt("beta"), mcp.NewPrompt("gamma"))
+	require.NoError(t, s.Reload())
+	assert.ElementsMatch(t, []string{"beta", "gamma"}, listPromptNames(t, s))
+}
+
+func TestMCPServer_ReloadNotifications(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("a"), 0o644))
+
+	s := NewMCPServer("test-server", "1.0.0",
+		WithToolCapabilities(true),
+		WithPromptCapabilities(true),
+		WithResourceCapabilities(false, true),
+	)
+	tools := &switchingToolProvider{}
+	tools.set(NewStaticToolProvider(ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a")}), nil)
+	require.NoError(t, s.RegisterToolProvider(tools))
+	prompts := &testPromptProvider{}
+	prompts.set(mcp.NewPrompt("alpha"))
+	require.NoError(t, s.RegisterPromptProvider(prompts))
+	require.NoError(t, s.RegisterResourceProvider(NewFilesystemResourceProvider(root)))
+
+	notifications := make(chan mcp.JSONRPCNotification, 10)
+	require.NoError(t, s.RegisterSession(context.Background(), &fakeSession{
+		sessionID:           "test",
+		notificationChannel: notifications,
+		initialized:         true,
+	}))
+
+	// Nothing changed
+	require.NoError(t, s.Reload())
+	assert.Empty(t, drainNotifications(notifications))
+
+	// Only the resources changed
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b.txt"), []byte("b"), 0o644))
+	require.NoError(t, s.Reload())
+	assert.Equal(t, []string{mcp.MethodNotificationResourcesListChanged}, drainNotifications(notifications))
+
+	// Tools and prompts changed
+	tools.set(NewStaticToolProvider(ServerTool{Tool: mcp.NewTool("beta"), Handler: textToolHandler("b")}), nil)
+	prompts.set()
+	require.NoError(t, s.Reload())
+	assert.ElementsMatch(t, []string{
+		mcp.MethodNotificationToolsListChanged,
+		mcp.MethodNotificationPromptsListChanged,
+	}, drainNotifications(notifications))
+	assert.Empty(t, listPromptNames(t, s))
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "b.txt")
+}
+
+func TestMCPServer_ReloadUnchangedWithCompleters(t *testing.T) {
+	completer := mcp.CompletionProviderFunc(func(ctx context.Context, prefix string) ([]string, error) {
+		return []string{prefix}, nil
+	})
+	// Providers build their tools and prompts anew on every call
+	newTools := func() ToolProvider {
+		tool := mcp.NewTool("alpha", mcp.WithString("city")).WithArgumentCompleter("city", completer)
+		return NewStaticToolProvider(ServerTool{Tool: tool, Handler: textToolHandler("a")})
+	}
+	newPrompt := func() mcp.Prompt {
+		return mcp.NewPrompt("alpha", mcp.WithArgument("city")).WithArgumentCompleter("city", completer)
+	}
+
+	s := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(true), WithPromptCapabilities(true))
+	tools := &switchingToolProvider{}
+	tools.set(newTools(), nil)
+	require.NoError(t, s.RegisterToolProvider(tools))
+	prompts := &testPromptProvider{}
+	prompts.set(newPrompt())
+	require.NoError(t, s.RegisterPromptProvider(prompts))
+
+	notifications := make(chan mcp.JSONRPCNotification, 10)
+	require.NoError(t, s.RegisterSession(context.Background(), &fakeSession{
+		sessionID:           "test",
+		notificationChannel: notifications,
+		initialized:         true,
+	}))
+
+	tools.set(newTools(), nil)
+	prompts.set(newPrompt())
+	require.NoError(t, s.Reload())
+	assert.Empty(t, drainNotifications(notifications))
+}
+
+func TestMCPServer_WithAutoReload(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	s := NewMCPServer("test-server", "1.0.0", WithAutoReload(interval))
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+
+	provider := &switchingToolProvider{}
+	provider.set(NewStaticToolProvider(ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a")}), nil)
+	require.NoError(t, s.RegisterToolProvider(provider))
+
+	provider.set(NewStaticToolProvider(
+		ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a")},
+		ServerTool{Tool: mcp.NewTool("beta"), Handler: textToolHandler("b")},
+	), nil)
+	time.Sleep(2 * interval)
+
+	assert.Eventually(t, func() bool {
+		return len(s.ListTools()) == 2
+	}, time.Second, interval)
+	assert.Equal(t, "b", callToolText(t, s, "beta"))
+}
+
+func TestMCPServer_WithAutoReloadStopsOnShutdown(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	s := NewMCPServer("test-server", "1.0.0", WithAutoReload(interval))
+
+	provider := &switchingToolProvider{}
+	provider.set(NewStaticToolProvider(ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a")}), nil)
+	require.NoError(t, s.RegisterToolProvider(provider))
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	provider.set(NewStaticToolProvider(), nil)
+	time.Sleep(5 * interval)
+	assert.NotNil(t, s.GetTool("alpha"))
+}
@@ -11,22 +11,49 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// HandleMessage processes an incoming JSON-RPC message and returns an appropriate response
+// jsonrpcEnvelope holds the fields common to all JSON-RPC messages
+type jsonrpcEnvelope struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  mcp.MCPMethod `json:"method"`
+	ID      any           `json:"id,omitempty"`
+	Result  any           `json:"result,omitempty"`
+}
+
+// HandleMessage processes an incoming JSON-RPC message and returns an appropriate response.
+// The messages of a JSON-RPC batch are handled in order, and their responses returned as an array.
 func (s *MCPServer) HandleMessage(
 	ctx context.Context,
 	message json.RawMessage,
-) mcp.JSONRPCMessage {
+) (response mcp.JSONRPCMessage) {
+	if isBatch(message) {
+		return s.handleBatch(ctx, message)
+	}
+
+	// Track the message so GracefulShutdown can wait for it
+	if !s.beginMessage() {
+		return rejectMessage(message)
+	}
+	defer s.inFlight.Done()
+
 	// Add server to context
 	ctx = context.WithValue(ctx, serverKey{}, s)
-	var err *requestError
 
-	var baseMessage struct {
-		JSONRPC string        `json:"jsonrpc"`
-		Method  mcp.MCPMethod `json:"method"`
-		ID      any           `json:"id,omitempty"`
-		Result  any           `json:"result,omitempty"`
+	s.protocolLogger.logRaw(ctx, protocolDirectionRecv, message)
+	defer func() {
+		s.protocolLogger.logMessage(ctx, protocolDirectionSend, response)
+	}()
+
+	// Replace responses larger than WithMaxResponseBodySize with an error
+	defer func() {
+		response = s.limitResponseSize(response)
+	}()
+
+	if s.maxRequestBodySize > 0 && int64(len(message)) > s.maxRequestBodySize {
+		return createErrorResponse(nil, mcp.PARSE_ERROR, s.requestTooLargeMessage())
 	}
 
+	var baseMessage jsonrpcEnvelope
+
 	if err := json.Unmarshal(message, &baseMessage); err != nil {
 		return createErrorResponse(
 			nil,
@@ -63,6 +90,25 @@ func (s *MCPServer) HandleMessage(
 		return nil
 	}
 
+	if errResp := s.checkInitialized(ctx, baseMessage); errResp != nil {
+		return errResp
+	}
+
+	// Track the request so Shutdown can cancel it if it does not finish in time
+	ctx, untrack := s.trackRequest(ctx, baseMessage.ID)
+	defer untrack()
+
+	return s.handleRequestWithMiddleware(ctx, baseMessage, message)
+}
+
+// handleRequest dispatches a request to the handler of its method
+func (s *MCPServer) handleRequest(
+	ctx context.Context,
+	baseMessage jsonrpcEnvelope,
+	message json.RawMessage,
+) mcp.JSONRPCMessage {
+	var err *requestError
+
 	handleErr := s.hooks.onRequestInitialization(ctx, baseMessage.ID, message)
 	if handleErr != nil {
 		return createErrorResponse(
@@ -93,7 +139,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeInitialize(ctx, baseMessage.ID, &request)
-			result, err = s.handleInitialize(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleInitialize(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -113,7 +161,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforePing(ctx, baseMessage.ID, &request)
-			result, err = s.handlePing(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handlePing(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -124,7 +174,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodSetLogLevel:
 		var request mcp.SetLevelRequest
 		var result *mcp.EmptyResult
-		if s.capabilities.logging == nil {
+		if s.capabilitiesSnapshot().logging == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -139,7 +189,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeSetLevel(ctx, baseMessage.ID, &request)
-			result, err = s.handleSetLevel(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleSetLevel(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -150,7 +202,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodResourcesList:
 		var request mcp.ListResourcesRequest
 		var result *mcp.ListResourcesResult
-		if s.capabilities.resources == nil {
+		if s.capabilitiesSnapshot().resources == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -165,7 +217,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeListResources(ctx, baseMessage.ID, &request)
-			result, err = s.handleListResources(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleListResources(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -176,7 +230,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodResourcesTemplatesList:
 		var request mcp.ListResourceTemplatesRequest
 		var result *mcp.ListResourceTemplatesResult
-		if s.capabilities.resources == nil {
+		if s.capabilitiesSnapshot().resources == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -191,7 +245,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeListResourceTemplates(ctx, baseMessage.ID, &request)
-			result, err = s.handleListResourceTemplates(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleListResourceTemplates(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -202,7 +258,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodResourcesRead:
 		var request mcp.ReadResourceRequest
 		var result *mcp.ReadResourceResult
-		if s.capabilities.resources == nil {
+		if s.capabilitiesSnapshot().resources == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -217,7 +273,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeReadResource(ctx, baseMessage.ID, &request)
-			result, err = s.handleReadResource(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleReadResource(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -228,7 +286,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodPromptsList:
 		var request mcp.ListPromptsRequest
 		var result *mcp.ListPromptsResult
-		if s.capabilities.prompts == nil {
+		if s.capabilitiesSnapshot().prompts == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -243,7 +301,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeListPrompts(ctx, baseMessage.ID, &request)
-			result, err = s.handleListPrompts(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleListPrompts(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -254,7 +314,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodPromptsGet:
 		var request mcp.GetPromptRequest
 		var result *mcp.GetPromptResult
-		if s.capabilities.prompts == nil {
+		if s.capabilitiesSnapshot().prompts == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -269,7 +329,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeGetPrompt(ctx, baseMessage.ID, &request)
-			result, err = s.handleGetPrompt(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleGetPrompt(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -280,7 +342,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodToolsList:
 		var request mcp.ListToolsRequest
 		var result *mcp.ListToolsResult
-		if s.capabilities.tools == nil {
+		if s.capabilitiesSnapshot().tools == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -295,7 +357,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeListTools(ctx, baseMessage.ID, &request)
-			result, err = s.handleListTools(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleListTools(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -306,7 +370,7 @@ func (s *MCPServer) HandleMessage(
 	case mcp.MethodToolsCall:
 		var request mcp.CallToolRequest
 		var result *mcp.CallToolResult
-		if s.capabilities.tools == nil {
+		if s.capabilitiesSnapshot().tools == nil {
 			err = &requestError{
 				id:   baseMessage.ID,
 				code: mcp.METHOD_NOT_FOUND,
@@ -321,7 +385,9 @@ func (s *MCPServer) HandleMessage(
 		} else {
 			request.Header = headers
 			s.hooks.beforeCallTool(ctx, baseMessage.ID, &request)
-			result, err = s.handleToolCall(ctx, baseMessage.ID, request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleToolCall(hookCtx, baseMessage.ID, request)
+			finish(result, err)
 		}
 		if err != nil {
 			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
@@ -329,11 +395,35 @@ func (s *MCPServer) HandleMessage(
 		}
 		s.hooks.afterCallTool(ctx, baseMessage.ID, &request, result)
 		return createResponse(baseMessage.ID, *result)
+	case mcp.MethodCompletionComplete:
+		var request mcp.CompleteRequest
+		var result *mcp.CompleteResult
+		if s.capabilitiesSnapshot().completions == nil {
+			err = &requestError{
+				id:   baseMessage.ID,
+				code: mcp.METHOD_NOT_FOUND,
+				err:  fmt.Errorf("completions %w", ErrUnsupported),
+			}
+		} else if unmarshalErr := json.Unmarshal(message, &request); unmarshalErr != nil {
+			err = &requestError{
+				id:   baseMessage.ID,
+				code: mcp.INVALID_REQUEST,
+				err:  &UnparsableMessageError{message: message, err: unmarshalErr, method: baseMessage.Method},
+			}
+		} else {
+			request.Header = headers
+			s.hooks.beforeComplete(ctx, baseMessage.ID, &request)
+			hookCtx, finish := s.startRequestHook(ctx, baseMessage.Method, &request)
+			result, err = s.handleComplete(hookCtx, baseMessage.ID, request)
+			finish(result, err)
+		}
+		if err != nil {
+			s.hooks.onError(ctx, baseMessage.ID, baseMessage.Method, &request, err)
+			return err.ToJSONRPCError()
+		}
+		s.hooks.afterComplete(ctx, baseMessage.ID, &request, result)
+		return createResponse(baseMessage.ID, *result)
 	default:
-		return createErrorResponse(
-			baseMessage.ID,
-			mcp.METHOD_NOT_FOUND,
-			fmt.Sprintf("Method %s not found", baseMessage.Method),
-		)
+		return s.handleCustomMethod(ctx, baseMessage, message)
 	}
 }
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RequestHook is called before the server handles a request, with the request
+// method and the parsed request. The returned context is passed to the
+// handler, which allows a hook to start a tracing span or attach other values.
+// The returned function is called once the handler has returned, with the
+// result on success or the error on failure. It may be nil.
+type RequestHook func(ctx context.Context, method string, req any) (context.Context, func(resp any, err error))
+
+// SetRequestHook installs a hook wrapped around the handling of every request,
+// for example to record OpenTelemetry spans or Prometheus metrics. Passing nil
+// removes the hook. Notifications are not passed to the hook.
+func (s *MCPServer) SetRequestHook(hook RequestHook) {
+	s.requestHookMu.Lock()
+	defer s.requestHookMu.Unlock()
+	s.requestHook = hook
+}
+
+// WithRequestHook installs the request hook at construction, as
+// SetRequestHook does.
+func WithRequestHook(hook RequestHook) ServerOption {
+	return func(s *MCPServer) {
+		s.SetRequestHook(hook)
+	}
+}
+
+// startRequestHook calls the request hook, if any, and returns the context for
+// the handler along with a function to report the handler's outcome.
+func (s *MCPServer) startRequestHook(
+	ctx context.Context,
+	method mcp.MCPMethod,
+	request any,
+) (context.Context, func(result any, err *requestError)) {
+	s.requestHookMu.RLock()
+	hook := s.requestHook
+	s.requestHookMu.RUnlock()
+
+	if hook == nil {
+		return ctx, func(any, *requestError) {}
+	}
+
+	hookCtx, finish := hook(ctx, string(method), request)
+	if hookCtx == nil {
+		hookCtx = ctx
+	}
+	return hookCtx, func(result any, err *requestError) {
+		if finish == nil {
+			return
+		}
+		// Avoid passing typed nils, which would not compare equal to nil
+		if err != nil {
+			finish(nil, err)
+			return
+		}
+		finish(result, nil)
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type requestHookKey struct{}
+
+type recordedRequest struct {
+	method string
+	req    any
+	resp   any
+	err    error
+}
+
+func TestMCPServer_RequestHook(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddTool(mcp.NewTool("traced"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// The context returned by the hook is passed to the handler
+		span, _ := ctx.Value(requestHookKey{}).(string)
+		return mcp.NewToolResultText(span), nil
+	})
+
+	var mu sync.Mutex
+	var recorded []recordedRequest
+	server.SetRequestHook(func(ctx context.Context, method string, req any) (context.Context, func(resp any, err error)) {
+		ctx = context.WithValue(ctx, requestHookKey{}, "span-"+method)
+		return ctx, func(resp any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded = append(recorded, recordedRequest{method: method, req: req, resp: resp, err: err})
+		}
+	})
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/call",
+		"params": {"name": "traced"}
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := resp.Result.(mcp.CallToolResult)
+	require.True(t, ok)
+	assert.Equal(t, "span-tools/call", result.Content[0].(mcp.TextContent).Text)
+
+	response = server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 2,
+		"method": "tools/call",
+		"params": {"name": "missing"}
+	}`))
+	_, ok = response.(mcp.JSONRPCError)
+	require.True(t, ok)
+
+	// Notifications are not passed to the hook
+	server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"method": "notifications/initialized"
+	}`))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, recorded, 2)
+
+	assert.Equal(t, "tools/call", recorded[0].method)
+	assert.IsType(t, &mcp.CallToolRequest{}, recorded[0].req)
+	assert.IsType(t, &mcp.CallToolResult{}, recorded[0].resp)
+	assert.NoError(t, recorded[0].err)
+
+	assert.Equal(t, "tools/call", recorded[1].method)
+	assert.Nil(t, recorded[1].resp)
+	assert.ErrorIs(t, recorded[1].err, ErrToolNotFound)
+}
+
+func TestMCPServer_RequestHookRemoved(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+
+	calls := 0
+	server.SetRequestHook(func(ctx context.Context, method string, req any) (context.Context, func(resp any, err error)) {
+		calls++
+		return ctx, nil
+	})
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+	assert.Equal(t, 1, calls)
+
+	server.SetRequestHook(nil)
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 2, "method": "ping"}`))
+	assert.Equal(t, 1, calls)
+}
+
+func TestMCPServer_WithRequestHook(t *testing.T) {
+	var methods []string
+	server := NewMCPServer("test-server", "1.0.0", WithRequestHook(
+		func(ctx context.Context, method string, req any) (context.Context, func(resp any, err error)) {
+			methods = append(methods, method)
+			return ctx, nil
+		},
+	))
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`))
+	assert.Equal(t, []string{"ping"}, methods)
+}
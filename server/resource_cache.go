@@ -0,0 +1,159 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ResourceCache stores the contents of resources read by clients, so that
+// repeated resources/read requests for the same URI do not call the resource
+// handler each time. Implementations must be safe for concurrent use.
+type ResourceCache interface {
+	// Get returns the cached contents of the resource, if any and not expired.
+	Get(uri string) ([]mcp.ResourceContents, bool)
+	// Set caches the contents of the resource for ttl, or until evicted if
+	// ttl is zero.
+	Set(uri string, contents []mcp.ResourceContents, ttl time.Duration)
+	// Invalidate removes the contents of the resource from the cache.
+	Invalidate(uri string)
+}
+
+// SetResourceCache sets the cache of the contents of resources read through
+// the server, or removes it if cache is nil. Cached contents do not expire
+// unless set with WithResourceCache, but are invalidated by
+// NotifyResourceUpdated. Resources registered for a single session are never
+// cached.
+func (s *MCPServer) SetResourceCache(cache ResourceCache) {
+	s.resourceCacheMu.Lock()
+	defer s.resourceCacheMu.Unlock()
+	s.resourceCache = cache
+}
+
+// WithResourceCache sets the cache of the contents of resources, as
+// SetResourceCache does, with contents expiring after ttl.
+func WithResourceCache(cache ResourceCache, ttl time.Duration) ServerOption {
+	return func(s *MCPServer) {
+		s.SetResourceCache(cache)
+		s.resourceCacheTTL = ttl
+	}
+}
+
+// NotifyResourceUpdated tells the clients that the resource with the given
+// URI changed, with a notifications/resources/updated notification, after
+// removing its contents from the resource cache.
+func (s *MCPServer) NotifyResourceUpdated(uri string) {
+	s.resourceCacheMu.RLock()
+	cache := s.resourceCache
+	s.resourceCacheMu.RUnlock()
+	if cache != nil {
+		cache.Invalidate(uri)
+	}
+
+	s.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{
+		"uri": uri,
+	})
+}
+
+// cachedResourceHandler returns handler reading the contents of resources
+// from the resource cache when present, and adding them to it otherwise.
+func (s *MCPServer) cachedResourceHandler(handler ResourceHandlerFunc) ResourceHandlerFunc {
+	s.resourceCacheMu.RLock()
+	cache, ttl := s.resourceCache, s.resourceCacheTTL
+	s.resourceCacheMu.RUnlock()
+	if cache == nil {
+		return handler
+	}
+
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if contents, ok := cache.Get(request.Params.URI); ok {
+			return contents, nil
+		}
+		contents, err := handler(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		cache.Set(request.Params.URI, contents, ttl)
+		return contents, nil
+	}
+}
+
+// inMemoryResourceCache is a ResourceCache evicting the least recently used
+// entries once full.
+type inMemoryResourceCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List // Of *resourceCacheEntry, most recently used first
+}
+
+type resourceCacheEntry struct {
+	uri       string
+	contents  []mcp.ResourceContents
+	expiresAt time.Time // Zero if the entry does not expire
+}
+
+// NewInMemoryResourceCache creates a ResourceCache holding the contents of at
+// most maxEntries resources in memory, evicting the least recently used ones
+// first. A maxEntries of zero or less means no limit.
+func NewInMemoryResourceCache(maxEntries int) ResourceCache {
+	return &inMemoryResourceCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func (c *inMemoryResourceCache) Get(uri string) ([]mcp.ResourceContents, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[uri]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*resourceCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, uri)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return slices.Clone(entry.contents), true
+}
+
+func (c *inMemoryResourceCache) Set(uri string, contents []mcp.ResourceContents, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &resourceCacheEntry{uri: uri, contents: slices.Clone(contents)}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	if element, ok := c.entries[uri]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[uri] = c.lru.PushFront(entry)
+
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*resourceCacheEntry).uri)
+	}
+}
+
+func (c *inMemoryResourceCache) Invalidate(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[uri]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, uri)
+	}
+}
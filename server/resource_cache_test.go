@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryResourceCache(t *testing.T) {
+	contents := func(uri string) []mcp.ResourceContents {
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, Text: uri}}
+	}
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		cache := NewInMemoryResourceCache(2)
+		cache.Set("test://a", contents("test://a"), 0)
+		cache.Set("test://b", contents("test://b"), 0)
+
+		// Reading a makes b the least recently used entry
+		_, ok := cache.Get("test://a")
+		require.True(t, ok)
+		cache.Set("test://c", contents("test://c"), 0)
+
+		_, ok = cache.Get("test://b")
+		assert.False(t, ok)
+		got, ok := cache.Get("test://a")
+		assert.True(t, ok)
+		assert.Equal(t, contents("test://a"), got)
+		_, ok = cache.Get("test://c")
+		assert.True(t, ok)
+	})
+
+	t.Run("expires entries", func(t *testing.T) {
+		cache := NewInMemoryResourceCache(10)
+		cache.Set("test://a", contents("test://a"), time.Millisecond)
+		cache.Set("test://b", contents("test://b"), 0)
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := cache.Get("test://a")
+		assert.False(t, ok)
+		_, ok = cache.Get("test://b")
+		assert.True(t, ok)
+	})
+
+	t.Run("invalidates entries", func(t *testing.T) {
+		cache := NewInMemoryResourceCache(10)
+		cache.Set("test://a", contents("test://a"), 0)
+		cache.Invalidate("test://a")
+
+		_, ok := cache.Get("test://a")
+		assert.False(t, ok)
+	})
+}
+
+func TestMCPServer_ResourceCache(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithResourceCapabilities(true, false))
+	server.SetResourceCache(NewInMemoryResourceCache(10))
+
+	var calls int
+	server.AddResource(mcp.NewResource("test://resource", "resource"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		calls++
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "content"}}, nil
+	})
+	server.AddResourceTemplate(mcp.NewResourceTemplate("test://items/{id}", "item"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		calls++
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "item"}}, nil
+	})
+
+	read := func(uri string) {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "resources/read",
+			"params": {"uri": "`+uri+`"}
+		}`))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		result, ok := resp.Result.(mcp.ReadResourceResult)
+		require.True(t, ok)
+		require.Len(t, result.Contents, 1)
+	}
+
+	read("test://resource")
+	read("test://resource")
+	assert.Equal(t, 1, calls)
+
+	read("test://items/1")
+	read("test://items/1")
+	read("test://items/2")
+	assert.Equal(t, 3, calls)
+
+	server.NotifyResourceUpdated("test://resource")
+	read("test://resource")
+	assert.Equal(t, 4, calls)
+	read("test://items/1")
+	assert.Equal(t, 4, calls)
+}
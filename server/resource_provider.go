@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ResourceProvider supplies a dynamic set of resources, such as the files of
+// a directory, without registering each resource individually.
+type ResourceProvider interface {
+	// List returns the resources currently available from the provider.
+	List(ctx context.Context) ([]mcp.Resource, error)
+	// Read returns the contents of the resource identified by uri. It must
+	// return an error wrapping ErrResourceNotFound if the provider does not
+	// serve uri.
+	Read(ctx context.Context, uri string) (mcp.ResourceContents, error)
+}
+
+// RegisterResourceProvider registers a resource provider. Its resources are
+// listed alongside the registered resources, which take precedence when their
+// URIs collide, and are read whenever no resource or template matches a URI.
+//
+// The provider is listed first, and an error is returned if that fails. The
+// listed resources are the baseline Reload compares against to decide whether
+// the list of resources changed.
+func (s *MCPServer) RegisterResourceProvider(provider ResourceProvider) error {
+	uris, err := listProviderURIs(context.Background(), provider)
+	if err != nil {
+		return err
+	}
+
+	s.implicitlyRegisterResourceCapabilities()
+
+	s.providersMu.Lock()
+	defer s.providersMu.Unlock()
+
+	s.resourcesMu.Lock()
+	s.resourceProviders = append(s.resourceProviders, provider)
+	s.resourceProviderURIs = append(s.resourceProviderURIs, uris)
+	s.resourcesMu.Unlock()
+
+	if s.capabilitiesSnapshot().resourcesListChanged() {
+		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
+	}
+	return nil
+}
+
+// listProviderURIs returns the sorted URIs of the resources of provider. The
+// result is never nil.
+func listProviderURIs(ctx context.Context, provider ResourceProvider) ([]string, error) {
+	resources, err := provider.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider resources: %w", err)
+	}
+	uris := make([]string, len(resources))
+	for i, resource := range resources {
+		uris[i] = resource.URI
+	}
+	slices.Sort(uris)
+	return uris, nil
+}
+
+// providerResourceHandler returns a handler reading from the first of
+// providers that serves the requested URI.
+func providerResourceHandler(providers []ResourceProvider) ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		for _, provider := range providers {
+			contents, err := provider.Read(ctx, request.Params.URI)
+			if errors.Is(err, ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return []mcp.ResourceContents{contents}, nil
+		}
+		return nil, fmt.Errorf(
+			"handler not found for resource URI '%s': %w",
+			request.Params.URI,
+			ErrResourceNotFound,
+		)
+	}
+}
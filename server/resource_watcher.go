@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ResourceWatcher polls a resource of a server and notifies the clients
+// with NotifyResourceUpdated when its contents change, for resources whose
+// handlers cannot push change events themselves, such as files.
+type ResourceWatcher struct {
+	server   *MCPServer
+	uri      string
+	interval time.Duration
+}
+
+// NewResourceWatcher creates a ResourceWatcher reading the resource at uri
+// from server every interval once started.
+func NewResourceWatcher(server *MCPServer, uri string, interval time.Duration) *ResourceWatcher {
+	return &ResourceWatcher{
+		server:   server,
+		uri:      uri,
+		interval: interval,
+	}
+}
+
+// Start starts polling the resource in the background until ctx is
+// cancelled. The contents read when it starts are the baseline later reads
+// are compared to, through a hash of their JSON encoding. Failed reads are
+// ignored. Reads go through the handlers and middlewares of resources/read,
+// so with a resource cache, changes are seen once the cached contents expire.
+func (w *ResourceWatcher) Start(ctx context.Context) {
+	last, _ := w.read(ctx)
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				hash, ok := w.read(ctx)
+				if !ok || hash == last {
+					continue
+				}
+				last = hash
+				if ctx.Err() == nil {
+					w.server.NotifyResourceUpdated(w.uri)
+				}
+			}
+		}
+	}()
+}
+
+// read returns the hash of the contents of the resource, or false if it
+// cannot be read.
+func (w *ResourceWatcher) read(ctx context.Context) ([sha256.Size]byte, bool) {
+	request := mcp.ReadResourceRequest{}
+	request.Method = string(mcp.MethodResourcesRead)
+	request.Params.URI = w.uri
+	result, reqErr := w.server.handleReadResource(ctx, nil, request)
+	if reqErr != nil {
+		return [sha256.Size]byte{}, false
+	}
+	data, err := json.Marshal(result.Contents)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(data), true
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceWatcher(t *testing.T) {
+	var content atomic.Value
+	content.Store("v1")
+
+	server := NewMCPServer("test", "1.0.0", WithResourceCapabilities(true, false))
+	server.AddResource(mcp.NewResource("file:///config.json", "config"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{mcp.TextResourceContents{
+				URI:  request.Params.URI,
+				Text: content.Load().(string),
+			}}, nil
+		})
+
+	session := fakeSession{
+		sessionID:           "watcher",
+		notificationChannel: make(chan mcp.JSONRPCNotification, 10),
+		initialized:         true,
+	}
+	require.NoError(t, server.RegisterSession(context.Background(), session))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	NewResourceWatcher(server, "file:///config.json", 10*time.Millisecond).Start(ctx)
+
+	// Unchanged contents are not notified
+	select {
+	case notification := <-session.notificationChannel:
+		t.Fatalf("unexpected notification %v", notification)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	content.Store("v2")
+	select {
+	case notification := <-session.notificationChannel:
+		assert.Equal(t, mcp.MethodNotificationResourceUpdated, notification.Method)
+		assert.Equal(t, "file:///config.json", notification.Params.AdditionalFields["uri"])
+	case <-time.After(time.Second):
+		t.Fatal("change not notified")
+	}
+
+	// Each change is notified once
+	select {
+	case notification := <-session.notificationChannel:
+		t.Fatalf("unexpected notification %v", notification)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Changes are not notified once the context is cancelled
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	content.Store("v3")
+	select {
+	case notification := <-session.notificationChannel:
+		t.Fatalf("unexpected notification %v", notification)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -17,6 +17,7 @@ var (
 // RequestRoots sends an list roots request to the client.
 // The client must have declared roots capability during initialization.
 // The session must implement SessionWithRoots to support this operation.
+// A successful result is also stored as the session's cached roots.
 func (s *MCPServer) RequestRoots(ctx context.Context, request mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
 	session := ClientSessionFromContext(ctx)
 	if session == nil {
@@ -25,8 +26,66 @@ func (s *MCPServer) RequestRoots(ctx context.Context, request mcp.ListRootsReque
 
 	// Check if the session supports roots requests
 	if rootsSession, ok := session.(SessionWithRoots); ok {
-		return rootsSession.ListRoots(ctx, request)
+		logResult := s.protocolLogger.logRequest(ctx, mcp.MethodListRoots, nil)
+		result, err := rootsSession.ListRoots(ctx, request)
+		logResult(result, err)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			s.rootsCache.Store(session.SessionID(), result.Roots)
+		}
+		return result, nil
 	}
 
 	return nil, ErrRootsNotSupported
 }
+
+// CachedRoots returns the roots most recently fetched from the client session
+// in ctx, either by RequestRoots or by the automatic refresh performed when the
+// client sends a roots list changed notification. The boolean is false if no
+// roots have been fetched for the session yet.
+func (s *MCPServer) CachedRoots(ctx context.Context) ([]mcp.Root, bool) {
+	session := ClientSessionFromContext(ctx)
+	if session == nil {
+		return nil, false
+	}
+
+	roots, ok := s.rootsCache.Load(session.SessionID())
+	if !ok {
+		return nil, false
+	}
+	return roots.([]mcp.Root), true
+}
+
+// refreshRoots re-fetches the roots of the client session in ctx after the
+// client reported that its roots changed. It only runs when the roots
+// capability is enabled. The request is sent from a separate goroutine because
+// the client's response is usually delivered by the same transport loop that
+// is dispatching the notification.
+func (s *MCPServer) refreshRoots(ctx context.Context) {
+	s.capabilitiesMu.RLock()
+	enabled := s.capabilities.roots != nil && *s.capabilities.roots
+	s.capabilitiesMu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	session := ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	if _, ok := session.(SessionWithRoots); !ok {
+		return
+	}
+
+	// The notification's context may be cancelled once it has been dispatched
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		_, _ = s.RequestRoots(ctx, mcp.ListRootsRequest{
+			Request: mcp.Request{
+				Method: string(mcp.MethodListRoots),
+			},
+		})
+	}()
+}
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
@@ -238,3 +240,110 @@ func TestRequestRoots(t *testing.T) {
 		})
 	}
 }
+
+// mockChangingRootsSession implements SessionWithRoots with roots that can be
+// replaced while the server is fetching them concurrently
+type mockChangingRootsSession struct {
+	mockBasicRootsSession
+	mu    sync.Mutex
+	roots []mcp.Root
+	calls int
+}
+
+func (m *mockChangingRootsSession) setRoots(roots []mcp.Root) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roots = roots
+}
+
+func (m *mockChangingRootsSession) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+func (m *mockChangingRootsSession) ListRoots(ctx context.Context, request mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return &mcp.ListRootsResult{Roots: m.roots}, nil
+}
+
+func TestMCPServer_RootsListChangedRefreshesRoots(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0", WithRoots())
+
+	handlerCalled := make(chan struct{}, 2)
+	server.AddNotificationHandler(mcp.MethodNotificationRootsListChanged, func(ctx context.Context, notification mcp.JSONRPCNotification) {
+		handlerCalled <- struct{}{}
+	})
+
+	session := &mockChangingRootsSession{
+		mockBasicRootsSession: mockBasicRootsSession{sessionID: "roots-session"},
+		roots:                 []mcp.Root{{Name: "first", URI: "file:///first"}},
+	}
+	ctx := server.WithContext(context.Background(), session)
+	require.NoError(t, server.RegisterSession(ctx, session))
+
+	_, ok := server.CachedRoots(ctx)
+	assert.False(t, ok)
+
+	notify := func() {
+		response := server.HandleMessage(ctx, []byte(`{
+			"jsonrpc": "2.0",
+			"method": "notifications/roots/list_changed"
+		}`))
+		assert.Nil(t, response)
+		<-handlerCalled
+	}
+
+	notify()
+	require.Eventually(t, func() bool {
+		roots, ok := server.CachedRoots(ctx)
+		return ok && len(roots) == 1 && roots[0].Name == "first"
+	}, time.Second, 10*time.Millisecond)
+
+	updated := []mcp.Root{
+		{Name: "second", URI: "file:///second"},
+		{Name: "third", URI: "file:///third"},
+	}
+	session.setRoots(updated)
+
+	notify()
+	require.Eventually(t, func() bool {
+		roots, _ := server.CachedRoots(ctx)
+		return len(roots) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	roots, ok := server.CachedRoots(ctx)
+	require.True(t, ok)
+	assert.Equal(t, updated, roots)
+
+	result, err := server.RequestRoots(ctx, mcp.ListRootsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, updated, result.Roots)
+
+	server.UnregisterSession(ctx, session.SessionID())
+	_, ok = server.CachedRoots(ctx)
+	assert.False(t, ok)
+}
+
+func TestMCPServer_RootsListChangedWithoutRootsCapability(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0")
+
+	session := &mockChangingRootsSession{
+		mockBasicRootsSession: mockBasicRootsSession{sessionID: "roots-session"},
+	}
+	ctx := server.WithContext(context.Background(), session)
+
+	server.HandleMessage(ctx, []byte(`{
+		"jsonrpc": "2.0",
+		"method": "notifications/roots/list_changed"
+	}`))
+
+	assert.Never(t, func() bool {
+		return session.callCount() > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	_, ok := server.CachedRoots(ctx)
+	assert.False(t, ok)
+}
@@ -17,6 +17,14 @@ func (s *MCPServer) EnableSampling() {
 	s.capabilities.sampling = &enabled
 }
 
+// WithSampling enables sampling capabilities at construction, as
+// EnableSampling does.
+func WithSampling() ServerOption {
+	return func(s *MCPServer) {
+		s.EnableSampling()
+	}
+}
+
 // RequestSampling sends a sampling request to the client.
 // The client must have declared sampling capability during initialization.
 func (s *MCPServer) RequestSampling(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
@@ -27,12 +35,18 @@ func (s *MCPServer) RequestSampling(ctx context.Context, request mcp.CreateMessa
 
 	// Check if the session supports sampling requests
 	if samplingSession, ok := session.(SessionWithSampling); ok {
-		return samplingSession.RequestSampling(ctx, request)
+		logResult := s.protocolLogger.logRequest(ctx, mcp.MethodSamplingCreateMessage, request.CreateMessageParams)
+		result, err := samplingSession.RequestSampling(ctx, request)
+		logResult(result, err)
+		return result, err
 	}
 
 	// Check for inprocess sampling handler in context
 	if handler := InProcessSamplingHandlerFromContext(ctx); handler != nil {
-		return handler.CreateMessage(ctx, request)
+		logResult := s.protocolLogger.logRequest(ctx, mcp.MethodSamplingCreateMessage, request.CreateMessageParams)
+		result, err := handler.CreateMessage(ctx, request)
+		logResult(result, err)
+		return result, err
 	}
 
 	return nil, fmt.Errorf("session does not support sampling")
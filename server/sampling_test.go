@@ -152,3 +152,10 @@ func TestMCPServer_EnableSampling_SetsCapability(t *testing.T) {
 		t.Error("sampling capability should be set after EnableSampling() is called")
 	}
 }
+
+func TestMCPServer_WithSampling(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0", WithSampling())
+	if server.capabilities.sampling == nil || !*server.capabilities.sampling {
+		t.Error("sampling capability should be enabled by WithSampling")
+	}
+}
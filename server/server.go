@@ -4,13 +4,17 @@ package server
 import (
 	"cmp"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -51,6 +55,10 @@ type ResourceHandlerMiddleware func(ResourceHandlerFunc) ResourceHandlerFunc
 // ToolFilterFunc is a function that filters tools based on context, typically using session information.
 type ToolFilterFunc func(ctx context.Context, tools []mcp.Tool) []mcp.Tool
 
+// ResourceFilterFunc is a function that filters the resources returned by resources/list,
+// typically using session information.
+type ResourceFilterFunc func(ctx context.Context, resources []mcp.Resource) []mcp.Resource
+
 // ServerTool combines a Tool with its ToolHandlerFunc.
 type ServerTool struct {
 	Tool    mcp.Tool
@@ -123,10 +131,18 @@ func (e *requestError) Error() string {
 }
 
 func (e *requestError) ToJSONRPCError() mcp.JSONRPCError {
+	details := mcp.NewJSONRPCErrorDetails(e.code, e.err.Error(), nil)
+
+	// A structured error from a handler determines the code and data sent to the client
+	var mcpErr *mcp.MCPError
+	if errors.As(e.err, &mcpErr) {
+		details = mcpErr.Details()
+	}
+
 	return mcp.JSONRPCError{
 		JSONRPC: mcp.JSONRPC_VERSION,
 		ID:      mcp.NewRequestId(e.id),
-		Error:   mcp.NewJSONRPCErrorDetails(e.code, e.err.Error(), nil),
+		Error:   details,
 	}
 }
 
@@ -137,6 +153,10 @@ func (e *requestError) Unwrap() error {
 // NotificationHandlerFunc handles incoming notifications.
 type NotificationHandlerFunc func(ctx context.Context, notification mcp.JSONRPCNotification)
 
+// NotificationHandler handles incoming notifications from their JSON encoded
+// params, which are nil if the notification has none.
+type NotificationHandler func(ctx context.Context, params json.RawMessage)
+
 // MCPServer implements a Model Context Protocol server that can handle various types of requests
 // including resources, prompts, and tools.
 type MCPServer struct {
@@ -144,28 +164,69 @@ type MCPServer struct {
 	resourcesMu            sync.RWMutex
 	resourceMiddlewareMu   sync.RWMutex
 	promptsMu              sync.RWMutex
-	toolsMu                sync.RWMutex
+	toolGroupsMu           sync.RWMutex
 	toolMiddlewareMu       sync.RWMutex
 	notificationHandlersMu sync.RWMutex
 	capabilitiesMu         sync.RWMutex
 	toolFiltersMu          sync.RWMutex
+	resourceFilterMu       sync.RWMutex
+	providersMu            sync.Mutex
+	requestHookMu          sync.RWMutex
+	middlewareMu           sync.RWMutex
+	resourceCacheMu        sync.RWMutex
+	shutdownMu             sync.RWMutex
 
 	name                       string
 	version                    string
 	instructions               string
 	resources                  map[string]resourceEntry
 	resourceTemplates          map[string]resourceTemplateEntry
+	resourceProviders          []ResourceProvider
 	prompts                    map[string]mcp.Prompt
 	promptHandlers             map[string]PromptHandlerFunc
-	tools                      map[string]ServerTool
+	tools                      *ToolRegistry
+	toolProviders              []*toolProviderEntry
+	toolGroups                 []*ToolGroup
+	promptProviders            []*promptProviderEntry
+	resourceProviderURIs       [][]string // per resource provider, as last listed
+	autoReloadInterval         time.Duration
 	toolHandlerMiddlewares     []ToolHandlerMiddleware
 	resourceHandlerMiddlewares []ResourceHandlerMiddleware
 	toolFilters                []ToolFilterFunc
+	resourceListFilter         ResourceFilterFunc
 	notificationHandlers       map[string]NotificationHandlerFunc
+	customMethodsMu            sync.RWMutex
+	customMethods              map[string]JSONRPCHandler
 	capabilities               serverCapabilities
 	paginationLimit            *int
 	sessions                   sync.Map
+	rootsCache                 sync.Map
 	hooks                      *Hooks
+	protocolLogger             *protocolLogger
+	protocolLogMaxBytes        int
+	shutdownTimeout            time.Duration
+	inFlight                   sync.WaitGroup
+	shuttingDown               bool
+	shutdownHooks              map[uint64]func(context.Context) error
+	nextShutdownHookID         uint64
+	activeRequests             sync.Map
+	maxRequestBodySize         int64
+	maxResponseBodySize        int64
+	requestHook                RequestHook
+	rateLimiter                RateLimiter
+	middlewares                []namedMiddleware
+	resourceCache              ResourceCache
+	resourceCacheTTL           time.Duration
+	latency                    latencyRecorder
+	strictInitialization       bool
+	capabilitiesNotifications  bool
+	tlsConfig                  *tls.Config
+	tlsEnabled                 bool
+	tlsCertFile                string
+	tlsKeyFile                 string
+	compression                bool
+	toolInputValidation        bool
+	initializeTimeout          atomic.Int64
 }
 
 // WithPaginationLimit sets the pagination limit for the server.
@@ -177,13 +238,15 @@ func WithPaginationLimit(limit int) ServerOption {
 
 // serverCapabilities defines the supported features of the MCP server
 type serverCapabilities struct {
-	tools       *toolCapabilities
-	resources   *resourceCapabilities
-	prompts     *promptCapabilities
-	logging     *bool
-	sampling    *bool
-	elicitation *bool
-	roots       *bool
+	tools        *toolCapabilities
+	resources    *resourceCapabilities
+	prompts      *promptCapabilities
+	logging      *bool
+	sampling     *bool
+	elicitation  *bool
+	roots        *bool
+	completions  *bool
+	experimental map[string]any
 }
 
 // resourceCapabilities defines the supported resource-related features
@@ -266,6 +329,23 @@ func WithToolFilter(
 	}
 }
 
+// SetResourceListFilter sets a function that filters resources before they are
+// returned by resources/list, regardless of any filters sent by the client.
+// Passing nil removes the filter.
+func (s *MCPServer) SetResourceListFilter(filter ResourceFilterFunc) {
+	s.resourceFilterMu.Lock()
+	defer s.resourceFilterMu.Unlock()
+	s.resourceListFilter = filter
+}
+
+// WithResourceListFilter sets the server-side resource filter at construction,
+// as SetResourceListFilter does.
+func WithResourceListFilter(filter ResourceFilterFunc) ServerOption {
+	return func(s *MCPServer) {
+		s.SetResourceListFilter(filter)
+	}
+}
+
 // WithRecovery adds a middleware that recovers from panics in tool handlers.
 func WithRecovery() ServerOption {
 	return WithToolHandlerMiddleware(func(next ToolHandlerFunc) ToolHandlerFunc {
@@ -351,12 +431,13 @@ func NewMCPServer(
 		resourceTemplates:          make(map[string]resourceTemplateEntry),
 		prompts:                    make(map[string]mcp.Prompt),
 		promptHandlers:             make(map[string]PromptHandlerFunc),
-		tools:                      make(map[string]ServerTool),
+		tools:                      NewToolRegistry(),
 		toolHandlerMiddlewares:     make([]ToolHandlerMiddleware, 0),
 		resourceHandlerMiddlewares: make([]ResourceHandlerMiddleware, 0),
 		name:                       name,
 		version:                    version,
 		notificationHandlers:       make(map[string]NotificationHandlerFunc),
+		protocolLogMaxBytes:        defaultProtocolLogMaxBytes,
 		capabilities: serverCapabilities{
 			tools:     nil,
 			resources: nil,
@@ -369,6 +450,14 @@ func NewMCPServer(
 		opt(s)
 	}
 
+	if s.protocolLogger != nil {
+		s.protocolLogger.maxBytes = s.protocolLogMaxBytes
+	}
+
+	if s.autoReloadInterval > 0 {
+		s.startAutoReload(s.autoReloadInterval)
+	}
+
 	return s
 }
 
@@ -391,7 +480,7 @@ func (s *MCPServer) AddResources(resources ...ServerResource) {
 	s.resourcesMu.Unlock()
 
 	// When the list of available resources changes, servers that declared the listChanged capability SHOULD send a notification
-	if s.capabilities.resources.listChanged {
+	if s.capabilitiesSnapshot().resourcesListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
 	}
@@ -426,7 +515,7 @@ func (s *MCPServer) DeleteResources(uris ...string) {
 	s.resourcesMu.Unlock()
 
 	// Send notification to all initialized sessions if listChanged capability is enabled and we actually remove a resource
-	if exists && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+	if exists && s.capabilitiesSnapshot().resourcesListChanged() {
 		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
 	}
 }
@@ -441,7 +530,7 @@ func (s *MCPServer) RemoveResource(uri string) {
 	s.resourcesMu.Unlock()
 
 	// Send notification to all initialized sessions if listChanged capability is enabled and we actually remove a resource
-	if exists && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+	if exists && s.capabilitiesSnapshot().resourcesListChanged() {
 		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
 	}
 }
@@ -460,7 +549,7 @@ func (s *MCPServer) AddResourceTemplates(resourceTemplates ...ServerResourceTemp
 	s.resourcesMu.Unlock()
 
 	// When the list of available resources changes, servers that declared the listChanged capability SHOULD send a notification
-	if s.capabilities.resources.listChanged {
+	if s.capabilitiesSnapshot().resourcesListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationResourcesListChanged, nil)
 	}
@@ -494,7 +583,7 @@ func (s *MCPServer) AddPrompts(prompts ...ServerPrompt) {
 	s.promptsMu.Unlock()
 
 	// When the list of available prompts changes, servers that declared the listChanged capability SHOULD send a notification.
-	if s.capabilities.prompts.listChanged {
+	if s.capabilitiesSnapshot().promptsListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationPromptsListChanged, nil)
 	}
@@ -528,7 +617,7 @@ func (s *MCPServer) DeletePrompts(names ...string) {
 	s.promptsMu.Unlock()
 
 	// Send notification to all initialized sessions if listChanged capability is enabled, and we actually remove a prompt
-	if exists && s.capabilities.prompts != nil && s.capabilities.prompts.listChanged {
+	if exists && s.capabilitiesSnapshot().promptsListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationPromptsListChanged, nil)
 	}
@@ -582,14 +671,10 @@ func (s *MCPServer) implicitlyRegisterCapabilities(check func() bool, register f
 func (s *MCPServer) AddTools(tools ...ServerTool) {
 	s.implicitlyRegisterToolCapabilities()
 
-	s.toolsMu.Lock()
-	for _, entry := range tools {
-		s.tools[entry.Tool.Name] = entry
-	}
-	s.toolsMu.Unlock()
+	s.tools.registerAll(tools...)
 
 	// When the list of available tools changes, servers that declared the listChanged capability SHOULD send a notification.
-	if s.capabilities.tools.listChanged {
+	if s.capabilitiesSnapshot().toolsListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
 	}
@@ -597,31 +682,26 @@ func (s *MCPServer) AddTools(tools ...ServerTool) {
 
 // SetTools replaces all existing tools with the provided list
 func (s *MCPServer) SetTools(tools ...ServerTool) {
-	s.toolsMu.Lock()
-	s.tools = make(map[string]ServerTool, len(tools))
-	s.toolsMu.Unlock()
+	s.tools.clear()
 	s.AddTools(tools...)
 }
 
 // GetTool retrieves the specified tool
 func (s *MCPServer) GetTool(toolName string) *ServerTool {
-	s.toolsMu.RLock()
-	defer s.toolsMu.RUnlock()
-	if tool, ok := s.tools[toolName]; ok {
+	if tool, ok := s.tools.get(toolName); ok {
 		return &tool
 	}
 	return nil
 }
 
 func (s *MCPServer) ListTools() map[string]*ServerTool {
-	s.toolsMu.RLock()
-	defer s.toolsMu.RUnlock()
-	if len(s.tools) == 0 {
+	tools := s.tools.snapshot()
+	if len(tools) == 0 {
 		return nil
 	}
 	// Create a copy to prevent external modification
-	toolsCopy := make(map[string]*ServerTool, len(s.tools))
-	for name, tool := range s.tools {
+	toolsCopy := make(map[string]*ServerTool, len(tools))
+	for name, tool := range tools {
 		toolsCopy[name] = &tool
 	}
 	return toolsCopy
@@ -629,18 +709,10 @@ func (s *MCPServer) ListTools() map[string]*ServerTool {
 
 // DeleteTools removes tools from the server
 func (s *MCPServer) DeleteTools(names ...string) {
-	s.toolsMu.Lock()
-	var exists bool
-	for _, name := range names {
-		if _, ok := s.tools[name]; ok {
-			delete(s.tools, name)
-			exists = true
-		}
-	}
-	s.toolsMu.Unlock()
+	exists := s.tools.deregisterAll(names...)
 
 	// When the list of available tools changes, servers that declared the listChanged capability SHOULD send a notification.
-	if exists && s.capabilities.tools != nil && s.capabilities.tools.listChanged {
+	if exists && s.capabilitiesSnapshot().toolsListChanged() {
 		// Send notification to all initialized sessions
 		s.SendNotificationToAllClients(mcp.MethodNotificationToolsListChanged, nil)
 	}
@@ -656,57 +728,34 @@ func (s *MCPServer) AddNotificationHandler(
 	s.notificationHandlers[method] = handler
 }
 
+// RegisterNotificationHandler registers a handler for incoming notifications
+// of method, such as custom notifications, receiving their params as JSON.
+// Notifications of methods without a handler are ignored.
+func (s *MCPServer) RegisterNotificationHandler(method string, handler NotificationHandler) {
+	s.AddNotificationHandler(method, func(ctx context.Context, notification mcp.JSONRPCNotification) {
+		params := notification.Params
+		if len(params.Meta) == 0 && len(params.AdditionalFields) == 0 {
+			handler(ctx, nil)
+			return
+		}
+		if len(params.Meta) == 0 {
+			// Decoding notifications sets an empty _meta
+			params.Meta = nil
+		}
+		data, err := json.Marshal(params)
+		if err != nil {
+			return
+		}
+		handler(ctx, data)
+	})
+}
+
 func (s *MCPServer) handleInitialize(
 	ctx context.Context,
 	_ any,
 	request mcp.InitializeRequest,
 ) (*mcp.InitializeResult, *requestError) {
-	capabilities := mcp.ServerCapabilities{}
-
-	// Only add resource capabilities if they're configured
-	if s.capabilities.resources != nil {
-		capabilities.Resources = &struct {
-			Subscribe   bool `json:"subscribe,omitempty"`
-			ListChanged bool `json:"listChanged,omitempty"`
-		}{
-			Subscribe:   s.capabilities.resources.subscribe,
-			ListChanged: s.capabilities.resources.listChanged,
-		}
-	}
-
-	// Only add prompt capabilities if they're configured
-	if s.capabilities.prompts != nil {
-		capabilities.Prompts = &struct {
-			ListChanged bool `json:"listChanged,omitempty"`
-		}{
-			ListChanged: s.capabilities.prompts.listChanged,
-		}
-	}
-
-	// Only add tool capabilities if they're configured
-	if s.capabilities.tools != nil {
-		capabilities.Tools = &struct {
-			ListChanged bool `json:"listChanged,omitempty"`
-		}{
-			ListChanged: s.capabilities.tools.listChanged,
-		}
-	}
-
-	if s.capabilities.logging != nil && *s.capabilities.logging {
-		capabilities.Logging = &struct{}{}
-	}
-
-	if s.capabilities.sampling != nil && *s.capabilities.sampling {
-		capabilities.Sampling = &struct{}{}
-	}
-
-	if s.capabilities.elicitation != nil && *s.capabilities.elicitation {
-		capabilities.Elicitation = &struct{}{}
-	}
-
-	if s.capabilities.roots != nil && *s.capabilities.roots {
-		capabilities.Roots = &struct{}{}
-	}
+	capabilities := s.advertisedCapabilities()
 
 	result := mcp.InitializeResult{
 		ProtocolVersion: s.protocolVersion(request.Params.ProtocolVersion),
@@ -720,6 +769,7 @@ func (s *MCPServer) handleInitialize(
 
 	if session := ClientSessionFromContext(ctx); session != nil {
 		session.Initialize()
+		s.startInitializeTimer(session)
 
 		// Store client info if the session supports it
 		if sessionWithClientInfo, ok := session.(SessionWithClientInfo); ok {
@@ -844,8 +894,26 @@ func (s *MCPServer) handleListResources(
 	for uri, entry := range s.resources {
 		resourceMap[uri] = entry.resource
 	}
+	providers := s.resourceProviders
 	s.resourcesMu.RUnlock()
 
+	// Add the resources of providers, unless registered directly
+	for _, provider := range providers {
+		providerResources, err := provider.List(ctx)
+		if err != nil {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.INTERNAL_ERROR,
+				err:  err,
+			}
+		}
+		for _, resource := range providerResources {
+			if _, exists := resourceMap[resource.URI]; !exists {
+				resourceMap[resource.URI] = resource
+			}
+		}
+	}
+
 	// Check if there are session-specific resources
 	session := ClientSessionFromContext(ctx)
 	if session != nil {
@@ -859,11 +927,25 @@ func (s *MCPServer) handleListResources(
 		}
 	}
 
+	// Apply the filters requested by the client
+	for uri, resource := range resourceMap {
+		if !request.MatchesFilter(resource) {
+			delete(resourceMap, uri)
+		}
+	}
+
 	// Sort the resources by name
 	resourcesList := slices.SortedFunc(maps.Values(resourceMap), func(a, b mcp.Resource) int {
 		return cmp.Compare(a.Name, b.Name)
 	})
 
+	// Apply the server-side filter before paginating
+	s.resourceFilterMu.RLock()
+	if s.resourceListFilter != nil {
+		resourcesList = s.resourceListFilter(ctx, resourcesList)
+	}
+	s.resourceFilterMu.RUnlock()
+
 	// Apply pagination
 	resourcesToReturn, nextCursor, err := listByPagination(
 		ctx,
@@ -945,11 +1027,29 @@ func (s *MCPServer) handleListResourceTemplates(
 	return &result, nil
 }
 
+// negotiateResourceContents converts the contents read from a resource to the
+// form preferred by the client. The contents are copied, since handlers may
+// return contents they share, such as cached ones.
+func negotiateResourceContents(contents []mcp.ResourceContents, preference mcp.ContentTypePreference) []mcp.ResourceContents {
+	if contents == nil {
+		return nil
+	}
+	negotiated := make([]mcp.ResourceContents, len(contents))
+	for i, c := range contents {
+		negotiated[i] = mcp.NegotiateResourceContents(c, preference)
+	}
+	return negotiated
+}
+
 func (s *MCPServer) handleReadResource(
 	ctx context.Context,
 	id any,
 	request mcp.ReadResourceRequest,
 ) (*mcp.ReadResourceResult, *requestError) {
+	if err := s.checkRateLimit(ctx, id, mcp.MethodResourcesRead); err != nil {
+		return nil, err
+	}
+
 	s.resourcesMu.RLock()
 
 	// First check session-specific resources
@@ -973,7 +1073,7 @@ func (s *MCPServer) handleReadResource(
 	if !ok {
 		globalResource, rok := s.resources[request.Params.URI]
 		if rok {
-			handler = globalResource.handler
+			handler = s.cachedResourceHandler(globalResource.handler)
 			ok = true
 		}
 	}
@@ -999,12 +1099,12 @@ func (s *MCPServer) handleReadResource(
 				err:  err,
 			}
 		}
-		return &mcp.ReadResourceResult{Contents: contents}, nil
+		return &mcp.ReadResourceResult{Contents: negotiateResourceContents(contents, request.Params.Accept)}, nil
 	}
 
 	// If no direct handler found, try matching against templates
 	var matchedHandler ResourceTemplateHandlerFunc
-	var matched bool
+	var matched, sessionMatched bool
 
 	// First check session templates if available
 	if session != nil {
@@ -1017,6 +1117,7 @@ func (s *MCPServer) handleReadResource(
 				if matchesTemplate(request.Params.URI, serverTemplate.Template.URITemplate) {
 					matchedHandler = serverTemplate.Handler
 					matched = true
+					sessionMatched = true
 					matchedVars := serverTemplate.Template.URITemplate.Match(request.Params.URI)
 					// Convert matched variables to a map
 					request.Params.Arguments = make(map[string]any, len(matchedVars))
@@ -1049,13 +1150,22 @@ func (s *MCPServer) handleReadResource(
 			}
 		}
 	}
+
+	// Fall back to resource providers
+	if !matched && len(s.resourceProviders) > 0 {
+		matchedHandler = ResourceTemplateHandlerFunc(providerResourceHandler(s.resourceProviders))
+		matched = true
+	}
 	s.resourcesMu.RUnlock()
 
 	if matched {
 		// If a match is found, then we have a final handler and can
 		// apply middlewares.
-		s.resourceMiddlewareMu.RLock()
 		finalHandler := ResourceHandlerFunc(matchedHandler)
+		if !sessionMatched {
+			finalHandler = s.cachedResourceHandler(finalHandler)
+		}
+		s.resourceMiddlewareMu.RLock()
 		mw := s.resourceHandlerMiddlewares
 		// Apply middlewares in reverse order
 		for i := len(mw) - 1; i >= 0; i-- {
@@ -1063,6 +1173,13 @@ func (s *MCPServer) handleReadResource(
 		}
 		s.resourceMiddlewareMu.RUnlock()
 		contents, err := finalHandler(ctx, request)
+		if errors.Is(err, ErrResourceNotFound) {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.RESOURCE_NOT_FOUND,
+				err:  err,
+			}
+		}
 		if err != nil {
 			return nil, &requestError{
 				id:   id,
@@ -1070,7 +1187,7 @@ func (s *MCPServer) handleReadResource(
 				err:  err,
 			}
 		}
-		return &mcp.ReadResourceResult{Contents: contents}, nil
+		return &mcp.ReadResourceResult{Contents: negotiateResourceContents(contents, request.Params.Accept)}, nil
 	}
 
 	return nil, &requestError{
@@ -1132,7 +1249,12 @@ func (s *MCPServer) handleGetPrompt(
 	id any,
 	request mcp.GetPromptRequest,
 ) (*mcp.GetPromptResult, *requestError) {
+	if err := s.checkRateLimit(ctx, id, mcp.MethodPromptsGet); err != nil {
+		return nil, err
+	}
+
 	s.promptsMu.RLock()
+	prompt := s.prompts[request.Params.Name]
 	handler, ok := s.promptHandlers[request.Params.Name]
 	s.promptsMu.RUnlock()
 
@@ -1144,6 +1266,15 @@ func (s *MCPServer) handleGetPrompt(
 		}
 	}
 
+	// Reject the request before invoking the handler if required arguments are missing
+	if err := mcp.ValidatePromptArguments(prompt, request.Params.Arguments); err != nil {
+		return nil, &requestError{
+			id:   id,
+			code: mcp.INVALID_PARAMS,
+			err:  err,
+		}
+	}
+
 	result, err := handler(ctx, request)
 	if err != nil {
 		return nil, &requestError{
@@ -1161,24 +1292,8 @@ func (s *MCPServer) handleListTools(
 	id any,
 	request mcp.ListToolsRequest,
 ) (*mcp.ListToolsResult, *requestError) {
-	// Get the base tools from the server
-	s.toolsMu.RLock()
-	tools := make([]mcp.Tool, 0, len(s.tools))
-
-	// Get all tool names for consistent ordering
-	toolNames := make([]string, 0, len(s.tools))
-	for name := range s.tools {
-		toolNames = append(toolNames, name)
-	}
-
-	// Sort the tool names for consistent ordering
-	sort.Strings(toolNames)
-
-	// Add tools in sorted order
-	for _, name := range toolNames {
-		tools = append(tools, s.tools[name].Tool)
-	}
-	s.toolsMu.RUnlock()
+	// Get the base tools from the server, sorted by name for consistent ordering
+	tools := s.tools.List()
 
 	// Check if there are session-specific tools
 	session := ClientSessionFromContext(ctx)
@@ -1213,6 +1328,10 @@ func (s *MCPServer) handleListTools(
 		}
 	}
 
+	if request.Params.ExcludeDeprecated {
+		tools = slices.DeleteFunc(tools, func(tool mcp.Tool) bool { return tool.Deprecated })
+	}
+
 	// Apply tool filters if any are defined
 	s.toolFiltersMu.RLock()
 	if len(s.toolFilters) > 0 {
@@ -1246,35 +1365,35 @@ func (s *MCPServer) handleListTools(
 	return &result, nil
 }
 
-func (s *MCPServer) handleToolCall(
-	ctx context.Context,
-	id any,
-	request mcp.CallToolRequest,
-) (*mcp.CallToolResult, *requestError) {
+// lookupTool finds the tool a call refers to, preferring tools registered on
+// the client session in ctx over global tools.
+func (s *MCPServer) lookupTool(ctx context.Context, name string) (ServerTool, bool) {
 	// First check session-specific tools
-	var tool ServerTool
-	var ok bool
-
 	session := ClientSessionFromContext(ctx)
 	if session != nil {
-		if sessionWithTools, typeAssertOk := session.(SessionWithTools); typeAssertOk {
+		if sessionWithTools, ok := session.(SessionWithTools); ok {
 			if sessionTools := sessionWithTools.GetSessionTools(); sessionTools != nil {
-				var sessionOk bool
-				tool, sessionOk = sessionTools[request.Params.Name]
-				if sessionOk {
-					ok = true
+				if tool, ok := sessionTools[name]; ok {
+					return tool, true
 				}
 			}
 		}
 	}
 
 	// If not found in session tools, check global tools
-	if !ok {
-		s.toolsMu.RLock()
-		tool, ok = s.tools[request.Params.Name]
-		s.toolsMu.RUnlock()
+	return s.tools.get(name)
+}
+
+func (s *MCPServer) handleToolCall(
+	ctx context.Context,
+	id any,
+	request mcp.CallToolRequest,
+) (*mcp.CallToolResult, *requestError) {
+	if err := s.checkRateLimit(ctx, id, mcp.MethodToolsCall); err != nil {
+		return nil, err
 	}
 
+	tool, ok := s.lookupTool(ctx, request.Params.Name)
 	if !ok {
 		return nil, &requestError{
 			id:   id,
@@ -1283,6 +1402,16 @@ func (s *MCPServer) handleToolCall(
 		}
 	}
 
+	if tool.Tool.Deprecated {
+		s.notifyDeprecatedToolCall(ctx, tool.Tool)
+	}
+
+	if s.toolInputValidation {
+		if err := validateToolInput(id, tool.Tool, request); err != nil {
+			return nil, err
+		}
+	}
+
 	finalHandler := tool.Handler
 
 	s.toolMiddlewareMu.RLock()
@@ -1294,7 +1423,19 @@ func (s *MCPServer) handleToolCall(
 	}
 	s.toolMiddlewareMu.RUnlock()
 
+	// Tools with a timeout get their own deadline
+	if timeout := tool.Tool.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, ErrToolTimeout)
+		defer cancel()
+	}
+
 	result, err := finalHandler(ctx, request)
+	if tool.Tool.Timeout > 0 && context.Cause(ctx) == ErrToolTimeout {
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"timeout: tool '%s' did not complete within %s", request.Params.Name, tool.Tool.Timeout,
+		)), nil
+	}
 	if err != nil {
 		return nil, &requestError{
 			id:   id,
@@ -1303,6 +1444,17 @@ func (s *MCPServer) handleToolCall(
 		}
 	}
 
+	// Structured content must match the output schema of the tool
+	if result != nil && !result.IsError && result.StructuredContent != nil {
+		if err := tool.Tool.ValidateStructuredContent(result.StructuredContent); err != nil {
+			return nil, &requestError{
+				id:   id,
+				code: mcp.INTERNAL_ERROR,
+				err:  fmt.Errorf("tool '%s' returned structured content not matching its output schema: %w", request.Params.Name, err),
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -1310,6 +1462,13 @@ func (s *MCPServer) handleNotification(
 	ctx context.Context,
 	notification mcp.JSONRPCNotification,
 ) mcp.JSONRPCMessage {
+	switch notification.Method {
+	case mcp.MethodNotificationInitialized:
+		s.markClientReady(ctx)
+	case mcp.MethodNotificationRootsListChanged:
+		s.refreshRoots(ctx)
+	}
+
 	s.notificationHandlersMu.RLock()
 	handler, ok := s.notificationHandlers[notification.Method]
 	s.notificationHandlersMu.RUnlock()
@@ -379,6 +379,30 @@ func TestMCPServer_Tools(t *testing.T) {
 	}
 }
 
+func TestMCPServer_ListToolsIncludesDeprecation(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(true))
+	server.AddTool(
+		mcp.NewTool("old-tool").Deprecate("use new-tool instead"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("old"), nil
+		},
+	)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/list"
+	}`))
+
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok)
+
+	data, err := json.Marshal(resp.Result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"deprecated":true`)
+	assert.Contains(t, string(data), `"deprecationMessage":"use new-tool instead"`)
+}
+
 func TestMCPServer_HandleValidMessages(t *testing.T) {
 	server := NewMCPServer("test-server", "1.0.0",
 		WithResourceCapabilities(true, true),
@@ -810,6 +834,34 @@ func TestMCPServer_PromptHandling(t *testing.T) {
 	}
 }
 
+func TestMCPServer_GetPromptMissingRequiredArgument(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithPromptCapabilities(true))
+	server.AddPrompt(
+		mcp.NewPrompt("required-prompt",
+			mcp.WithArgument("query", mcp.RequiredArgument()),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			t.Fatal("handler must not be called when required arguments are missing")
+			return nil, nil
+		},
+	)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "prompts/get",
+		"params": {
+			"name": "required-prompt",
+			"arguments": {}
+		}
+	}`))
+
+	errResp, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_PARAMS, errResp.Error.Code)
+	assert.Contains(t, errResp.Error.Message, "query")
+}
+
 func TestMCPServer_Prompts(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -1247,6 +1299,86 @@ func TestMCPServer_Resources(t *testing.T) {
 	}
 }
 
+func TestMCPServer_ListResourcesFilter(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithResourceCapabilities(false, false))
+	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return nil, nil
+	}
+	server.AddResource(mcp.NewResource("file:///docs/a.txt", "a", mcp.WithMIMEType("text/plain")), handler)
+	server.AddResource(mcp.NewResource("file:///docs/b.json", "b", mcp.WithMIMEType("application/json")), handler)
+	server.AddResource(mcp.NewResource("file:///src/c.txt", "c", mcp.WithMIMEType("text/plain")), handler)
+
+	listNames := func(t *testing.T, message string) []string {
+		t.Helper()
+		response := server.HandleMessage(context.Background(), []byte(message))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+		result, ok := resp.Result.(mcp.ListResourcesResult)
+		require.True(t, ok)
+
+		var names []string
+		for _, resource := range result.Resources {
+			names = append(names, resource.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, listNames(t, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	assert.Equal(t, []string{"a", "c"}, listNames(t, `{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "resources/list",
+		"params": {"_meta": {"mimeType": "text/plain"}}
+	}`))
+	assert.Equal(t, []string{"a"}, listNames(t, `{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "resources/list",
+		"params": {"_meta": {"mimeType": "text/plain", "uriPrefix": "file:///docs/"}}
+	}`))
+
+	server.SetResourceListFilter(func(ctx context.Context, resources []mcp.Resource) []mcp.Resource {
+		var filtered []mcp.Resource
+		for _, resource := range resources {
+			if resource.Name != "a" {
+				filtered = append(filtered, resource)
+			}
+		}
+		return filtered
+	})
+	assert.Equal(t, []string{"b", "c"}, listNames(t, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	assert.Equal(t, []string{"c"}, listNames(t, `{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "resources/list",
+		"params": {"_meta": {"mimeType": "text/plain"}}
+	}`))
+
+	server.SetResourceListFilter(nil)
+	assert.Equal(t, []string{"a", "b", "c"}, listNames(t, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+}
+
+func TestMCPServer_WithResourceListFilter(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithResourceListFilter(
+		func(ctx context.Context, resources []mcp.Resource) []mcp.Resource {
+			return resources[:1]
+		},
+	))
+	for _, name := range []string{"a", "b"} {
+		server.AddResource(mcp.NewResource("test://"+name, name), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		})
+	}
+
+	response := server.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected JSONRPCResponse, got %T", response)
+	result, ok := resp.Result.(mcp.ListResourcesResult)
+	require.True(t, ok)
+	require.Len(t, result.Resources, 1)
+	assert.Equal(t, "a", result.Resources[0].Name)
+}
+
 func TestMCPServer_HandleInvalidMessages(t *testing.T) {
 	var errs []error
 	hooks := &Hooks{}
@@ -2127,6 +2259,34 @@ func TestMCPServer_WithRecover(t *testing.T) {
 	assert.Nil(t, errorResponse.Error.Data)
 }
 
+func TestMCPServer_ToolHandlerMCPError(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+
+	server.AddTool(
+		mcp.NewTool("strict-tool"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, fmt.Errorf("validating input: %w",
+				mcp.NewMCPError(mcp.INVALID_PARAMS, "missing field 'name'", map[string]any{"field": "name"}))
+		},
+	)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 5,
+		"method": "tools/call",
+		"params": {
+			"name": "strict-tool"
+		}
+	}`))
+
+	errorResponse, ok := response.(mcp.JSONRPCError)
+
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_PARAMS, errorResponse.Error.Code)
+	assert.Equal(t, "missing field 'name'", errorResponse.Error.Message)
+	assert.Equal(t, map[string]any{"field": "name"}, errorResponse.Error.Data)
+}
+
 func getTools(length int) []mcp.Tool {
 	list := make([]mcp.Tool, 0, 10000)
 	for i := range length {
@@ -2866,3 +3026,167 @@ func TestMCPServer_ListTools(t *testing.T) {
 		assert.Contains(t, tools3, "test-tool")
 	})
 }
+
+func TestMCPServer_ValidatesStructuredContent(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(false))
+	tool := mcp.NewTool("weather", mcp.WithRawOutputSchema(json.RawMessage(`{
+		"type": "object",
+		"properties": {"temperature": {"type": "number"}},
+		"required": ["temperature"]
+	}`)))
+	var result *mcp.CallToolResult
+	server.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return result, nil
+	})
+
+	callTool := func() mcp.JSONRPCMessage {
+		return server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "weather"}
+		}`))
+	}
+
+	result = mcp.NewStructuredToolResult(map[string]any{"temperature": 21.5})
+	_, ok := callTool().(mcp.JSONRPCResponse)
+	assert.True(t, ok)
+
+	result = mcp.NewStructuredToolResult(map[string]any{"temperature": "warm"})
+	errorResponse, ok := callTool().(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INTERNAL_ERROR, errorResponse.Error.Code)
+	assert.Contains(t, errorResponse.Error.Message, "/temperature: expected number, got string")
+
+	// Error results are not validated
+	result = mcp.NewToolResultError("no data")
+	result.StructuredContent = map[string]any{}
+	_, ok = callTool().(mcp.JSONRPCResponse)
+	assert.True(t, ok)
+}
+
+func TestMCPServer_RegisterNotificationHandler(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	received := make(chan json.RawMessage, 2)
+	server.RegisterNotificationHandler("notifications/my_event", func(ctx context.Context, params json.RawMessage) {
+		received <- params
+	})
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"method": "notifications/my_event",
+		"params": {"name": "deploy", "count": 2}
+	}`))
+	assert.Nil(t, response)
+	require.Len(t, received, 1)
+	assert.JSONEq(t, `{"name": "deploy", "count": 2}`, string(<-received))
+
+	server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "notifications/my_event"}`))
+	require.Len(t, received, 1)
+	assert.Nil(t, <-received)
+
+	// Notifications without a handler are ignored
+	response = server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "notifications/other"}`))
+	assert.Nil(t, response)
+}
+
+func TestMCPServer_ReadResourceContentTypePreference(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithResourceCapabilities(false, false))
+	server.AddResource(mcp.NewResource("file:///notes.txt", "notes"), func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return []mcp.ResourceContents{mcp.BlobResourceContents{
+			URI:      "file:///notes.txt",
+			MIMEType: "text/plain",
+			Blob:     base64.StdEncoding.EncodeToString([]byte("remember the milk")),
+		}}, nil
+	})
+
+	read := func(accept string) mcp.ResourceContents {
+		response := server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "resources/read",
+			"params": {"uri": "file:///notes.txt", "accept": "`+accept+`"}
+		}`))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		result, ok := resp.Result.(mcp.ReadResourceResult)
+		require.True(t, ok)
+		require.Len(t, result.Contents, 1)
+		return result.Contents[0]
+	}
+
+	assert.Equal(t, "remember the milk", read("auto").(mcp.TextResourceContents).Text)
+	assert.Equal(t, "remember the milk", read("").(mcp.TextResourceContents).Text)
+	assert.IsType(t, mcp.BlobResourceContents{}, read("blob"))
+}
+
+func TestMCPServer_ToolTimeout(t *testing.T) {
+	server := NewMCPServer("test", "1.0.0")
+	server.AddTool(mcp.NewTool("fast", mcp.WithToolTimeout(time.Second)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("done"), nil
+		})
+	server.AddTool(mcp.NewTool("cooperative", mcp.WithToolTimeout(20*time.Millisecond)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			<-ctx.Done()
+			assert.ErrorIs(t, context.Cause(ctx), ErrToolTimeout)
+			return nil, ctx.Err()
+		})
+	server.AddTool(mcp.NewTool("stubborn", mcp.WithToolTimeout(20*time.Millisecond)),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			time.Sleep(50 * time.Millisecond)
+			return mcp.NewToolResultText("late"), nil
+		})
+
+	call := func(name string) mcp.CallToolResult {
+		response := server.HandleMessage(context.Background(), []byte(fmt.Sprintf(
+			`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": %q}}`, name)))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "unexpected response %#v", response)
+		return resp.Result.(mcp.CallToolResult)
+	}
+
+	result := call("fast")
+	assert.False(t, result.IsError)
+	assert.Equal(t, "done", result.Content[0].(mcp.TextContent).Text)
+
+	for _, name := range []string{"cooperative", "stubborn"} {
+		result := call(name)
+		assert.True(t, result.IsError, name)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "timeout", name)
+	}
+}
+
+func TestMCPServer_CallToolWithSchemaTaggedArguments(t *testing.T) {
+	type searchArgs struct {
+		Query string `schema:"q" json:"query"`
+		Limit int    `schema:"max" json:"limit,omitempty"`
+	}
+
+	server := NewMCPServer("test-server", "1.0.0")
+	server.AddTool(
+		mcp.NewTool("search", mcp.WithInputSchema[searchArgs]()),
+		mcp.NewTypedToolHandler(func(ctx context.Context, request mcp.CallToolRequest, args searchArgs) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(fmt.Sprintf("%s/%d", args.Query, args.Limit)), nil
+		}),
+	)
+
+	// The client follows the advertised schema
+	listed := server.HandleMessage(context.Background(), []byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`))
+	tools := listed.(mcp.JSONRPCResponse).Result.(mcp.ListToolsResult).Tools
+	require.Len(t, tools, 1)
+	assert.Contains(t, string(tools[0].RawInputSchema), `"q"`)
+	assert.Contains(t, string(tools[0].RawInputSchema), `"max"`)
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 2,
+		"method": "tools/call",
+		"params": {"name": "search", "arguments": {"q": "mcp", "max": 5}}
+	}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "unexpected response %#v", response)
+	result := resp.Result.(mcp.CallToolResult)
+	require.False(t, result.IsError)
+	assert.Equal(t, "mcp/5", result.Content[0].(mcp.TextContent).Text)
+}
@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/url"
+	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -20,6 +23,20 @@ type ClientSession interface {
 	SessionID() string
 }
 
+// SessionWithInitialization is an extension of ClientSession that tracks
+// whether the client has sent notifications/initialized, which completes the
+// initialization started by the initialize request. This differs from
+// ClientSession.Initialized, which reports whether the server registered the
+// session and may send it notifications, and is true as soon as the
+// initialize request is handled.
+type SessionWithInitialization interface {
+	ClientSession
+	// MarkClientReady records that the client sent notifications/initialized
+	MarkClientReady()
+	// ClientReady returns whether the client sent notifications/initialized
+	ClientReady() bool
+}
+
 // SessionWithLogging is an extension of ClientSession that can receive log message notifications and set log level
 type SessionWithLogging interface {
 	ClientSession
@@ -173,7 +190,7 @@ func (s *MCPServer) sendNotificationToAllClients(notification mcp.JSONRPCNotific
 		if session, ok := v.(ClientSession); ok && session.Initialized() {
 			select {
 			case session.NotificationChannel() <- notification:
-				// Successfully sent notification
+				s.protocolLogger.logMessage(context.Background(), protocolDirectionSend, notification)
 			default:
 				// Channel is blocked, if there's an error hook, use it
 				if s.hooks != nil && len(s.hooks.OnError) > 0 {
@@ -202,6 +219,7 @@ func (s *MCPServer) sendNotificationToSpecificClient(session ClientSession, noti
 	}
 	select {
 	case session.NotificationChannel() <- notification:
+		s.protocolLogger.logMessage(context.Background(), protocolDirectionSend, notification)
 		return nil
 	default:
 		// Channel is blocked, if there's an error hook, use it
@@ -250,6 +268,8 @@ func (s *MCPServer) UnregisterSession(
 	if !ok {
 		return
 	}
+	s.rootsCache.Delete(sessionID)
+	s.forgetRateLimitSession(sessionID)
 	if session, ok := sessionValue.(ClientSession); ok {
 		s.hooks.UnregisterSession(ctx, session)
 	}
@@ -272,6 +292,57 @@ func (s *MCPServer) SendNotificationToAllClients(
 	s.sendNotificationToAllClients(notification)
 }
 
+// Broadcast sends a notification to all initialized sessions concurrently.
+// Sends never wait for a slow client: a session whose notification channel is
+// full fails, is logged and is closed and unregistered, since it no longer
+// keeps up with the server. Its stdio or SSE connection is closed, and a
+// streamable HTTP session is terminated, so that the client notices and can
+// reconnect. The returned error joins the errors of all failed sessions.
+func (s *MCPServer) Broadcast(notification mcp.Notification) error {
+	message := mcp.JSONRPCNotification{
+		JSONRPC:      mcp.JSONRPC_VERSION,
+		Notification: notification,
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	s.sessions.Range(func(k, v any) bool {
+		session, ok := v.(ClientSession)
+		if !ok || !session.Initialized() {
+			return true
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.sendNotificationToSpecificClient(session, message); err != nil {
+				log.Printf("Failed to broadcast %s to session %s, removing it: %v",
+					notification.Method, session.SessionID(), err)
+				s.closeSession(session)
+
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("session %s: %w", session.SessionID(), err))
+				mu.Unlock()
+			}
+		}()
+		return true
+	})
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// closeSession closes the connection of session through its unexported close
+// method, if it has one, and unregisters it.
+func (s *MCPServer) closeSession(session ClientSession) {
+	if closer, ok := session.(interface{ close() }); ok {
+		closer.close()
+	}
+	s.UnregisterSession(context.Background(), session.SessionID())
+}
+
 // SendNotificationToClient sends a notification to the current client
 func (s *MCPServer) sendNotificationCore(
 	ctx context.Context,
@@ -284,6 +355,7 @@ func (s *MCPServer) sendNotificationCore(
 	}
 	select {
 	case session.NotificationChannel() <- notification:
+		s.protocolLogger.logMessage(ctx, protocolDirectionSend, notification)
 		return nil
 	default:
 		// Channel is blocked, if there's an error hook, use it
@@ -397,7 +469,7 @@ func (s *MCPServer) AddSessionTools(sessionID string, tools ...ServerTool) error
 	// For initialized sessions, honor tools.listChanged, which is specifically
 	// about whether notifications will be sent or not.
 	// see <https://modelcontextprotocol.io/specification/2025-03-26/server/tools#capabilities>
-	if session.Initialized() && s.capabilities.tools != nil && s.capabilities.tools.listChanged {
+	if session.Initialized() && s.capabilitiesSnapshot().toolsListChanged() {
 		// Send notification only to this session
 		if err := s.SendNotificationToSpecificClient(sessionID, "notifications/tools/list_changed", nil); err != nil {
 			// Log the error but don't fail the operation
@@ -459,7 +531,7 @@ func (s *MCPServer) DeleteSessionTools(sessionID string, names ...string) error
 	// For initialized sessions, honor tools.listChanged, which is specifically
 	// about whether notifications will be sent or not.
 	// see <https://modelcontextprotocol.io/specification/2025-03-26/server/tools#capabilities>
-	if session.Initialized() && s.capabilities.tools != nil && s.capabilities.tools.listChanged {
+	if session.Initialized() && s.capabilitiesSnapshot().toolsListChanged() {
 		// Send notification only to this session
 		if err := s.SendNotificationToSpecificClient(sessionID, "notifications/tools/list_changed", nil); err != nil {
 			// Log the error but don't fail the operation
@@ -539,7 +611,7 @@ func (s *MCPServer) AddSessionResources(sessionID string, resources ...ServerRes
 	// For initialized sessions, honor resources.listChanged, which is specifically
 	// about whether notifications will be sent or not.
 	// see <https://modelcontextprotocol.io/specification/2025-03-26/server/resources#capabilities>
-	if session.Initialized() && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+	if session.Initialized() && s.capabilitiesSnapshot().resourcesListChanged() {
 		// Send notification only to this session
 		if err := s.SendNotificationToSpecificClient(sessionID, "notifications/resources/list_changed", nil); err != nil {
 			// Log the error but don't fail the operation
@@ -611,7 +683,7 @@ func (s *MCPServer) DeleteSessionResources(sessionID string, uris ...string) err
 	// about whether notifications will be sent or not.
 	// see <https://modelcontextprotocol.io/specification/2025-03-26/server/resources#capabilities>
 	// Only send notification if something was actually deleted
-	if actuallyDeleted && session.Initialized() && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+	if actuallyDeleted && session.Initialized() && s.capabilitiesSnapshot().resourcesListChanged() {
 		// Send notification only to this session
 		if err := s.SendNotificationToSpecificClient(sessionID, "notifications/resources/list_changed", nil); err != nil {
 			// Log the error but don't fail the operation
@@ -689,7 +761,7 @@ func (s *MCPServer) AddSessionResourceTemplates(sessionID string, templates ...S
 	session.SetSessionResourceTemplates(newTemplates)
 
 	// Send notification if the session is initialized and listChanged is enabled
-	if session.Initialized() && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+	if session.Initialized() && s.capabilitiesSnapshot().resourcesListChanged() {
 		if err := s.SendNotificationToSpecificClient(sessionID, "notifications/resources/list_changed", nil); err != nil {
 			// Log the error but don't fail the operation
 			if s.hooks != nil && len(s.hooks.OnError) > 0 {
@@ -746,7 +818,7 @@ func (s *MCPServer) DeleteSessionResourceTemplates(sessionID string, uriTemplate
 		session.SetSessionResourceTemplates(newTemplates)
 
 		// Send notification if the session is initialized and listChanged is enabled
-		if session.Initialized() && s.capabilities.resources != nil && s.capabilities.resources.listChanged {
+		if session.Initialized() && s.capabilitiesSnapshot().resourcesListChanged() {
 			if err := s.SendNotificationToSpecificClient(sessionID, "notifications/resources/list_changed", nil); err != nil {
 				// Log the error but don't fail the operation
 				if s.hooks != nil && len(s.hooks.OnError) > 0 {
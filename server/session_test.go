@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"maps"
 	"sync"
 	"sync/atomic"
@@ -1756,3 +1757,69 @@ func TestMCPServer_LoggingNotificationFormat(t *testing.T) {
 		})
 	}
 }
+
+// closableSessionTestClient is a sessionTestClient recording whether its
+// connection was closed.
+type closableSessionTestClient struct {
+	sessionTestClient
+	closed atomic.Bool
+}
+
+func (c *closableSessionTestClient) close() {
+	c.closed.Store(true)
+}
+
+func TestMCPServer_Broadcast(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+
+	healthy := make([]chan mcp.JSONRPCNotification, 3)
+	for i := range healthy {
+		healthy[i] = make(chan mcp.JSONRPCNotification, 1)
+		require.NoError(t, server.RegisterSession(context.Background(), &sessionTestClient{
+			sessionID:           fmt.Sprintf("healthy-%d", i),
+			notificationChannel: healthy[i],
+			initialized:         true,
+		}))
+	}
+	// The channel of this session is full
+	blocked := &closableSessionTestClient{sessionTestClient: sessionTestClient{
+		sessionID:           "blocked",
+		notificationChannel: make(chan mcp.JSONRPCNotification),
+		initialized:         true,
+	}}
+	require.NoError(t, server.RegisterSession(context.Background(), blocked))
+	// Sessions that are not initialized are skipped
+	uninitialized := make(chan mcp.JSONRPCNotification, 1)
+	require.NoError(t, server.RegisterSession(context.Background(), &sessionTestClient{
+		sessionID:           "uninitialized",
+		notificationChannel: uninitialized,
+	}))
+
+	err := server.Broadcast(mcp.Notification{
+		Method: mcp.MethodNotificationResourceUpdated,
+		Params: mcp.NotificationParams{AdditionalFields: map[string]any{"uri": "test://resource"}},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotificationChannelBlocked)
+	assert.Contains(t, err.Error(), "blocked")
+
+	for i, ch := range healthy {
+		select {
+		case notification := <-ch:
+			assert.Equal(t, mcp.MethodNotificationResourceUpdated, notification.Method)
+			assert.Equal(t, "test://resource", notification.Params.AdditionalFields["uri"])
+		default:
+			t.Errorf("session %d did not receive the notification", i)
+		}
+	}
+	assert.Empty(t, uninitialized)
+
+	_, ok := server.sessions.Load("blocked")
+	assert.False(t, ok, "blocked session should be unregistered")
+	assert.True(t, blocked.closed.Load(), "blocked session should be closed")
+	_, ok = server.sessions.Load("healthy-0")
+	assert.True(t, ok)
+
+	// With the failed session gone, broadcasting succeeds
+	assert.NoError(t, server.Broadcast(mcp.Notification{Method: mcp.MethodNotificationToolsListChanged}))
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// shutdownCancelReason is sent with the cancellation notifications for
+// requests that did not finish before Shutdown gave up waiting.
+const shutdownCancelReason = "server shutting down"
+
+// DefaultShutdownTimeout is how long GracefulShutdown waits for in-flight
+// requests when neither WithShutdownTimeout nor a context deadline is given.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// WithShutdownTimeout sets how long GracefulShutdown waits for in-flight
+// requests to finish when the context passed to it has no deadline.
+func WithShutdownTimeout(d time.Duration) ServerOption {
+	return func(s *MCPServer) {
+		s.shutdownTimeout = d
+	}
+}
+
+// ShutdownTimeout returns the configured graceful shutdown timeout.
+func (s *MCPServer) ShutdownTimeout() time.Duration {
+	if s.shutdownTimeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return s.shutdownTimeout
+}
+
+// GracefulShutdown waits for all requests currently being handled by
+// HandleMessage to complete. If ctx has no deadline, the timeout configured
+// with WithShutdownTimeout (or DefaultShutdownTimeout) is applied. It returns
+// an error wrapping ctx.Err() if the requests do not finish in time.
+func (s *MCPServer) GracefulShutdown(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.ShutdownTimeout())
+		defer cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for in-flight requests: %w", ctx.Err())
+	}
+}
+
+// Shutdown stops the server gracefully. New requests are rejected with
+// ErrServerShutdown, and requests already being handled are given until ctx is
+// done (or the timeout configured with WithShutdownTimeout, if ctx has no
+// deadline) to finish. Requests still running after that have their contexts
+// cancelled and a notifications/cancelled message is sent to their sessions.
+// Finally the SSE and streamable HTTP servers started for this server and its
+// stdio listeners are shut down.
+//
+// The returned error wraps ctx.Err() if requests had to be cancelled, joined
+// with any errors from shutting down the transports.
+func (s *MCPServer) Shutdown(ctx context.Context) error {
+	s.shutdownMu.Lock()
+	s.shuttingDown = true
+	hooks := make([]func(context.Context) error, 0, len(s.shutdownHooks))
+	for _, hook := range s.shutdownHooks {
+		hooks = append(hooks, hook)
+	}
+	s.shutdownMu.Unlock()
+
+	var errs []error
+	if err := s.GracefulShutdown(ctx); err != nil {
+		errs = append(errs, err)
+		s.cancelActiveRequests(shutdownCancelReason)
+	}
+
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// beginMessage registers a message with the in-flight WaitGroup. It returns
+// false once Shutdown has been called, in which case the message must be
+// rejected. Holding the read lock while adding ensures the WaitGroup is never
+// added to after Shutdown has started waiting on it.
+func (s *MCPServer) beginMessage() bool {
+	s.shutdownMu.RLock()
+	defer s.shutdownMu.RUnlock()
+	if s.shuttingDown {
+		return false
+	}
+	s.inFlight.Add(1)
+	return true
+}
+
+// rejectMessage returns the response to a message received after Shutdown was
+// called. Notifications and responses are dropped.
+func rejectMessage(message json.RawMessage) mcp.JSONRPCMessage {
+	var baseMessage struct {
+		ID     any `json:"id,omitempty"`
+		Result any `json:"result,omitempty"`
+	}
+	if err := json.Unmarshal(message, &baseMessage); err != nil || baseMessage.ID == nil || baseMessage.Result != nil {
+		return nil
+	}
+	return createErrorResponse(baseMessage.ID, mcp.INTERNAL_ERROR, ErrServerShutdown.Error())
+}
+
+// activeRequest is a request being handled by HandleMessage.
+type activeRequest struct {
+	id        mcp.RequestId
+	sessionID string
+	cancel    context.CancelFunc
+}
+
+// trackRequest records a request so Shutdown can cancel it. The returned
+// context is cancelled when the request is cancelled; the returned function
+// must be called once the request has been handled.
+func (s *MCPServer) trackRequest(ctx context.Context, id any) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	request := &activeRequest{
+		id:     mcp.NewRequestId(id),
+		cancel: cancel,
+	}
+	if session := ClientSessionFromContext(ctx); session != nil {
+		request.sessionID = session.SessionID()
+	}
+
+	s.activeRequests.Store(request, struct{}{})
+	return ctx, func() {
+		s.activeRequests.Delete(request)
+		cancel()
+	}
+}
+
+// cancelActiveRequests cancels every tracked request and notifies the client
+// sessions they came from.
+func (s *MCPServer) cancelActiveRequests(reason string) {
+	s.activeRequests.Range(func(key, _ any) bool {
+		request := key.(*activeRequest)
+		request.cancel()
+		if request.sessionID != "" {
+			_ = s.SendNotificationToSpecificClient(request.sessionID, mcp.MethodNotificationCancelled, map[string]any{
+				"requestId": request.id,
+				"reason":    reason,
+			})
+		}
+		return true
+	})
+}
+
+// onShutdown registers fn to be called by Shutdown, typically to close a
+// transport serving this server. The returned function removes it again.
+func (s *MCPServer) onShutdown(fn func(context.Context) error) func() {
+	s.shutdownMu.Lock()
+	defer s.shutdownMu.Unlock()
+
+	if s.shutdownHooks == nil {
+		s.shutdownHooks = make(map[uint64]func(context.Context) error)
+	}
+	id := s.nextShutdownHookID
+	s.nextShutdownHookID++
+	s.shutdownHooks[id] = fn
+
+	return func() {
+		s.shutdownMu.Lock()
+		defer s.shutdownMu.Unlock()
+		delete(s.shutdownHooks, id)
+	}
+}
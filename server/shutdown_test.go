@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSleepingToolServer(sleep time.Duration, started chan<- struct{}, opts ...ServerOption) *MCPServer {
+	server := NewMCPServer("test-server", "1.0.0", opts...)
+	server.AddTool(mcp.NewTool("sleep"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		time.Sleep(sleep)
+		return mcp.NewToolResultText("done"), nil
+	})
+	return server
+}
+
+func callToolAsync(server *MCPServer, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		server.HandleMessage(context.Background(), []byte(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "sleep"}
+		}`))
+	}()
+}
+
+func TestMCPServer_ShutdownTimeoutDefault(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	assert.Equal(t, DefaultShutdownTimeout, server.ShutdownTimeout())
+
+	server = NewMCPServer("test-server", "1.0.0", WithShutdownTimeout(time.Second))
+	assert.Equal(t, time.Second, server.ShutdownTimeout())
+}
+
+func TestMCPServer_GracefulShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	server := newSleepingToolServer(200*time.Millisecond, started, WithShutdownTimeout(100*time.Millisecond))
+
+	var wg sync.WaitGroup
+	callToolAsync(server, &wg)
+	<-started
+
+	err := server.GracefulShutdown(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	wg.Wait()
+}
+
+func TestMCPServer_GracefulShutdownWaitsForRequests(t *testing.T) {
+	started := make(chan struct{})
+	server := newSleepingToolServer(50*time.Millisecond, started, WithShutdownTimeout(time.Second))
+
+	var wg sync.WaitGroup
+	callToolAsync(server, &wg)
+	<-started
+
+	require.NoError(t, server.GracefulShutdown(context.Background()))
+	wg.Wait()
+}
+
+func TestMCPServer_GracefulShutdownContextDeadline(t *testing.T) {
+	started := make(chan struct{})
+	// The configured timeout is long; the context deadline must take precedence.
+	server := newSleepingToolServer(200*time.Millisecond, started, WithShutdownTimeout(time.Minute))
+
+	var wg sync.WaitGroup
+	callToolAsync(server, &wg)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := server.GracefulShutdown(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	wg.Wait()
+}
+
+func TestMCPServer_ShutdownRejectsNewRequests(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	response := server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "ping"
+	}`))
+	errorResponse, ok := response.(mcp.JSONRPCError)
+	require.True(t, ok)
+	assert.Equal(t, mcp.INTERNAL_ERROR, errorResponse.Error.Code)
+	assert.Equal(t, ErrServerShutdown.Error(), errorResponse.Error.Message)
+
+	response = server.HandleMessage(context.Background(), []byte(`{
+		"jsonrpc": "2.0",
+		"method": "notifications/initialized"
+	}`))
+	assert.Nil(t, response)
+}
+
+func TestMCPServer_ShutdownCancelsTimedOutRequests(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+
+	started := make(chan struct{})
+	handlerCancelled := make(chan struct{})
+	server.AddTool(mcp.NewTool("block"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		close(handlerCancelled)
+		return nil, ctx.Err()
+	})
+
+	session := fakeSession{
+		sessionID:           "shutdown-session",
+		notificationChannel: make(chan mcp.JSONRPCNotification, 10),
+		initialized:         true,
+	}
+	require.NoError(t, server.RegisterSession(context.Background(), session))
+	ctx := server.WithContext(context.Background(), session)
+
+	go server.HandleMessage(ctx, []byte(`{
+		"jsonrpc": "2.0",
+		"id": 7,
+		"method": "tools/call",
+		"params": {"name": "block"}
+	}`))
+	<-started
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case <-handlerCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("handler context was not cancelled")
+	}
+
+	select {
+	case notification := <-session.notificationChannel:
+		assert.Equal(t, mcp.MethodNotificationCancelled, notification.Method)
+		assert.Equal(t, mcp.NewRequestId(float64(7)), notification.Params.AdditionalFields["requestId"])
+		assert.Equal(t, "server shutting down", notification.Params.AdditionalFields["reason"])
+	case <-time.After(time.Second):
+		t.Fatal("no cancellation notification sent")
+	}
+}
+
+func TestMCPServer_ShutdownStopsStdioServer(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+	stdioServer := NewStdioServer(server)
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- stdioServer.Listen(context.Background(), stdinReader, io.Discard)
+	}()
+
+	// Wait for the listener to register itself with the server
+	require.Eventually(t, func() bool {
+		server.shutdownMu.RLock()
+		defer server.shutdownMu.RUnlock()
+		return len(server.shutdownHooks) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("stdio server did not stop")
+	}
+}
+
+func shutdownHookCount(server *MCPServer) int {
+	server.shutdownMu.RLock()
+	defer server.shutdownMu.RUnlock()
+	return len(server.shutdownHooks)
+}
+
+func TestMCPServer_ShutdownHooksOfHTTPTransports(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0")
+
+	// Servers used only as HTTP handlers do not register themselves
+	for range 3 {
+		_ = NewSSEServer(server)
+		_ = NewStreamableHTTPServer(server)
+	}
+	assert.Equal(t, 0, shutdownHookCount(server))
+
+	sseServer := NewSSEServer(server)
+	httpServer := NewStreamableHTTPServer(server)
+	sseDone := make(chan error, 1)
+	httpDone := make(chan error, 1)
+	go func() { sseDone <- sseServer.Start("127.0.0.1:0") }()
+	go func() { httpDone <- httpServer.Start("127.0.0.1:0") }()
+
+	require.Eventually(t, func() bool {
+		return shutdownHookCount(server) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// Shutting a transport down removes its hook
+	require.Eventually(t, func() bool {
+		return sseServer.Shutdown(context.Background()) == nil && shutdownHookCount(server) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The MCP server shuts down the transports still running
+	require.NoError(t, server.Shutdown(context.Background()))
+	assert.Equal(t, 0, shutdownHookCount(server))
+	for _, done := range []chan error{sseDone, httpDone} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("HTTP transport did not stop")
+		}
+	}
+}
@@ -22,17 +22,24 @@ import (
 // sseSession represents an active SSE connection.
 type sseSession struct {
 	done                chan struct{}
+	closeOnce           sync.Once
+	events              *sseEventLog // nil unless event IDs are enabled
+	attachMu            sync.Mutex
+	detached            bool // the SSE connection ended, waiting for the client to reconnect
+	detachTimer         *time.Timer
 	eventQueue          chan string // Channel for queuing events
 	sessionID           string
 	requestID           atomic.Int64
 	notificationChannel chan mcp.JSONRPCNotification
 	initialized         atomic.Bool
+	initializedNotice   atomic.Bool
 	loggingLevel        atomic.Value
-	tools               sync.Map     // stores session-specific tools
-	resources           sync.Map     // stores session-specific resources
-	resourceTemplates   sync.Map     // stores session-specific resource templates
-	clientInfo          atomic.Value // stores session-specific client info
-	clientCapabilities  atomic.Value // stores session-specific client capabilities
+	tools               sync.Map           // stores session-specific tools
+	resources           sync.Map           // stores session-specific resources
+	resourceTemplates   sync.Map           // stores session-specific resource templates
+	clientInfo          atomic.Value       // stores session-specific client info
+	clientCapabilities  atomic.Value       // stores session-specific client capabilities
+	pong                chan mcp.RequestId // responses to keep-alive pings
 }
 
 // SSEContextFunc is a function that takes an existing context and the current
@@ -65,6 +72,19 @@ func (s *sseSession) Initialized() bool {
 	return s.initialized.Load()
 }
 
+// close ends the session, making its SSE connection handler return.
+func (s *sseSession) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+func (s *sseSession) MarkClientReady() {
+	s.initializedNotice.Store(true)
+}
+
+func (s *sseSession) ClientReady() bool {
+	return s.initializedNotice.Load()
+}
+
 func (s *sseSession) SetLogLevel(level mcp.LoggingLevel) {
 	s.loggingLevel.Store(level)
 }
@@ -173,6 +193,7 @@ var (
 	_ SessionWithResourceTemplates = (*sseSession)(nil)
 	_ SessionWithLogging           = (*sseSession)(nil)
 	_ SessionWithClientInfo        = (*sseSession)(nil)
+	_ SessionWithInitialization    = (*sseSession)(nil)
 )
 
 // SSEServer implements a Server-Sent Events (SSE) based MCP server.
@@ -192,6 +213,16 @@ type SSEServer struct {
 
 	keepAlive         bool
 	keepAliveInterval time.Duration
+	keepAliveTimeout  time.Duration
+
+	eventIDs              bool
+	eventReplayBufferSize int
+
+	selfSignedDevCertificate bool
+
+	// unregisterShutdown removes the hook registered by Start which shuts
+	// this server down with the MCP server.
+	unregisterShutdown func()
 
 	mu sync.RWMutex
 }
@@ -309,6 +340,30 @@ func WithKeepAlive(keepAlive bool) SSEOption {
 	}
 }
 
+// WithSelfSignedDevCertificate makes Start serve HTTPS with a generated
+// self-signed certificate when it listens on localhost or a loopback IP
+// address and no TLS is configured on the MCP server, for development.
+// Clients accept the certificate with transport.WithInsecureSkipVerify. It
+// is ignored if the base URL set with WithBaseURL is an http:// URL, as the
+// message endpoint announced to clients would not match.
+func WithSelfSignedDevCertificate() SSEOption {
+	return func(s *SSEServer) {
+		s.selfSignedDevCertificate = true
+	}
+}
+
+// WithKeepAliveTimeout enables keep-alive pings and closes the SSE connection
+// and its session when the client does not respond to a ping within timeout.
+// This detects connections silently dropped by proxies. The timeout should be
+// shorter than the keep-alive interval. A zero timeout never closes the
+// connection, which is the default.
+func WithKeepAliveTimeout(timeout time.Duration) SSEOption {
+	return func(s *SSEServer) {
+		s.keepAlive = true
+		s.keepAliveTimeout = timeout
+	}
+}
+
 // WithSSEContextFunc sets a function that will be called to customise the context
 // to the server using the incoming request.
 func WithSSEContextFunc(fn SSEContextFunc) SSEOption {
@@ -347,6 +402,11 @@ func NewTestServer(server *MCPServer, opts ...SSEOption) *httptest.Server {
 
 // Start begins serving SSE connections on the specified address.
 // It sets up HTTP handlers for SSE and message endpoints.
+//
+// It serves HTTPS if TLS is configured on the MCP server with WithTLS or
+// WithTLSFromFiles, or with a self-signed certificate on localhost if the
+// server was created with WithSelfSignedDevCertificate, and plain HTTP
+// otherwise.
 func (s *SSEServer) Start(addr string) error {
 	s.mu.Lock()
 	if s.srv == nil {
@@ -362,22 +422,33 @@ func (s *SSEServer) Start(addr string) error {
 		}
 	}
 	srv := s.srv
+	selfSigned := s.selfSignedDevCertificate && !strings.HasPrefix(s.baseURL, "http://")
+	// Close the SSE server when the MCP server shuts down
+	if s.server != nil && s.unregisterShutdown == nil {
+		s.unregisterShutdown = s.server.onShutdown(s.Shutdown)
+	}
 	s.mu.Unlock()
 
-	return srv.ListenAndServe()
+	return listenAndServe(s.server, srv, selfSigned)
 }
 
 // Shutdown gracefully stops the SSE server, closing all active sessions
 // and shutting down the HTTP server.
 func (s *SSEServer) Shutdown(ctx context.Context) error {
-	s.mu.RLock()
+	s.mu.Lock()
 	srv := s.srv
-	s.mu.RUnlock()
+	unregisterShutdown := s.unregisterShutdown
+	s.unregisterShutdown = nil
+	s.mu.Unlock()
+
+	if unregisterShutdown != nil {
+		unregisterShutdown()
+	}
 
 	if srv != nil {
 		s.sessions.Range(func(key, value any) bool {
 			if session, ok := value.(*sseSession); ok {
-				close(session.done)
+				session.close()
 			}
 			s.sessions.Delete(key)
 			return true
@@ -407,18 +478,27 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.eventIDs && r.Header.Get(headerLastEventID) != "" {
+		s.resumeSSE(w, r, flusher)
+		return
+	}
+
 	sessionID := uuid.New().String()
 	session := &sseSession{
 		done:                make(chan struct{}),
 		eventQueue:          make(chan string, 100), // Buffer for events
 		sessionID:           sessionID,
 		notificationChannel: make(chan mcp.JSONRPCNotification, 100),
+		pong:                make(chan mcp.RequestId, 1),
+	}
+	if s.eventIDs {
+		session.events = newSSEEventLog(s.eventReplayBufferSize)
 	}
 
 	s.sessions.Store(sessionID, session)
-	defer s.sessions.Delete(sessionID)
 
 	if err := s.server.RegisterSession(r.Context(), session); err != nil {
+		s.sessions.Delete(sessionID)
 		http.Error(
 			w,
 			fmt.Sprintf("Session registration failed: %v", err),
@@ -426,7 +506,13 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 		)
 		return
 	}
-	defer s.server.UnregisterSession(r.Context(), sessionID)
+
+	// With event IDs, the session outlives the connection so that the client
+	// can reconnect to it
+	sessionCtx := r.Context()
+	if s.eventIDs {
+		sessionCtx = context.WithoutCancel(sessionCtx)
+	}
 
 	// Start notification handler for this session
 	go func() {
@@ -444,7 +530,7 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 				}
 			case <-session.done:
 				return
-			case <-r.Context().Done():
+			case <-sessionCtx.Done():
 				return
 			}
 		}
@@ -473,9 +559,14 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 					case <-session.done:
 						return
 					}
+					if s.keepAliveTimeout > 0 && !session.awaitPong(message.ID, s.keepAliveTimeout) {
+						// The client stopped responding to pings, drop the connection
+						session.close()
+						return
+					}
 				case <-session.done:
 					return
-				case <-r.Context().Done():
+				case <-sessionCtx.Done():
 					return
 				}
 			}
@@ -490,22 +581,88 @@ func (s *SSEServer) handleSSE(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "event: endpoint\ndata: %s\r\n\r\n", endpoint)
 	flusher.Flush()
 
+	s.streamEvents(w, r, flusher, session)
+}
+
+// streamEvents writes the events of the session to the SSE connection until
+// either of them ends. When the connection ends first and event IDs are
+// enabled, the session is kept for the client to reconnect to; otherwise the
+// session is closed and unregistered.
+func (s *SSEServer) streamEvents(w http.ResponseWriter, r *http.Request, flusher http.Flusher, session *sseSession) {
 	// Main event loop - this runs in the HTTP handler goroutine
 	for {
 		select {
 		case event := <-session.eventQueue:
+			if session.events != nil {
+				event = session.events.record(event)
+			}
 			// Write the event to the response
 			fmt.Fprint(w, event)
 			flusher.Flush()
 		case <-r.Context().Done():
-			close(session.done)
+			if s.eventIDs {
+				s.detachSession(session)
+				return
+			}
+			session.close()
+			s.endSession(r.Context(), session)
 			return
 		case <-session.done:
+			s.endSession(r.Context(), session)
 			return
 		}
 	}
 }
 
+// endSession forgets a closed session.
+func (s *SSEServer) endSession(ctx context.Context, session *sseSession) {
+	s.sessions.Delete(session.sessionID)
+	s.server.UnregisterSession(ctx, session.sessionID)
+}
+
+// awaitPong waits up to timeout for the client to respond to the ping with
+// the given ID. It returns false if no response arrives in time or the
+// session is closed.
+func (s *sseSession) awaitPong(id mcp.RequestId, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case pongID := <-s.pong:
+			if pongID.String() == id.String() {
+				return true
+			}
+			// A response to an earlier ping, keep waiting
+		case <-timer.C:
+			return false
+		case <-s.done:
+			return false
+		}
+	}
+}
+
+// handlePong forwards a response to a keep-alive ping to the session. Other
+// messages are ignored.
+func (s *sseSession) handlePong(message json.RawMessage) {
+	var response struct {
+		ID     *mcp.RequestId  `json:"id"`
+		Method string          `json:"method"`
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(message, &response); err != nil || response.ID == nil || response.Method != "" {
+		return
+	}
+	if response.Result == nil && response.Error == nil {
+		return
+	}
+	select {
+	case s.pong <- *response.ID:
+	default:
+		// Nobody is waiting for a response
+	}
+}
+
 // GetMessageEndpointForClient returns the appropriate message endpoint URL with session ID
 // for the given request. This is the canonical way to compute the message endpoint for a client.
 // It handles both dynamic and static path modes, and honors the WithUseFullURLForMessageEndpoint flag.
@@ -550,12 +707,22 @@ func (s *SSEServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse message as raw JSON
+	s.server.limitRequestBody(w, r)
 	var rawMessage json.RawMessage
 	if err := json.NewDecoder(r.Body).Decode(&rawMessage); err != nil {
+		if isRequestTooLarge(err) {
+			w.Header().Set("Connection", "close")
+			s.writeJSONRPCError(w, nil, mcp.PARSE_ERROR, s.server.requestTooLargeMessage())
+			return
+		}
 		s.writeJSONRPCError(w, nil, mcp.PARSE_ERROR, "Parse error")
 		return
 	}
 
+	if s.keepAliveTimeout > 0 {
+		session.handlePong(rawMessage)
+	}
+
 	// Create a context that preserves all values from parent ctx but won't be canceled when the parent is canceled.
 	// this is required because the http ctx will be canceled when the client disconnects
 	detachedCtx := context.WithoutCancel(ctx)
@@ -720,7 +887,7 @@ func (s *SSEServer) CompleteMessagePath() string {
 //
 // For non-dynamic cases, use ServeHTTP method instead.
 func (s *SSEServer) SSEHandler() http.Handler {
-	return http.HandlerFunc(s.handleSSE)
+	return compressHandler(s.server, s.handleSSE)
 }
 
 // MessageHandler returns an http.Handler for the message endpoint.
@@ -749,7 +916,7 @@ func (s *SSEServer) SSEHandler() http.Handler {
 //
 // For non-dynamic cases, use ServeHTTP method instead.
 func (s *SSEServer) MessageHandler() http.Handler {
-	return http.HandlerFunc(s.handleMessage)
+	return compressHandler(s.server, s.handleMessage)
 }
 
 // ServeHTTP implements the http.Handler interface.
@@ -762,6 +929,9 @@ func (s *SSEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		)
 		return
 	}
+	w, done := compressResponse(s.server, w, r)
+	defer done()
+
 	path := r.URL.Path
 	// Use exact path matching rather than Contains
 	ssePath := s.CompleteSsePath()
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// headerLastEventID is the header with which SSE clients reconnecting
+	// tell the ID of the last event they received.
+	headerLastEventID = "Last-Event-ID"
+
+	// defaultEventReplayBufferSize is the number of events kept per session
+	// for replay, unless set with WithEventReplayBuffer.
+	defaultEventReplayBufferSize = 100
+
+	// sseReconnectWindow is how long a session whose SSE connection ended is
+	// kept for its client to reconnect, when event IDs are enabled.
+	sseReconnectWindow = 30 * time.Second
+)
+
+// WithEventIDCounter gives every event sent on an SSE connection an id field,
+// increasing from 1 within each session, so that clients can recover from a
+// dropped connection. When its connection ends, a session is kept for a while
+// instead of being closed; the client reconnects to it by opening the SSE
+// endpoint again with the sessionId query parameter of the message endpoint
+// and the Last-Event-ID header set to the ID of the last event it received.
+// The events sent after that one are then replayed, if they are still
+// buffered (see WithEventReplayBuffer).
+func WithEventIDCounter() SSEOption {
+	return func(s *SSEServer) {
+		s.eventIDs = true
+		if s.eventReplayBufferSize <= 0 {
+			s.eventReplayBufferSize = defaultEventReplayBufferSize
+		}
+	}
+}
+
+// WithEventReplayBuffer sets how many of the last events of each session are
+// kept to be replayed to reconnecting clients, and enables event IDs as
+// WithEventIDCounter does.
+func WithEventReplayBuffer(size int) SSEOption {
+	return func(s *SSEServer) {
+		WithEventIDCounter()(s)
+		if size > 0 {
+			s.eventReplayBufferSize = size
+		}
+	}
+}
+
+// sseEventLog numbers the events of a session and keeps the last ones.
+type sseEventLog struct {
+	mu     sync.Mutex
+	size   int
+	lastID int64
+	events []sseLoggedEvent
+}
+
+type sseLoggedEvent struct {
+	id    int64
+	event string
+}
+
+func newSSEEventLog(size int) *sseEventLog {
+	return &sseEventLog{size: size, events: make([]sseLoggedEvent, 0, size)}
+}
+
+// record gives the event the next ID and keeps it, returning the event with
+// its id field.
+func (l *sseEventLog) record(event string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastID++
+	event = fmt.Sprintf("id: %d\n%s", l.lastID, event)
+	if len(l.events) == l.size {
+		l.events = append(l.events[:0], l.events[1:]...)
+	}
+	l.events = append(l.events, sseLoggedEvent{id: l.lastID, event: event})
+	return event
+}
+
+// since returns the kept events with an ID greater than id.
+func (l *sseEventLog) since(id int64) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var events []string
+	for _, e := range l.events {
+		if e.id > id {
+			events = append(events, e.event)
+		}
+	}
+	return events
+}
+
+// detachSession keeps the session, whose SSE connection ended, for its client
+// to reconnect to within sseReconnectWindow, after which it is closed.
+func (s *SSEServer) detachSession(session *sseSession) {
+	session.attachMu.Lock()
+	defer session.attachMu.Unlock()
+	session.detached = true
+	session.detachTimer = time.AfterFunc(sseReconnectWindow, func() {
+		session.attachMu.Lock()
+		detached := session.detached
+		session.attachMu.Unlock()
+		if detached {
+			session.close()
+			s.endSession(context.Background(), session)
+		}
+	})
+}
+
+// attach reports whether the session was waiting for its client to reconnect,
+// in which case it no longer is.
+func (session *sseSession) attach() bool {
+	session.attachMu.Lock()
+	defer session.attachMu.Unlock()
+	if !session.detached {
+		return false
+	}
+	select {
+	case <-session.done:
+		return false
+	default:
+	}
+	session.detached = false
+	session.detachTimer.Stop()
+	return true
+}
+
+// resumeSSE reconnects a client to its session, replaying the events sent
+// after the one with the ID in the Last-Event-ID header.
+func (s *SSEServer) resumeSSE(w http.ResponseWriter, r *http.Request, flusher http.Flusher) {
+	lastEventID, err := strconv.ParseInt(r.Header.Get(headerLastEventID), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+		return
+	}
+	sessionI, ok := s.sessions.Load(r.URL.Query().Get("sessionId"))
+	if !ok {
+		http.Error(w, "Invalid session ID", http.StatusNotFound)
+		return
+	}
+	session := sessionI.(*sseSession)
+	if !session.attach() {
+		http.Error(w, "Session is already connected", http.StatusConflict)
+		return
+	}
+
+	for _, event := range session.events.since(lastEventID) {
+		fmt.Fprint(w, event)
+	}
+	flusher.Flush()
+
+	s.streamEvents(w, r, flusher, session)
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readSSEFrame reads the fields of the next event on an SSE stream.
+func readSSEFrame(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(fields) > 0 {
+				return fields
+			}
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields[name] = strings.TrimSpace(value)
+	}
+}
+
+func TestSSEServer_WithEventIDCounter(t *testing.T) {
+	sseServer := NewSSEServer(NewMCPServer("test-server", "1.0.0"), WithEventReplayBuffer(10))
+	testServer := httptest.NewServer(sseServer)
+	defer testServer.Close()
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+"/sse", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	reader := bufio.NewReader(resp.Body)
+
+	endpoint := readSSEFrame(t, reader)
+	require.Equal(t, "endpoint", endpoint["event"])
+	assert.NotContains(t, endpoint, "id")
+	endpointURL, err := url.Parse(endpoint["data"])
+	require.NoError(t, err)
+	sessionID := endpointURL.Query().Get("sessionId")
+	require.NotEmpty(t, sessionID)
+
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, sseServer.SendEventToSession(sessionID, map[string]int{"n": i}))
+	}
+
+	// Receive the first 5 events, then drop the connection
+	for i := 1; i <= 5; i++ {
+		event := readSSEFrame(t, reader)
+		assert.Equal(t, fmt.Sprint(i), event["id"])
+		assert.Equal(t, fmt.Sprintf(`{"n":%d}`, i), event["data"])
+	}
+	disconnect()
+	resp.Body.Close()
+
+	// Reconnect once the server noticed the connection ended
+	var resumed *http.Response
+	require.Eventually(t, func() bool {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+"/sse?sessionId="+sessionID, nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "5")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return false
+		}
+		resumed = resp
+		return true
+	}, time.Second, 10*time.Millisecond)
+	defer resumed.Body.Close()
+
+	reader = bufio.NewReader(resumed.Body)
+	for i := 6; i <= 10; i++ {
+		event := readSSEFrame(t, reader)
+		assert.Equal(t, fmt.Sprint(i), event["id"])
+		assert.Equal(t, fmt.Sprintf(`{"n":%d}`, i), event["data"])
+	}
+
+	// New events keep numbering on
+	require.NoError(t, sseServer.SendEventToSession(sessionID, map[string]int{"n": 11}))
+	assert.Equal(t, "11", readSSEFrame(t, reader)["id"])
+}
+
+func TestSSEServer_ResumeUnknownSession(t *testing.T) {
+	testServer := NewTestServer(NewMCPServer("test-server", "1.0.0"), WithEventIDCounter())
+	defer testServer.Close()
+
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/sse?sessionId=unknown", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestSSEEventLog_KeepsLastEvents(t *testing.T) {
+	log := newSSEEventLog(3)
+	for i := range 5 {
+		log.record(fmt.Sprintf("data: %d\n\n", i))
+	}
+	assert.Equal(t, []string{"id: 4\ndata: 3\n\n", "id: 5\ndata: 4\n\n"}, log.since(3))
+	assert.Len(t, log.since(0), 3)
+	assert.Empty(t, log.since(5))
+}
@@ -1606,6 +1606,97 @@ func TestSSEServer(t *testing.T) {
 			t.Error("Headers check hook was not called within timeout")
 		}
 	})
+
+	t.Run("Connection is closed when pings are not answered", func(t *testing.T) {
+		mcpServer := NewMCPServer("test", "1.0.0")
+		testServer := NewTestServer(mcpServer,
+			WithKeepAliveInterval(50*time.Millisecond),
+			WithKeepAliveTimeout(50*time.Millisecond),
+		)
+		defer testServer.Close()
+
+		sseResp, err := http.Get(fmt.Sprintf("%s/sse", testServer.URL))
+		if err != nil {
+			t.Fatalf("Failed to connect to SSE endpoint: %v", err)
+		}
+		defer sseResp.Body.Close()
+
+		// Never answer the pings; the server must end the stream
+		done := make(chan error, 1)
+		go func() {
+			_, err := io.Copy(io.Discard, sseResp.Body)
+			done <- err
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Connection was not closed after the keep-alive timeout")
+		}
+
+		sessions := 0
+		mcpServer.sessions.Range(func(key, value any) bool {
+			sessions++
+			return true
+		})
+		if sessions != 0 {
+			t.Errorf("Expected the session to be unregistered, got %d sessions", sessions)
+		}
+	})
+
+	t.Run("Connection stays open while pings are answered", func(t *testing.T) {
+		mcpServer := NewMCPServer("test", "1.0.0")
+		testServer := NewTestServer(mcpServer,
+			WithKeepAliveInterval(50*time.Millisecond),
+			WithKeepAliveTimeout(100*time.Millisecond),
+		)
+		defer testServer.Close()
+
+		sseResp, err := http.Get(fmt.Sprintf("%s/sse", testServer.URL))
+		if err != nil {
+			t.Fatalf("Failed to connect to SSE endpoint: %v", err)
+		}
+		defer sseResp.Body.Close()
+
+		reader := bufio.NewReader(sseResp.Body)
+		var messageURL string
+		pings := 0
+		for pings < 5 {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("Connection closed after %d pings: %v", pings, err)
+			}
+			data, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if messageURL == "" {
+				messageURL = data
+				continue
+			}
+
+			var ping mcp.JSONRPCRequest
+			if err := json.Unmarshal([]byte(data), &ping); err != nil || ping.Method != "ping" {
+				continue
+			}
+			pings++
+
+			pong, err := json.Marshal(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      ping.ID,
+				"result":  map[string]any{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal ping response: %v", err)
+			}
+			resp, err := http.Post(messageURL, "application/json", bytes.NewReader(pong))
+			if err != nil {
+				t.Fatalf("Failed to send ping response: %v", err)
+			}
+			resp.Body.Close()
+		}
+	})
 }
 
 func readSSEEvent(sseResp *http.Response) (string, error) {
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -94,12 +95,14 @@ func WithQueueSize(size int) StdioOption {
 type stdioSession struct {
 	notifications       chan mcp.JSONRPCNotification
 	initialized         atomic.Bool
+	initializedNotice   atomic.Bool
 	loggingLevel        atomic.Value
 	clientInfo          atomic.Value                        // stores session-specific client info
 	clientCapabilities  atomic.Value                        // stores session-specific client capabilities
 	writer              io.Writer                           // for sending requests to client
+	cancel              context.CancelFunc                  // stops the Listen call serving the session
 	requestID           atomic.Int64                        // for generating unique request IDs
-	mu                  sync.RWMutex                        // protects writer
+	mu                  sync.RWMutex                        // protects writer and cancel
 	pendingRequests     map[int64]chan *samplingResponse    // for tracking pending sampling requests
 	pendingElicitations map[int64]chan *elicitationResponse // for tracking pending elicitation requests
 	pendingRoots        map[int64]chan *rootsResponse       // for tracking pending list roots requests
@@ -142,6 +145,24 @@ func (s *stdioSession) Initialized() bool {
 	return s.initialized.Load()
 }
 
+func (s *stdioSession) MarkClientReady() {
+	s.initializedNotice.Store(true)
+}
+
+func (s *stdioSession) ClientReady() bool {
+	return s.initializedNotice.Load()
+}
+
+// close ends the session, making the Listen call serving it return.
+func (s *stdioSession) close() {
+	s.mu.RLock()
+	cancel := s.cancel
+	s.mu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
 func (s *stdioSession) GetClientInfo() mcp.Implementation {
 	if value := s.clientInfo.Load(); value != nil {
 		if clientInfo, ok := value.(mcp.Implementation); ok {
@@ -375,12 +396,13 @@ func (s *stdioSession) SetWriter(writer io.Writer) {
 }
 
 var (
-	_ ClientSession          = (*stdioSession)(nil)
-	_ SessionWithLogging     = (*stdioSession)(nil)
-	_ SessionWithClientInfo  = (*stdioSession)(nil)
-	_ SessionWithSampling    = (*stdioSession)(nil)
-	_ SessionWithElicitation = (*stdioSession)(nil)
-	_ SessionWithRoots       = (*stdioSession)(nil)
+	_ ClientSession             = (*stdioSession)(nil)
+	_ SessionWithLogging        = (*stdioSession)(nil)
+	_ SessionWithClientInfo     = (*stdioSession)(nil)
+	_ SessionWithSampling       = (*stdioSession)(nil)
+	_ SessionWithElicitation    = (*stdioSession)(nil)
+	_ SessionWithRoots          = (*stdioSession)(nil)
+	_ SessionWithInitialization = (*stdioSession)(nil)
 )
 
 var stdioSessionInstance = stdioSession{
@@ -451,6 +473,13 @@ func (s *StdioServer) processInputStream(ctx context.Context, reader *bufio.Read
 			if err == io.EOF {
 				return nil
 			}
+			if errors.Is(err, ErrRequestTooLarge) {
+				// Tell the client why before closing the connection
+				response := createErrorResponse(nil, mcp.PARSE_ERROR, s.server.requestTooLargeMessage())
+				if writeErr := s.writeResponse(response, stdout); writeErr != nil {
+					s.errLogger.Printf("Error writing response: %v", writeErr)
+				}
+			}
 			s.errLogger.Printf("Error reading input: %v", err)
 			return err
 		}
@@ -503,7 +532,7 @@ func (s *StdioServer) readNextLine(ctx context.Context, reader *bufio.Reader) (s
 	resultCh := make(chan result, 1)
 
 	go func() {
-		line, err := reader.ReadString('\n')
+		line, err := readLimitedLine(reader, s.server.maxRequestBodySize)
 		resultCh <- result{line: line, err: err}
 	}()
 
@@ -523,6 +552,14 @@ func (s *StdioServer) Listen(
 	stdin io.Reader,
 	stdout io.Writer,
 ) error {
+	// Stop listening when the MCP server shuts down
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer s.server.onShutdown(func(context.Context) error {
+		cancel()
+		return nil
+	})()
+
 	// Initialize the tool call queue
 	s.toolCallQueue = make(chan *toolCallWork, s.queueSize)
 
@@ -535,6 +572,9 @@ func (s *StdioServer) Listen(
 
 	// Set the writer for sending requests to the client
 	stdioSessionInstance.SetWriter(stdout)
+	stdioSessionInstance.mu.Lock()
+	stdioSessionInstance.cancel = cancel
+	stdioSessionInstance.mu.Unlock()
 
 	// Add in any custom context.
 	if s.contextFunc != nil {
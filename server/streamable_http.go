@@ -164,10 +164,15 @@ type StreamableHTTPServer struct {
 	sessionResourceTemplates *sessionResourceTemplatesStore
 	sessionRequestIDs        sync.Map // sessionId --> last requestID(*atomic.Int64)
 	activeSessions           sync.Map // sessionId --> *streamableHttpSession (for sampling responses)
+	initializedSessions      sync.Map // sessionId --> struct{}, for sessions that sent notifications/initialized
 
 	httpServer *http.Server
 	mu         sync.RWMutex
 
+	// unregisterShutdown removes the hook registered by Start which shuts
+	// this server down with the MCP server.
+	unregisterShutdown func()
+
 	endpointPath             string
 	contextFunc              HTTPContextFunc
 	sessionIdManagerResolver SessionIdManagerResolver
@@ -197,11 +202,15 @@ func NewStreamableHTTPServer(server *MCPServer, opts ...StreamableHTTPOption) *S
 	for _, opt := range opts {
 		opt(s)
 	}
+
 	return s
 }
 
 // ServeHTTP implements the http.Handler interface.
 func (s *StreamableHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w, done := compressResponse(s.server, w, r)
+	defer done()
+
 	switch r.Method {
 	case http.MethodPost:
 		s.handlePost(w, r)
@@ -218,6 +227,9 @@ func (s *StreamableHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request)
 // (endpointPath). like:
 //
 //	s.Start(":8080")
+//
+// It serves HTTPS with the certificate set with WithTLSCert, or else with the
+// TLS configuration of the MCP server set with WithTLS or WithTLSFromFiles.
 func (s *StreamableHTTPServer) Start(addr string) error {
 	s.mu.Lock()
 	if s.httpServer == nil {
@@ -235,6 +247,10 @@ func (s *StreamableHTTPServer) Start(addr string) error {
 		}
 	}
 	srv := s.httpServer
+	// Close the HTTP server when the MCP server shuts down
+	if s.server != nil && s.unregisterShutdown == nil {
+		s.unregisterShutdown = s.server.onShutdown(s.Shutdown)
+	}
 	s.mu.Unlock()
 
 	if s.tlsCertFile != "" || s.tlsKeyFile != "" {
@@ -250,7 +266,7 @@ func (s *StreamableHTTPServer) Start(addr string) error {
 		return srv.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
 	}
 
-	return srv.ListenAndServe()
+	return listenAndServe(s.server, srv, false)
 }
 
 // Shutdown gracefully stops the server, closing all active sessions
@@ -258,9 +274,15 @@ func (s *StreamableHTTPServer) Start(addr string) error {
 func (s *StreamableHTTPServer) Shutdown(ctx context.Context) error {
 
 	// shutdown the server if needed (may use as a http.Handler)
-	s.mu.RLock()
+	s.mu.Lock()
 	srv := s.httpServer
-	s.mu.RUnlock()
+	unregisterShutdown := s.unregisterShutdown
+	s.unregisterShutdown = nil
+	s.mu.Unlock()
+
+	if unregisterShutdown != nil {
+		unregisterShutdown()
+	}
 	if srv != nil {
 		return srv.Shutdown(ctx)
 	}
@@ -281,8 +303,14 @@ func (s *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request
 	}
 
 	// Check the request body is valid json, meanwhile, get the request Method
+	s.server.limitRequestBody(w, r)
 	rawData, err := io.ReadAll(r.Body)
 	if err != nil {
+		if isRequestTooLarge(err) {
+			w.Header().Set("Connection", "close")
+			s.writeJSONRPCError(w, nil, mcp.PARSE_ERROR, s.server.requestTooLargeMessage())
+			return
+		}
 		s.writeJSONRPCError(w, nil, mcp.PARSE_ERROR, fmt.Sprintf("read request body error: %v", err))
 		return
 	}
@@ -366,7 +394,8 @@ func (s *StreamableHTTPServer) handlePost(w http.ResponseWriter, r *http.Request
 
 	// Create ephemeral session if no persistent session exists
 	if session == nil {
-		session = newStreamableHttpSession(sessionID, s.sessionTools, s.sessionResources, s.sessionResourceTemplates, s.sessionLogLevels)
+		session = s.newSession(sessionID)
+		session.sessionIdManager = sessionIdManager
 	}
 
 	// Set the client context before handling the message
@@ -501,7 +530,7 @@ func (s *StreamableHTTPServer) handleGet(w http.ResponseWriter, r *http.Request)
 	// Get or create session atomically to prevent TOCTOU races
 	// where concurrent GETs could both create and register duplicate sessions
 	var session *streamableHttpSession
-	newSession := newStreamableHttpSession(sessionID, s.sessionTools, s.sessionResources, s.sessionResourceTemplates, s.sessionLogLevels)
+	newSession := s.newSession(sessionID)
 	actual, loaded := s.activeSessions.LoadOrStore(sessionID, newSession)
 	session = actual.(*streamableHttpSession)
 
@@ -637,6 +666,8 @@ func (s *StreamableHTTPServer) handleGet(w http.ResponseWriter, r *http.Request)
 			flusher.Flush()
 		case <-r.Context().Done():
 			return
+		case <-session.done:
+			return
 		}
 	}
 }
@@ -655,15 +686,34 @@ func (s *StreamableHTTPServer) handleDelete(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	s.deleteSessionData(sessionID)
+	w.WriteHeader(http.StatusOK)
+}
+
+// deleteSessionData removes the data the server keeps for a session.
+func (s *StreamableHTTPServer) deleteSessionData(sessionID string) {
 	// remove the session relateddata from the sessionToolsStore
 	s.sessionTools.delete(sessionID)
 	s.sessionResources.delete(sessionID)
 	s.sessionResourceTemplates.delete(sessionID)
 	s.sessionLogLevels.delete(sessionID)
+	s.initializedSessions.Delete(sessionID)
 	// remove current session's requstID information
 	s.sessionRequestIDs.Delete(sessionID)
+}
 
-	w.WriteHeader(http.StatusOK)
+// terminateSession ends a session as a DELETE request does, and closes the
+// stream of its GET request if it has one.
+func (s *StreamableHTTPServer) terminateSession(sessionIdManager SessionIdManager, sessionID string) {
+	if sessionIdManager != nil {
+		if _, err := sessionIdManager.Terminate(sessionID); err != nil {
+			s.logger.Errorf("Failed to terminate session %s: %v", sessionID, err)
+		}
+	}
+	s.deleteSessionData(sessionID)
+	if active, ok := s.activeSessions.Load(sessionID); ok {
+		active.(*streamableHttpSession).closeStream()
+	}
 }
 
 func writeSSEEvent(w io.Writer, data any) error {
@@ -984,6 +1034,14 @@ type streamableHttpSession struct {
 
 	samplingRequests sync.Map     // requestID -> pending sampling request context
 	requestIDCounter atomic.Int64 // for generating unique request IDs
+
+	// server is the server of the session, nil for sessions made outside of it
+	server *StreamableHTTPServer
+	// sessionIdManager is the manager of the session ID of POST sessions
+	sessionIdManager SessionIdManager
+	// done is closed to end the GET stream of the session
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
 func newStreamableHttpSession(sessionID string, toolStore *sessionToolsStore, resourcesStore *sessionResourcesStore, templatesStore *sessionResourceTemplatesStore, levels *sessionLogLevelsStore) *streamableHttpSession {
@@ -997,10 +1055,18 @@ func newStreamableHttpSession(sessionID string, toolStore *sessionToolsStore, re
 		samplingRequestChan:    make(chan samplingRequestItem, 10),
 		elicitationRequestChan: make(chan elicitationRequestItem, 10),
 		rootsRequestChan:       make(chan rootsRequestItem, 10),
+		done:                   make(chan struct{}),
 	}
 	return s
 }
 
+// newSession creates a session of the server.
+func (s *StreamableHTTPServer) newSession(sessionID string) *streamableHttpSession {
+	session := newStreamableHttpSession(sessionID, s.sessionTools, s.sessionResources, s.sessionResourceTemplates, s.sessionLogLevels)
+	session.server = s
+	return session
+}
+
 func (s *streamableHttpSession) SessionID() string {
 	return s.sessionID
 }
@@ -1019,6 +1085,39 @@ func (s *streamableHttpSession) Initialized() bool {
 	return true
 }
 
+// MarkClientReady records that the client sent notifications/initialized.
+// As sessions are ephemeral, it is recorded by the server for the session ID.
+func (s *streamableHttpSession) MarkClientReady() {
+	if s.server != nil && s.sessionID != "" {
+		s.server.initializedSessions.Store(s.sessionID, struct{}{})
+	}
+}
+
+// ClientReady returns whether the client of the session ID sent
+// notifications/initialized. Sessions of stateless servers, which have no
+// ID, are always considered initialized.
+func (s *streamableHttpSession) ClientReady() bool {
+	if s.server == nil || s.sessionID == "" {
+		return true
+	}
+	_, ok := s.server.initializedSessions.Load(s.sessionID)
+	return ok
+}
+
+// close terminates the session, as a DELETE request does, and closes its GET
+// stream.
+func (s *streamableHttpSession) close() {
+	if s.server != nil {
+		s.server.terminateSession(s.sessionIdManager, s.sessionID)
+	}
+	s.closeStream()
+}
+
+// closeStream ends the GET stream of the session, if it has one.
+func (s *streamableHttpSession) closeStream() {
+	s.doneOnce.Do(func() { close(s.done) })
+}
+
 func (s *streamableHttpSession) SetLogLevel(level mcp.LoggingLevel) {
 	s.logLevels.set(s.sessionID, level)
 }
@@ -1058,6 +1157,7 @@ var (
 	_ SessionWithResources         = (*streamableHttpSession)(nil)
 	_ SessionWithResourceTemplates = (*streamableHttpSession)(nil)
 	_ SessionWithLogging           = (*streamableHttpSession)(nil)
+	_ SessionWithInitialization    = (*streamableHttpSession)(nil)
 )
 
 func (s *streamableHttpSession) UpgradeToSSEWhenReceiveNotification() {
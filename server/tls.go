@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+)
+
+// WithTLSConfig sets the TLS configuration ServeWithTLS serves with, for
+// example to require client certificates or a minimum TLS version. The
+// certificate and key passed to ServeWithTLS are added to it.
+func WithTLSConfig(config *tls.Config) ServerOption {
+	return func(s *MCPServer) {
+		s.tlsConfig = config
+	}
+}
+
+// WithTLS makes the HTTP transports of the server, SSEServer and
+// StreamableHTTPServer, serve HTTPS with config when started with Start. The
+// configuration must provide the certificate of the server, in Certificates
+// or with GetCertificate.
+func WithTLS(config *tls.Config) ServerOption {
+	return func(s *MCPServer) {
+		s.tlsConfig = config
+		s.tlsEnabled = true
+	}
+}
+
+// WithTLSFromFiles makes the HTTP transports of the server, SSEServer and
+// StreamableHTTPServer, serve HTTPS with the certificate and key in certFile
+// and keyFile when started with Start.
+func WithTLSFromFiles(certFile, keyFile string) ServerOption {
+	return func(s *MCPServer) {
+		s.tlsCertFile = certFile
+		s.tlsKeyFile = keyFile
+	}
+}
+
+// ServeWithTLS serves the server over HTTPS on addr with the Streamable HTTP
+// transport at its default endpoint path, using the certificate and key in
+// certFile and keyFile and the configuration set with WithTLSConfig. It runs
+// until ctx is cancelled, then shuts down the transport within the shutdown
+// timeout of the server and returns nil.
+func (s *MCPServer) ServeWithTLS(ctx context.Context, addr string, certFile, keyFile string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.serveTLS(ctx, listener, certFile, keyFile)
+}
+
+// serveTLS serves the server over HTTPS on listener until ctx is cancelled.
+func (s *MCPServer) serveTLS(ctx context.Context, listener net.Listener, certFile, keyFile string) error {
+	mux := http.NewServeMux()
+	httpServer := &http.Server{Handler: mux}
+	if s.tlsConfig != nil {
+		httpServer.TLSConfig = s.tlsConfig.Clone()
+	}
+	transport := NewStreamableHTTPServer(s, WithStreamableHTTPServer(httpServer))
+	mux.Handle(transport.endpointPath, transport)
+
+	return s.serveUntilDone(ctx, transport, func() error {
+		return httpServer.ServeTLS(listener, certFile, keyFile)
+	})
+}
+
+// serveUntilDone runs serve, which serves transport, until ctx is cancelled,
+// then shuts down transport within the shutdown timeout of the server.
+func (s *MCPServer) serveUntilDone(ctx context.Context, transport *StreamableHTTPServer, serve func() error) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- serve()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.ShutdownTimeout())
+		defer cancel()
+		if err := transport.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// listenAndServe serves srv, the HTTP server of a transport of server, over
+// HTTPS if TLS is configured with WithTLS or WithTLSFromFiles, and over plain
+// HTTP otherwise. With selfSigned, a server without TLS configuration
+// listening on a loopback address serves HTTPS with a self-signed
+// certificate, for development.
+func listenAndServe(server *MCPServer, srv *http.Server, selfSigned bool) error {
+	switch {
+	case server != nil && (server.tlsCertFile != "" || server.tlsKeyFile != ""):
+		if server.tlsCertFile == "" || server.tlsKeyFile == "" {
+			return fmt.Errorf("both TLS cert and key must be provided")
+		}
+		if srv.TLSConfig == nil && server.tlsConfig != nil {
+			srv.TLSConfig = server.tlsConfig.Clone()
+		}
+		return srv.ListenAndServeTLS(server.tlsCertFile, server.tlsKeyFile)
+	case server != nil && server.tlsEnabled:
+		if server.tlsConfig == nil {
+			return fmt.Errorf("TLS is enabled without a TLS configuration")
+		}
+		srv.TLSConfig = server.tlsConfig.Clone()
+		return srv.ListenAndServeTLS("", "")
+	case selfSigned && isLoopbackAddr(srv.Addr):
+		certificate, err := selfSignedCertificate()
+		if err != nil {
+			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+		if srv.TLSConfig == nil {
+			srv.TLSConfig = &tls.Config{}
+		}
+		srv.TLSConfig.Certificates = []tls.Certificate{certificate}
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
+
+// isLoopbackAddr reports whether the host of the listen address addr is
+// localhost or a loopback IP address.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// selfSignedCertificate generates a self-signed certificate for localhost and
+// the loopback IP addresses, valid for a year.
+func selfSignedCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"mcp-go development"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
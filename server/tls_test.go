@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCertificate writes the certificate and key of a TLS test server
+// to files, returning their paths and a client trusting the certificate.
+func writeTestCertificate(t *testing.T) (certFile, keyFile string, client *http.Client) {
+	t.Helper()
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	certificate := ts.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+	return certFile, keyFile, ts.Client()
+}
+
+// startTLS serves server over HTTPS on a free port until the test ends,
+// returning the URL of its endpoint.
+func startTLS(t *testing.T, server *MCPServer, certFile, keyFile string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.serveTLS(ctx, listener, certFile, keyFile)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Error("server did not stop")
+		}
+	})
+	return "https://" + listener.Addr().String() + "/mcp"
+}
+
+func TestMCPServer_ServeWithTLS(t *testing.T) {
+	certFile, keyFile, client := writeTestCertificate(t)
+	url := startTLS(t, NewMCPServer("test", "1.0.0"), certFile, keyFile)
+
+	resp, err := client.Post(url, "application/json", strings.NewReader(
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "clientInfo": {"name": "test", "version": "1.0.0"}}}`,
+	))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(HeaderKeySessionID))
+
+	// Clients not trusting the certificate are refused
+	_, err = http.Post(url, "application/json", strings.NewReader(`{}`))
+	assert.Error(t, err)
+}
+
+func TestMCPServer_ServeWithTLSConfig(t *testing.T) {
+	certFile, keyFile, client := writeTestCertificate(t)
+	server := NewMCPServer("test", "1.0.0", WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}))
+	url := startTLS(t, server, certFile, keyFile)
+
+	transport := client.Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.MaxVersion = tls.VersionTLS12
+	_, err := (&http.Client{Transport: transport}).Post(url, "application/json", strings.NewReader(`{}`))
+	assert.Error(t, err)
+
+	transport.TLSClientConfig.MaxVersion = tls.VersionTLS13
+	resp, err := (&http.Client{Transport: transport}).Post(url, "application/json", strings.NewReader(
+		`{"jsonrpc": "2.0", "id": 1, "method": "ping"}`,
+	))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, tls.VersionTLS13, int(resp.TLS.Version))
+}
+
+func TestMCPServer_ServeWithTLSMissingCertificate(t *testing.T) {
+	dir := t.TempDir()
+	err := NewMCPServer("test", "1.0.0").ServeWithTLS(context.Background(), "127.0.0.1:0",
+		filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	assert.Error(t, err)
+}
+
+// freeLoopbackAddr returns a free address on the loopback interface.
+func freeLoopbackAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return addr
+}
+
+// waitForGet gets url with client until the server answers.
+func waitForGet(t *testing.T, client *http.Client, url string) *http.Response {
+	t.Helper()
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		var err error
+		resp, err = client.Get(url)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	return resp
+}
+
+func TestSSEServer_StartSelfSigned(t *testing.T) {
+	addr := freeLoopbackAddr(t)
+	sseServer := NewSSEServer(NewMCPServer("test", "1.0.0"), WithSelfSignedDevCertificate())
+	go func() {
+		_ = sseServer.Start(addr)
+	}()
+	defer sseServer.Shutdown(context.Background())
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp := waitForGet(t, client, "https://"+addr+"/sse")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, resp.TLS)
+	assert.Equal(t, []string{"localhost"}, resp.TLS.PeerCertificates[0].DNSNames)
+
+	// The self-signed certificate is not trusted by default
+	_, err := http.Get("https://" + addr + "/sse")
+	assert.Error(t, err)
+}
+
+func TestSSEServer_StartServesHTTPByDefault(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []SSEOption
+	}{
+		{name: "without options"},
+		{name: "self-signed with http base URL", options: []SSEOption{WithSelfSignedDevCertificate(), WithBaseURL("http://localhost")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := freeLoopbackAddr(t)
+			sseServer := NewSSEServer(NewMCPServer("test", "1.0.0"), tt.options...)
+			go func() {
+				_ = sseServer.Start(addr)
+			}()
+			defer sseServer.Shutdown(context.Background())
+
+			resp := waitForGet(t, http.DefaultClient, "http://"+addr+"/sse")
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Nil(t, resp.TLS)
+		})
+	}
+}
+
+func TestStreamableHTTPServer_StartWithTLSFromFiles(t *testing.T) {
+	certFile, keyFile, client := writeTestCertificate(t)
+	addr := freeLoopbackAddr(t)
+	httpServer := NewStreamableHTTPServer(NewMCPServer("test", "1.0.0", WithTLSFromFiles(certFile, keyFile)))
+	go func() {
+		_ = httpServer.Start(addr)
+	}()
+	defer httpServer.Shutdown(context.Background())
+
+	resp := waitForGet(t, client, "https://"+addr+"/mcp")
+	resp.Body.Close()
+	assert.NotNil(t, resp.TLS)
+}
+
+func TestStreamableHTTPServer_StartWithTLS(t *testing.T) {
+	certFile, keyFile, client := writeTestCertificate(t)
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+	addr := freeLoopbackAddr(t)
+	config := &tls.Config{Certificates: []tls.Certificate{certificate}}
+	httpServer := NewStreamableHTTPServer(NewMCPServer("test", "1.0.0", WithTLS(config)))
+	go func() {
+		_ = httpServer.Start(addr)
+	}()
+	defer httpServer.Shutdown(context.Background())
+
+	resp := waitForGet(t, client, "https://"+addr+"/mcp")
+	resp.Body.Close()
+	assert.NotNil(t, resp.TLS)
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	assert.True(t, isLoopbackAddr("localhost:8080"))
+	assert.True(t, isLoopbackAddr("127.0.0.1:8080"))
+	assert.True(t, isLoopbackAddr("[::1]:8080"))
+	assert.False(t, isLoopbackAddr(":8080"))
+	assert.False(t, isLoopbackAddr("0.0.0.0:8080"))
+	assert.False(t, isLoopbackAddr("example.com:443"))
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolGroup namespaces related tools by prefixing their names, so that a
+// server exposing many tools stays easy to navigate. For example, the search
+// tool of a group with the prefix "github_" is exposed as github_search, and
+// clients can find all tools of the group by that prefix.
+type ToolGroup struct {
+	// Prefix is prepended to the name of every tool registered with the group.
+	Prefix string
+
+	tools []ServerTool
+}
+
+// NewToolGroup creates an empty tool group with the given name prefix.
+func NewToolGroup(prefix string) *ToolGroup {
+	return &ToolGroup{Prefix: prefix}
+}
+
+// Register adds a tool to the group under its prefixed name. Tools are
+// exposed by the server once the group is added with AddToolGroup.
+func (g *ToolGroup) Register(tool mcp.Tool, handler ToolHandlerFunc) {
+	tool.Name = g.Prefix + tool.Name
+	g.tools = append(g.tools, ServerTool{Tool: tool, Handler: handler})
+}
+
+// Tools returns the tools registered with the group, with prefixed names.
+func (g *ToolGroup) Tools() []ServerTool {
+	return slices.Clone(g.tools)
+}
+
+// Contains reports whether toolName belongs to the group by its prefix.
+func (g *ToolGroup) Contains(toolName string) bool {
+	return strings.HasPrefix(toolName, g.Prefix)
+}
+
+// AddToolGroup registers all tools of the group with the server and sends a
+// single tools/list_changed notification.
+func (s *MCPServer) AddToolGroup(group *ToolGroup) {
+	s.toolGroupsMu.Lock()
+	if !slices.Contains(s.toolGroups, group) {
+		s.toolGroups = append(s.toolGroups, group)
+	}
+	s.toolGroupsMu.Unlock()
+
+	s.AddTools(group.Tools()...)
+}
+
+// ToolGroups returns the tool groups added to the server, in the order they
+// were added.
+func (s *MCPServer) ToolGroups() []*ToolGroup {
+	s.toolGroupsMu.RLock()
+	defer s.toolGroupsMu.RUnlock()
+	return slices.Clone(s.toolGroups)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolGroup_Register(t *testing.T) {
+	group := NewToolGroup("github_")
+	group.Register(mcp.NewTool("search", mcp.WithDescription("Search repositories")), textToolHandler("search"))
+	group.Register(mcp.NewTool("issues"), textToolHandler("issues"))
+
+	tools := group.Tools()
+	require.Len(t, tools, 2)
+	assert.Equal(t, "github_search", tools[0].Tool.Name)
+	assert.Equal(t, "Search repositories", tools[0].Tool.Description)
+	assert.Equal(t, "github_issues", tools[1].Tool.Name)
+
+	assert.True(t, group.Contains("github_search"))
+	assert.False(t, group.Contains("search"))
+}
+
+func TestMCPServer_AddToolGroup(t *testing.T) {
+	s := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(true))
+	notifications := make(chan mcp.JSONRPCNotification, 10)
+	require.NoError(t, s.RegisterSession(context.Background(), &fakeSession{
+		sessionID:           "test",
+		notificationChannel: notifications,
+		initialized:         true,
+	}))
+
+	github := NewToolGroup("github_")
+	github.Register(mcp.NewTool("search"), textToolHandler("github search"))
+	github.Register(mcp.NewTool("issues"), textToolHandler("github issues"))
+	jira := NewToolGroup("jira_")
+	jira.Register(mcp.NewTool("search"), textToolHandler("jira search"))
+
+	s.AddToolGroup(github)
+	assert.Equal(t, []string{mcp.MethodNotificationToolsListChanged}, drainNotifications(notifications))
+	s.AddToolGroup(jira)
+	assert.Equal(t, []string{mcp.MethodNotificationToolsListChanged}, drainNotifications(notifications))
+
+	assert.Len(t, s.ListTools(), 3)
+	assert.Equal(t, "github search", callToolText(t, s, "github_search"))
+	assert.Equal(t, "jira search", callToolText(t, s, "jira_search"))
+
+	groups := s.ToolGroups()
+	require.Len(t, groups, 2)
+	assert.Equal(t, "github_", groups[0].Prefix)
+	assert.Equal(t, "jira_", groups[1].Prefix)
+
+	var inGroup []string
+	for name := range s.ListTools() {
+		if groups[0].Contains(name) {
+			inGroup = append(inGroup, name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"github_search", "github_issues"}, inGroup)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolProvider supplies tools and their handlers, for example from a plugin.
+type ToolProvider interface {
+	// Tools returns the tools currently offered by the provider.
+	Tools() ([]mcp.Tool, error)
+	// Handler returns the handler of the named tool.
+	Handler(name string) (ToolHandlerFunc, error)
+}
+
+// toolProviderEntry is a registered provider with the names of the tools it
+// registered most recently.
+type toolProviderEntry struct {
+	provider ToolProvider
+	names    []string
+}
+
+// RegisterToolProvider registers the tools of provider with the server. The
+// provider is queried again by Reload.
+func (s *MCPServer) RegisterToolProvider(provider ToolProvider) error {
+	tools, err := loadProviderTools(provider)
+	if err != nil {
+		return err
+	}
+
+	s.providersMu.Lock()
+	s.toolProviders = append(s.toolProviders, &toolProviderEntry{
+		provider: provider,
+		names:    serverToolNames(tools),
+	})
+	s.providersMu.Unlock()
+
+	s.AddTools(tools...)
+	return nil
+}
+
+// loadProviderTools queries provider for its tools and their handlers.
+func loadProviderTools(provider ToolProvider) ([]ServerTool, error) {
+	tools, err := provider.Tools()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider tools: %w", err)
+	}
+
+	serverTools := make([]ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		handler, err := provider.Handler(tool.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get handler for tool %q: %w", tool.Name, err)
+		}
+		if handler == nil {
+			return nil, fmt.Errorf("tool %q has no handler: %w", tool.Name, ErrInvalidRegistration)
+		}
+		serverTools = append(serverTools, ServerTool{Tool: tool, Handler: handler})
+	}
+	return serverTools, nil
+}
+
+func serverToolNames(tools []ServerTool) []string {
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Tool.Name
+	}
+	return names
+}
+
+// StaticToolProvider is a ToolProvider offering a fixed set of tools.
+type StaticToolProvider struct {
+	tools []ServerTool
+}
+
+// NewStaticToolProvider creates a ToolProvider offering the given tools.
+func NewStaticToolProvider(tools ...ServerTool) *StaticToolProvider {
+	return &StaticToolProvider{tools: tools}
+}
+
+// Tools implements ToolProvider.
+func (p *StaticToolProvider) Tools() ([]mcp.Tool, error) {
+	tools := make([]mcp.Tool, len(p.tools))
+	for i, tool := range p.tools {
+		tools[i] = tool.Tool
+	}
+	return tools, nil
+}
+
+// Handler implements ToolProvider.
+func (p *StaticToolProvider) Handler(name string) (ToolHandlerFunc, error) {
+	for _, tool := range p.tools {
+		if tool.Tool.Name == name {
+			return tool.Handler, nil
+		}
+	}
+	return nil, fmt.Errorf("tool %q: %w", name, ErrToolNotFound)
+}
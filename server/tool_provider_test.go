@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func textToolHandler(text string) ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+// switchingToolProvider delegates to a provider that can be replaced.
+type switchingToolProvider struct {
+	mu       sync.Mutex
+	provider ToolProvider
+	err      error
+}
+
+func (p *switchingToolProvider) set(provider ToolProvider, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.provider, p.err = provider, err
+}
+
+func (p *switchingToolProvider) Tools() ([]mcp.Tool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.provider.Tools()
+}
+
+func (p *switchingToolProvider) Handler(name string) (ToolHandlerFunc, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.provider.Handler(name)
+}
+
+func callToolText(t *testing.T, s *MCPServer, name string) string {
+	t.Helper()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	tool := s.GetTool(name)
+	require.NotNil(t, tool, "tool %q is not registered", name)
+	result, err := tool.Handler(context.Background(), request)
+	require.NoError(t, err)
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestMCPServer_RegisterToolProvider(t *testing.T) {
+	s := NewMCPServer("test-server", "1.0.0")
+	provider := NewStaticToolProvider(
+		ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a")},
+		ServerTool{Tool: mcp.NewTool("beta"), Handler: textToolHandler("b")},
+	)
+	require.NoError(t, s.RegisterToolProvider(provider))
+
+	assert.Len(t, s.ListTools(), 2)
+	assert.Equal(t, "a", callToolText(t, s, "alpha"))
+	assert.Equal(t, "b", callToolText(t, s, "beta"))
+
+	_, err := provider.Handler("gamma")
+	assert.ErrorIs(t, err, ErrToolNotFound)
+}
+
+func TestMCPServer_RegisterToolProviderErrors(t *testing.T) {
+	s := NewMCPServer("test-server", "1.0.0")
+
+	failing := &switchingToolProvider{}
+	failing.set(nil, errors.New("plugin unavailable"))
+	assert.ErrorContains(t, s.RegisterToolProvider(failing), "plugin unavailable")
+
+	missingHandler := &switchingToolProvider{}
+	missingHandler.set(NewStaticToolProvider(ServerTool{Tool: mcp.NewTool("nil-handler")}), nil)
+	assert.ErrorIs(t, s.RegisterToolProvider(missingHandler), ErrInvalidRegistration)
+
+	assert.Empty(t, s.ListTools())
+}
+
+func TestMCPServer_Reload(t *testing.T) {
+	s := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("builtin"), textToolHandler("builtin"))
+
+	provider := &switchingToolProvider{}
+	provider.set(NewStaticToolProvider(
+		ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a1")},
+		ServerTool{Tool: mcp.NewTool("beta"), Handler: textToolHandler("b1")},
+	), nil)
+	require.NoError(t, s.RegisterToolProvider(provider))
+	assert.Len(t, s.ListTools(), 3)
+
+	// The provider drops beta, changes alpha and adds gamma
+	provider.set(NewStaticToolProvider(
+		ServerTool{Tool: mcp.NewTool("alpha"), Handler: textToolHandler("a2")},
+		ServerTool{Tool: mcp.NewTool("gamma"), Handler: textToolHandler("c2")},
+	), nil)
+	require.NoError(t, s.Reload())
+
+	assert.Len(t, s.ListTools(), 3)
+	assert.Nil(t, s.GetTool("beta"))
+	assert.Equal(t, "a2", callToolText(t, s, "alpha"))
+	assert.Equal(t, "c2", callToolText(t, s, "gamma"))
+	assert.Equal(t, "builtin", callToolText(t, s, "builtin"))
+
+	// A failing provider leaves the tools untouched
+	provider.set(nil, errors.New("plugin crashed"))
+	assert.ErrorContains(t, s.Reload(), "plugin crashed")
+	assert.Len(t, s.ListTools(), 3)
+	assert.Equal(t, "a2", callToolText(t, s, "alpha"))
+}
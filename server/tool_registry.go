@@ -0,0 +1,106 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolRegistry holds tools and their handlers by name. It is safe for
+// concurrent use, so that tools can be registered and removed, for example
+// while hot-reloading, as they are being called.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]ServerTool
+}
+
+// NewToolRegistry creates an empty tool registry.
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{tools: make(map[string]ServerTool)}
+}
+
+// Register adds a tool, replacing any tool with the same name.
+func (r *ToolRegistry) Register(tool mcp.Tool, handler ToolHandlerFunc) {
+	r.registerAll(ServerTool{Tool: tool, Handler: handler})
+}
+
+// Deregister removes the tool with the given name and reports whether it was
+// registered.
+func (r *ToolRegistry) Deregister(name string) bool {
+	return r.deregisterAll(name)
+}
+
+// Lookup returns the tool with the given name and its handler.
+func (r *ToolRegistry) Lookup(name string) (mcp.Tool, ToolHandlerFunc, bool) {
+	tool, ok := r.get(name)
+	return tool.Tool, tool.Handler, ok
+}
+
+// List returns the registered tools sorted by name.
+func (r *ToolRegistry) List() []mcp.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tools := make([]mcp.Tool, 0, len(r.tools))
+	for _, tool := range r.tools {
+		tools = append(tools, tool.Tool)
+	}
+	slices.SortFunc(tools, func(a, b mcp.Tool) int { return strings.Compare(a.Name, b.Name) })
+	return tools
+}
+
+// registerAll adds the tools at once.
+func (r *ToolRegistry) registerAll(tools ...ServerTool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, tool := range tools {
+		r.tools[tool.Tool.Name] = tool
+	}
+}
+
+// deregisterAll removes the tools with the given names at once and reports
+// whether any of them was registered.
+func (r *ToolRegistry) deregisterAll(names ...string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	removed := false
+	for _, name := range names {
+		if _, ok := r.tools[name]; ok {
+			delete(r.tools, name)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// clear removes all tools.
+func (r *ToolRegistry) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.tools)
+}
+
+// get returns the tool with the given name along with its handler.
+func (r *ToolRegistry) get(name string) (ServerTool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+// snapshot returns a copy of the registered tools by name.
+func (r *ToolRegistry) snapshot() map[string]ServerTool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.tools)
+}
+
+// update calls fn with the registered tools by name, which fn may change,
+// holding the lock so that the changes are seen at once.
+func (r *ToolRegistry) update(fn func(tools map[string]ServerTool)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(r.tools)
+}
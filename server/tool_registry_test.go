@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolRegistry(t *testing.T) {
+	registry := NewToolRegistry()
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(request.Params.Name), nil
+	}
+
+	registry.Register(mcp.NewTool("b"), handler)
+	registry.Register(mcp.NewTool("a"), handler)
+	registry.Register(mcp.NewTool("a", mcp.WithDescription("replaced")), handler)
+
+	tools := registry.List()
+	require.Len(t, tools, 2)
+	assert.Equal(t, "a", tools[0].Name)
+	assert.Equal(t, "replaced", tools[0].Description)
+	assert.Equal(t, "b", tools[1].Name)
+
+	tool, h, ok := registry.Lookup("b")
+	require.True(t, ok)
+	assert.Equal(t, "b", tool.Name)
+	assert.NotNil(t, h)
+
+	assert.True(t, registry.Deregister("b"))
+	assert.False(t, registry.Deregister("b"))
+	_, _, ok = registry.Lookup("b")
+	assert.False(t, ok)
+	assert.Len(t, registry.List(), 1)
+}
+
+func TestMCPServer_ConcurrentToolRegistration(t *testing.T) {
+	server := NewMCPServer("test-server", "1.0.0", WithToolCapabilities(false))
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(request.Params.Name), nil
+	}
+	server.AddTool(mcp.NewTool("stable"), handler)
+
+	const workers = 8
+	const iterations = 200
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(3)
+		name := fmt.Sprintf("tool-%d", w)
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				server.AddTool(mcp.NewTool(name), handler)
+				server.DeleteTools(name)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				response := server.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+					"jsonrpc": "2.0",
+					"id": %d,
+					"method": "tools/call",
+					"params": {"name": "stable"}
+				}`, i)))
+				_, ok := response.(mcp.JSONRPCResponse)
+				assert.True(t, ok, "unexpected response %#v", response)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				response := server.HandleMessage(context.Background(), []byte(fmt.Sprintf(`{
+					"jsonrpc": "2.0",
+					"id": %d,
+					"method": "tools/list"
+				}`, i)))
+				_, ok := response.(mcp.JSONRPCResponse)
+				assert.True(t, ok, "unexpected response %#v", response)
+			}
+		}()
+	}
+	wg.Wait()
+
+	tools := server.ListTools()
+	require.Len(t, tools, 1)
+	assert.Contains(t, tools, "stable")
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ValidateAllRegistrations checks every registered tool, resource, resource
+// template and prompt for definitions that clients cannot use, such as missing
+// names, missing handlers or invalid input schemas. All problems found are
+// returned together, each wrapping ErrInvalidRegistration. It returns nil if
+// every registration is valid.
+func (s *MCPServer) ValidateAllRegistrations() error {
+	var errs []error
+
+	// Keys are sorted so that problems are reported in a stable order
+	tools := s.tools.snapshot()
+	for _, name := range slices.Sorted(maps.Keys(tools)) {
+		errs = append(errs, validateTool(name, tools[name])...)
+	}
+
+	s.resourcesMu.RLock()
+	for _, uri := range slices.Sorted(maps.Keys(s.resources)) {
+		entry := s.resources[uri]
+		if uri == "" {
+			errs = append(errs, fmt.Errorf("resource with empty URI: %w", ErrInvalidRegistration))
+		}
+		if entry.resource.Name == "" {
+			errs = append(errs, fmt.Errorf("resource '%s' has no name: %w", uri, ErrInvalidRegistration))
+		}
+		if entry.handler == nil {
+			errs = append(errs, fmt.Errorf("resource '%s' has no handler: %w", uri, ErrInvalidRegistration))
+		}
+	}
+	for _, uriTemplate := range slices.Sorted(maps.Keys(s.resourceTemplates)) {
+		entry := s.resourceTemplates[uriTemplate]
+		if entry.template.URITemplate == nil || entry.template.URITemplate.Template == nil {
+			errs = append(errs, fmt.Errorf("resource template '%s' has no URI template: %w", uriTemplate, ErrInvalidRegistration))
+		}
+		if entry.template.Name == "" {
+			errs = append(errs, fmt.Errorf("resource template '%s' has no name: %w", uriTemplate, ErrInvalidRegistration))
+		}
+		if entry.handler == nil {
+			errs = append(errs, fmt.Errorf("resource template '%s' has no handler: %w", uriTemplate, ErrInvalidRegistration))
+		}
+	}
+	s.resourcesMu.RUnlock()
+
+	s.promptsMu.RLock()
+	for _, name := range slices.Sorted(maps.Keys(s.prompts)) {
+		prompt := s.prompts[name]
+		if name == "" {
+			errs = append(errs, fmt.Errorf("prompt with empty name: %w", ErrInvalidRegistration))
+		}
+		if s.promptHandlers[name] == nil {
+			errs = append(errs, fmt.Errorf("prompt '%s' has no handler: %w", name, ErrInvalidRegistration))
+		}
+		seen := make(map[string]bool, len(prompt.Arguments))
+		for _, arg := range prompt.Arguments {
+			if arg.Name == "" {
+				errs = append(errs, fmt.Errorf("prompt '%s' has an argument with empty name: %w", name, ErrInvalidRegistration))
+				continue
+			}
+			if seen[arg.Name] {
+				errs = append(errs, fmt.Errorf("prompt '%s' has duplicate argument '%s': %w", name, arg.Name, ErrInvalidRegistration))
+			}
+			seen[arg.Name] = true
+		}
+	}
+	s.promptsMu.RUnlock()
+
+	return errors.Join(errs...)
+}
+
+// validateTool returns the problems with a single registered tool.
+func validateTool(name string, tool ServerTool) []error {
+	var errs []error
+
+	if strings.TrimSpace(name) == "" {
+		errs = append(errs, fmt.Errorf("tool with empty name: %w", ErrInvalidRegistration))
+	}
+	if tool.Handler == nil {
+		errs = append(errs, fmt.Errorf("tool '%s' has no handler: %w", name, ErrInvalidRegistration))
+	}
+
+	if tool.Tool.RawInputSchema == nil && tool.Tool.InputSchema.Type != "object" {
+		errs = append(errs, fmt.Errorf("tool '%s' input schema must be of type object, got %q: %w",
+			name, tool.Tool.InputSchema.Type, ErrInvalidRegistration))
+	}
+	if tool.Tool.RawInputSchema != nil && !json.Valid(tool.Tool.RawInputSchema) {
+		errs = append(errs, fmt.Errorf("tool '%s' raw input schema is not valid JSON: %w", name, ErrInvalidRegistration))
+	}
+	if tool.Tool.RawOutputSchema != nil && !json.Valid(tool.Tool.RawOutputSchema) {
+		errs = append(errs, fmt.Errorf("tool '%s' raw output schema is not valid JSON: %w", name, ErrInvalidRegistration))
+	}
+
+	// Marshaling catches conflicts such as both a structured and a raw schema being set
+	if _, err := json.Marshal(tool.Tool); err != nil {
+		errs = append(errs, fmt.Errorf("tool '%s' cannot be encoded: %v: %w", name, err, ErrInvalidRegistration))
+	}
+
+	inputSchema := tool.Tool.RawInputSchema
+	if inputSchema == nil {
+		inputSchema, _ = json.Marshal(tool.Tool.InputSchema)
+	}
+	if json.Valid(inputSchema) {
+		if err := mcp.ValidateSchemaRefs(inputSchema); err != nil {
+			errs = append(errs, fmt.Errorf("tool '%s' input schema has invalid references: %v: %w", name, err, ErrInvalidRegistration))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPServer_ValidateAllRegistrations(t *testing.T) {
+	toolHandler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, nil
+	}
+	promptHandler := func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return nil, nil
+	}
+
+	tests := []struct {
+		name     string
+		register func(s *MCPServer)
+		errors   []string
+	}{
+		{
+			name: "valid registrations",
+			register: func(s *MCPServer) {
+				s.AddTool(mcp.NewTool("tool"), toolHandler)
+				s.AddTool(mcp.NewToolWithRawSchema("raw", "", json.RawMessage(`{"type": "object"}`)), toolHandler)
+				s.AddPrompt(mcp.NewPrompt("prompt", mcp.WithArgument("arg")), promptHandler)
+			},
+		},
+		{
+			name: "tool without handler",
+			register: func(s *MCPServer) {
+				s.AddTool(mcp.NewTool("tool"), nil)
+			},
+			errors: []string{"tool 'tool' has no handler"},
+		},
+		{
+			name: "tool with non-object schema",
+			register: func(s *MCPServer) {
+				s.AddTool(mcp.Tool{Name: "tool", InputSchema: mcp.ToolInputSchema{Type: "string"}}, toolHandler)
+			},
+			errors: []string{`tool 'tool' input schema must be of type object, got "string"`},
+		},
+		{
+			name: "tool with conflicting schemas",
+			register: func(s *MCPServer) {
+				tool := mcp.NewTool("tool")
+				tool.RawInputSchema = json.RawMessage(`{"type": "object"}`)
+				s.AddTool(tool, toolHandler)
+			},
+			errors: []string{"tool 'tool' cannot be encoded"},
+		},
+		{
+			name: "tool with undefined schema reference",
+			register: func(s *MCPServer) {
+				s.AddTool(mcp.NewToolWithRawSchema("tool", "", json.RawMessage(`{
+					"type": "object",
+					"properties": {"page": {"$ref": "#/$defs/Pagination"}}
+				}`)), toolHandler)
+			},
+			errors: []string{`tool 'tool' input schema has invalid references: $ref "#/$defs/Pagination": no subschema at this location`},
+		},
+		{
+			name: "tool with reference cycle",
+			register: func(s *MCPServer) {
+				s.AddTool(mcp.NewToolWithRawSchema("tool", "", json.RawMessage(`{
+					"type": "object",
+					"$defs": {"A": {"$ref": "#/$defs/A"}},
+					"properties": {"a": {"$ref": "#/$defs/A"}}
+				}`)), toolHandler)
+			},
+			errors: []string{`tool 'tool' input schema has invalid references: $ref "#/$defs/A" is part of a reference cycle`},
+		},
+		{
+			name: "resource without name",
+			register: func(s *MCPServer) {
+				s.AddResource(mcp.Resource{URI: "test://resource"}, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+					return nil, nil
+				})
+			},
+			errors: []string{"resource 'test://resource' has no name"},
+		},
+		{
+			name: "prompt with duplicate arguments and missing handler",
+			register: func(s *MCPServer) {
+				s.AddPrompt(mcp.NewPrompt("prompt", mcp.WithArgument("arg"), mcp.WithArgument("arg")), nil)
+			},
+			errors: []string{
+				"prompt 'prompt' has no handler",
+				"prompt 'prompt' has duplicate argument 'arg'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewMCPServer("test-server", "1.0.0")
+			tt.register(server)
+
+			err := server.ValidateAllRegistrations()
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidRegistration)
+			for _, msg := range tt.errors {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}